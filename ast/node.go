@@ -0,0 +1,590 @@
+/*
+Package ast declares types representing a JavaScript AST.
+
+The tree is produced by the parser (see github.com/robertkrimen/otto/parser) and
+is the same tree the otto runtime evaluates, so tools built on top of it (linters,
+policy checks, etc.) see exactly the grammar that the interpreter accepts.
+
+Warning
+
+The parser and AST interfaces are still evolving and may change in the future.
+
+*/
+package ast
+
+import (
+	"github.com/robertkrimen/otto/file"
+)
+
+// Node is implemented by every node in the tree.
+type Node interface {
+	Idx0() file.Idx // The index of the first character belonging to the node
+	Idx1() file.Idx // The index of the first character immediately after the node
+}
+
+// Span is embedded in every node to record the part of the source that it came from.
+//
+// Use the File of the Program to translate an Idx into a file.Position (filename, line, column, etc.)
+type Span struct {
+	From file.Idx // The index of the first character belonging to the node
+	To   file.Idx // The index of the first character immediately after the node
+}
+
+func (self *Span) Idx0() file.Idx {
+	return self.From
+}
+
+func (self *Span) Idx1() file.Idx {
+	return self.To
+}
+
+// ========== //
+// Expression //
+// ========== //
+
+type (
+	// Expression is implemented by every expression node.
+	Expression interface {
+		Node
+		_expressionNode()
+	}
+
+	// ArrayLiteral is an array initializer, e.g. [ 1, , 3 ].
+	// An elided element (a hole) is represented by a nil Expression.
+	ArrayLiteral struct {
+		Span
+		Value []Expression
+	}
+
+	// ArrayPattern is an array destructuring pattern, e.g. [ abc, , def = 1, ...ghi ],
+	// in a declaration, a parameter, or (as the Left of an AssignExpression) an assignment.
+	// An elided element (a hole) is represented by a nil Expression.
+	ArrayPattern struct {
+		Span
+		Elements []Expression
+		Rest     Expression // nil, if there is no rest element (...ghi)
+	}
+
+	// AssignExpression is a simple or compound assignment, e.g. abc += 1.
+	// Operator is the assignment operator as written (=, +=, >>>=, ...).
+	AssignExpression struct {
+		Span
+		Operator string
+		Left     Expression
+		Right    Expression
+	}
+
+	// AssignmentPattern is a target with a default, e.g. abc = 1 in [ abc = 1 ] = def,
+	// where the Initializer is used if the value (being destructured) is undefined.
+	AssignmentPattern struct {
+		Span
+		Target      Expression
+		Initializer Expression
+	}
+
+	// AwaitExpression is await (in an async function), e.g. await abc, which waits
+	// until the promise abc is settled.
+	AwaitExpression struct {
+		Span
+		Argument Expression
+	}
+
+	// BinaryExpression is any binary operation, including the logical
+	// (&&, ||), relational (<, instanceof, in, ...) and equality (==, !==, ...) operators.
+	BinaryExpression struct {
+		Span
+		Operator string
+		Left     Expression
+		Right    Expression
+	}
+
+	BooleanLiteral struct {
+		Span
+		Literal string
+		Value   bool
+	}
+
+	// BracketExpression is a computed member access, e.g. abc[def].
+	BracketExpression struct {
+		Span
+		Left   Expression
+		Member Expression
+	}
+
+	// CallExpression is a call, e.g. abc(def). The Callee of a super
+	// call, e.g. super(abc), is a *SuperExpression.
+	CallExpression struct {
+		Span
+		Callee       Expression
+		ArgumentList []Expression
+	}
+
+	// ClassLiteral is a class expression, or the class of a class declaration.
+	ClassLiteral struct {
+		Span
+		Name        *Identifier      // nil, if anonymous
+		SuperClass  Expression       // nil, if the class does not extend another
+		Constructor *FunctionLiteral // nil, if the class does not have a constructor (of its own)
+		Body        []*ClassElement  // The methods, getters, and setters (other than the constructor)
+	}
+
+	ConditionalExpression struct {
+		Span
+		Test       Expression
+		Consequent Expression
+		Alternate  Expression
+	}
+
+	// DotExpression is a member access by name, e.g. abc.def.
+	DotExpression struct {
+		Span
+		Left       Expression
+		Identifier string
+	}
+
+	// FunctionLiteral is a function expression (or arrow function), or the function of a function declaration.
+	FunctionLiteral struct {
+		Span
+		Name          *Identifier // nil, if anonymous
+		ParameterList []*Identifier
+		Body          *BlockStatement
+
+		// ParameterPatternList is the pattern of each parameter that is destructured, or
+		// has a default (an AssignmentPattern), e.g. function({ abc }, def = 1) {}, where
+		// the parameter (in ParameterList) has an empty Name. It is nil if every parameter
+		// is just a name, and otherwise has a nil element for each parameter that is.
+		ParameterPatternList []Expression
+
+		// Rest is the rest parameter, e.g. ...def in function(abc, ...def) {}, which is
+		// not in the ParameterList (nil, if there is no rest parameter).
+		Rest Expression
+
+		// DeclarationList is every var and function declaration in the
+		// body (not including nested functions), in source order.
+		DeclarationList []Declaration
+
+		// Strict is true if the function is strict mode code: it has a "use strict"
+		// directive, or is nested in strict mode code.
+		Strict bool
+
+		// Arrow is true for an arrow function, e.g. (abc, def) => abc + def. The Body
+		// of an arrow function with an expression body is a single return statement.
+		Arrow bool
+
+		// Generator is true for a generator function, e.g. function* abc() { yield 1; }
+		Generator bool
+
+		// Async is true for an async function, e.g. async function abc() { await def; }
+		Async bool
+	}
+
+	Identifier struct {
+		Span
+		Name string
+	}
+
+	NewExpression struct {
+		Span
+		Callee       Expression
+		ArgumentList []Expression
+	}
+
+	NullLiteral struct {
+		Span
+		Literal string
+	}
+
+	NumberLiteral struct {
+		Span
+		Literal string
+		Value   float64
+	}
+
+	ObjectLiteral struct {
+		Span
+		Value []*Property
+	}
+
+	// ObjectPattern is an object destructuring pattern, e.g. { abc, def: ghi = 1, ...jkl }.
+	// Each property has the Kind "value", with the target (which may be an
+	// AssignmentPattern) as its Value.
+	ObjectPattern struct {
+		Span
+		Properties []*Property
+		Rest       Expression // nil, if there is no rest element (...jkl)
+	}
+
+	// RegExpLiteral is a regular expression literal, e.g. /abc/gi.
+	RegExpLiteral struct {
+		Span
+		Pattern string
+		Flags   string
+	}
+
+	// SequenceExpression is a comma-separated list of expressions.
+	SequenceExpression struct {
+		Span
+		Sequence []Expression
+	}
+
+	// StringLiteral is a string literal. Value has escapes, etc. already interpreted.
+	StringLiteral struct {
+		Span
+		Value string
+	}
+
+	// SuperExpression is super, which is only ever the Callee of a CallExpression,
+	// e.g. super(abc), or the Left of a DotExpression or BracketExpression, e.g. super.abc.
+	// SpreadElement is ...abc in an array literal, e.g. [ 1, ...abc ], or in the arguments
+	// of a call, e.g. def(...abc), where the elements of abc are spread out. In an array
+	// literal that is the target of an assignment, it is the rest element (see ArrayPattern).
+	SpreadElement struct {
+		Span
+		Argument Expression
+	}
+
+	SuperExpression struct {
+		Span
+	}
+
+	// TemplateLiteral is a template literal, e.g. `abc ${def} ghi`, or a tagged
+	// template, e.g. jkl`abc ${def} ghi` (in which case Tag is jkl).
+	//
+	// There is always one more element than there are expressions: the text
+	// before the first substitution, between each, and after the last.
+	TemplateLiteral struct {
+		Span
+		Tag         Expression // nil, if not tagged
+		Elements    []*TemplateElement
+		Expressions []Expression
+	}
+
+	ThisExpression struct {
+		Span
+	}
+
+	// UnaryExpression is a prefix or postfix operation, e.g. !abc, typeof abc, abc++.
+	UnaryExpression struct {
+		Span
+		Operator string
+		Operand  Expression
+		Postfix  bool // abc++ or abc--
+	}
+
+	// VariableExpression is a single declaration in a var statement, a let or const
+	// declaration, or in the head of a for/for-in loop.
+	VariableExpression struct {
+		Span
+		Name        string
+		Pattern     Expression // The pattern of a destructuring declaration (when Name is empty), e.g. var [ abc, def ] = ghi
+		Initializer Expression // nil, if there is no initializer
+	}
+
+	// YieldExpression is yield (in a generator function), e.g. yield abc, or yield* abc
+	// (if Delegate), which yields each value of the iterable abc in turn.
+	YieldExpression struct {
+		Span
+		Argument Expression // nil, if there is no argument
+		Delegate bool
+	}
+)
+
+// TemplateElement is the text of a template literal before, between, or after
+// the substitutions. Value has escapes, etc. already interpreted (the cooked
+// string), while Raw is the text as written (with line terminators normalized to \n).
+type TemplateElement struct {
+	Span
+	Value string
+	Raw   string
+}
+
+// ClassElement is a single method, getter, or setter of a class.
+//
+// Kind is "method" for a method (abc() {}), or "get" or "set" for a
+// getter or setter (get abc() {}, set abc(def) {}). Static is true for a
+// method of the class itself (static abc() {}), rather than of its prototype.
+//
+// Computed is the expression of a computed key ([abc]() {}), in which case Key is empty.
+type ClassElement struct {
+	Span
+	Key      string
+	Computed Expression
+	Kind     string
+	Static   bool
+	Value    *FunctionLiteral
+}
+
+// Property is a single name/value pair in an object literal.
+//
+// Kind is "value" for a data property (abc: 1, or the shorthand abc), "method"
+// for a method (abc() {}), or "get" or "set" for an accessor property (get abc() {},
+// set abc(def) {}), in which case Value is a *FunctionLiteral.
+//
+// Computed is the expression of a computed key ([abc]: 1), in which case Key is empty.
+type Property struct {
+	Span
+	Key      string
+	Computed Expression
+	Kind     string
+	Value    Expression
+}
+
+func (*ArrayLiteral) _expressionNode()          {}
+func (*ArrayPattern) _expressionNode()          {}
+func (*AssignExpression) _expressionNode()      {}
+func (*AssignmentPattern) _expressionNode()     {}
+func (*AwaitExpression) _expressionNode()       {}
+func (*BinaryExpression) _expressionNode()      {}
+func (*BooleanLiteral) _expressionNode()        {}
+func (*BracketExpression) _expressionNode()     {}
+func (*CallExpression) _expressionNode()        {}
+func (*ClassLiteral) _expressionNode()          {}
+func (*ConditionalExpression) _expressionNode() {}
+func (*DotExpression) _expressionNode()         {}
+func (*FunctionLiteral) _expressionNode()       {}
+func (*Identifier) _expressionNode()            {}
+func (*NewExpression) _expressionNode()         {}
+func (*NullLiteral) _expressionNode()           {}
+func (*NumberLiteral) _expressionNode()         {}
+func (*ObjectLiteral) _expressionNode()         {}
+func (*ObjectPattern) _expressionNode()         {}
+func (*RegExpLiteral) _expressionNode()         {}
+func (*SequenceExpression) _expressionNode()    {}
+func (*SpreadElement) _expressionNode()         {}
+func (*StringLiteral) _expressionNode()         {}
+func (*SuperExpression) _expressionNode()       {}
+func (*TemplateLiteral) _expressionNode()       {}
+func (*ThisExpression) _expressionNode()        {}
+func (*UnaryExpression) _expressionNode()       {}
+func (*VariableExpression) _expressionNode()    {}
+func (*YieldExpression) _expressionNode()       {}
+
+// ========= //
+// Statement //
+// ========= //
+
+type (
+	// Statement is implemented by every statement node.
+	Statement interface {
+		Node
+		_statementNode()
+	}
+
+	BlockStatement struct {
+		Span
+		List []Statement
+	}
+
+	BreakStatement struct {
+		Span
+		Label *Identifier // nil, if there is no label
+	}
+
+	// CaseStatement is a single clause of a switch. Test is nil for the default clause.
+	CaseStatement struct {
+		Span
+		Test       Expression
+		Consequent []Statement
+	}
+
+	// CatchStatement is the catch clause of a try statement. If the parameter is
+	// destructured, e.g. catch ({ message }), Parameter has an empty Name.
+	CatchStatement struct {
+		Span
+		Parameter *Identifier
+		Pattern   Expression // nil, if the parameter is not destructured
+		Body      *BlockStatement
+	}
+
+	// ClassStatement is a class declaration, which (like let) declares
+	// the name of the class in the enclosing block.
+	ClassStatement struct {
+		Span
+		Class *ClassLiteral
+	}
+
+	ContinueStatement struct {
+		Span
+		Label *Identifier // nil, if there is no label
+	}
+
+	DoWhileStatement struct {
+		Span
+		Test Expression
+		Body Statement
+	}
+
+	EmptyStatement struct {
+		Span
+	}
+
+	ExpressionStatement struct {
+		Span
+		Expression Expression
+	}
+
+	// ForInStatement is a for (... in ...) loop.
+	// Into is either a *VariableExpression (for (var abc in ...)),
+	// a left-hand side expression (for (abc.def in ...)), or a pattern
+	// (for ([ abc, def ] in ...)).
+	ForInStatement struct {
+		Span
+		Into        Expression
+		Source      Expression
+		Body        Statement
+		Declaration string // "var", "let" or "const", if Into is a *VariableExpression
+	}
+
+	// ForOfStatement is a for (... of ...) loop, over the values of an iterable.
+	// Into is as for a ForInStatement.
+	ForOfStatement struct {
+		Span
+		Into        Expression
+		Source      Expression
+		Body        Statement
+		Declaration string // "var", "let" or "const", if Into is a *VariableExpression
+	}
+
+	// ForStatement is a for (...; ...; ...) loop.
+	// Initializer is either nil, a *VariableStatement, a *LexicalDeclaration or an Expression.
+	ForStatement struct {
+		Span
+		Initializer Node
+		Test        Expression
+		Update      Expression
+		Body        Statement
+	}
+
+	// FunctionStatement is a function declaration. The function is also
+	// recorded (hoisted) in the DeclarationList of the enclosing function or program.
+	FunctionStatement struct {
+		Span
+		Function *FunctionLiteral
+	}
+
+	IfStatement struct {
+		Span
+		Test       Expression
+		Consequent Statement
+		Alternate  Statement // nil, if there is no else
+	}
+
+	// LexicalDeclaration is a let or const declaration, e.g. let abc = 1, def;
+	// Unlike a var, the declaration is not hoisted, but belongs to the enclosing block.
+	LexicalDeclaration struct {
+		Span
+		Token string // "let" or "const"
+		List  []*VariableExpression
+	}
+
+	LabelledStatement struct {
+		Span
+		Label     *Identifier
+		Statement Statement
+	}
+
+	ReturnStatement struct {
+		Span
+		Argument Expression // nil, if there is no argument
+	}
+
+	SwitchStatement struct {
+		Span
+		Discriminant Expression
+		Default      int // The index of the default clause in Body, or -1
+		Body         []*CaseStatement
+	}
+
+	ThrowStatement struct {
+		Span
+		Argument Expression
+	}
+
+	TryStatement struct {
+		Span
+		Body    *BlockStatement
+		Catch   *CatchStatement // nil, if there is no catch
+		Finally *BlockStatement // nil, if there is no finally
+	}
+
+	VariableStatement struct {
+		Span
+		List []*VariableExpression
+	}
+
+	WhileStatement struct {
+		Span
+		Test Expression
+		Body Statement
+	}
+
+	WithStatement struct {
+		Span
+		Object Expression
+		Body   Statement
+	}
+)
+
+func (*BlockStatement) _statementNode()      {}
+func (*BreakStatement) _statementNode()      {}
+func (*CaseStatement) _statementNode()       {}
+func (*CatchStatement) _statementNode()      {}
+func (*ClassStatement) _statementNode()      {}
+func (*ContinueStatement) _statementNode()   {}
+func (*DoWhileStatement) _statementNode()    {}
+func (*EmptyStatement) _statementNode()      {}
+func (*ExpressionStatement) _statementNode() {}
+func (*ForInStatement) _statementNode()      {}
+func (*ForOfStatement) _statementNode()      {}
+func (*ForStatement) _statementNode()        {}
+func (*FunctionStatement) _statementNode()   {}
+func (*IfStatement) _statementNode()         {}
+func (*LabelledStatement) _statementNode()   {}
+func (*LexicalDeclaration) _statementNode()  {}
+func (*ReturnStatement) _statementNode()     {}
+func (*SwitchStatement) _statementNode()     {}
+func (*ThrowStatement) _statementNode()      {}
+func (*TryStatement) _statementNode()        {}
+func (*VariableStatement) _statementNode()   {}
+func (*WhileStatement) _statementNode()      {}
+func (*WithStatement) _statementNode()       {}
+
+// =========== //
+// Declaration //
+// =========== //
+
+type (
+	// Declaration is a hoisted (var or function) declaration.
+	Declaration interface {
+		_declarationNode()
+	}
+
+	FunctionDeclaration struct {
+		Function *FunctionLiteral
+	}
+
+	VariableDeclaration struct {
+		List []*VariableExpression
+	}
+)
+
+func (*FunctionDeclaration) _declarationNode() {}
+func (*VariableDeclaration) _declarationNode() {}
+
+// ==== //
+// Node //
+// ==== //
+
+// Program is the root of a parsed source file.
+type Program struct {
+	Span
+	File *file.File
+	Body []Statement
+
+	// DeclarationList is every var and function declaration at the
+	// top-level (not including nested functions), in source order.
+	DeclarationList []Declaration
+
+	// Strict is true if the program begins with a "use strict" directive.
+	Strict bool
+}
@@ -0,0 +1,321 @@
+package ast
+
+import (
+	"fmt"
+)
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+func walkExpressionList(v Visitor, list []Expression) {
+	for _, node := range list {
+		if node != nil { // An array hole
+			Walk(v, node)
+		}
+	}
+}
+
+func walkStatementList(v Visitor, list []Statement) {
+	for _, node := range list {
+		Walk(v, node)
+	}
+}
+
+// Walk traverses an AST in depth-first order: It starts by calling
+// v.Visit(node); node must not be nil. If the visitor w returned by
+// v.Visit(node) is not nil, Walk is invoked recursively with visitor
+// w for each of the non-nil children of node, followed by a call of
+// w.Visit(nil).
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch node := node.(type) {
+
+	case *ArrayLiteral:
+		walkExpressionList(v, node.Value)
+
+	case *ArrayPattern:
+		walkExpressionList(v, node.Elements)
+		if node.Rest != nil {
+			Walk(v, node.Rest)
+		}
+
+	case *AssignExpression:
+		Walk(v, node.Left)
+		Walk(v, node.Right)
+
+	case *AssignmentPattern:
+		Walk(v, node.Target)
+		Walk(v, node.Initializer)
+
+	case *AwaitExpression:
+		Walk(v, node.Argument)
+
+	case *BinaryExpression:
+		Walk(v, node.Left)
+		Walk(v, node.Right)
+
+	case *BooleanLiteral, *Identifier, *NullLiteral, *NumberLiteral,
+		*RegExpLiteral, *StringLiteral, *SuperExpression, *ThisExpression:
+		// Nothing to do
+
+	case *BracketExpression:
+		Walk(v, node.Left)
+		Walk(v, node.Member)
+
+	case *CallExpression:
+		Walk(v, node.Callee)
+		walkExpressionList(v, node.ArgumentList)
+
+	case *ClassLiteral:
+		if node.Name != nil {
+			Walk(v, node.Name)
+		}
+		if node.SuperClass != nil {
+			Walk(v, node.SuperClass)
+		}
+		if node.Constructor != nil {
+			Walk(v, node.Constructor)
+		}
+		for _, element := range node.Body {
+			Walk(v, element)
+		}
+
+	case *ClassElement:
+		if node.Computed != nil {
+			Walk(v, node.Computed)
+		}
+		Walk(v, node.Value)
+
+	case *ConditionalExpression:
+		Walk(v, node.Test)
+		Walk(v, node.Consequent)
+		Walk(v, node.Alternate)
+
+	case *DotExpression:
+		Walk(v, node.Left)
+
+	case *FunctionLiteral:
+		if node.Name != nil {
+			Walk(v, node.Name)
+		}
+		for index, parameter := range node.ParameterList {
+			if node.ParameterPatternList != nil && node.ParameterPatternList[index] != nil {
+				Walk(v, node.ParameterPatternList[index])
+			} else {
+				Walk(v, parameter)
+			}
+		}
+		if node.Rest != nil {
+			Walk(v, node.Rest)
+		}
+		Walk(v, node.Body)
+
+	case *NewExpression:
+		Walk(v, node.Callee)
+		walkExpressionList(v, node.ArgumentList)
+
+	case *ObjectLiteral:
+		for _, property := range node.Value {
+			Walk(v, property)
+		}
+
+	case *ObjectPattern:
+		for _, property := range node.Properties {
+			Walk(v, property)
+		}
+		if node.Rest != nil {
+			Walk(v, node.Rest)
+		}
+
+	case *Property:
+		if node.Computed != nil {
+			Walk(v, node.Computed)
+		}
+		Walk(v, node.Value)
+
+	case *SequenceExpression:
+		walkExpressionList(v, node.Sequence)
+
+	case *SpreadElement:
+		Walk(v, node.Argument)
+
+	case *TemplateLiteral:
+		if node.Tag != nil {
+			Walk(v, node.Tag)
+		}
+		for index, element := range node.Elements {
+			Walk(v, element)
+			if index < len(node.Expressions) {
+				Walk(v, node.Expressions[index])
+			}
+		}
+
+	case *TemplateElement:
+		// Nothing to do
+
+	case *UnaryExpression:
+		Walk(v, node.Operand)
+
+	case *VariableExpression:
+		if node.Pattern != nil {
+			Walk(v, node.Pattern)
+		}
+		if node.Initializer != nil {
+			Walk(v, node.Initializer)
+		}
+
+	case *YieldExpression:
+		if node.Argument != nil {
+			Walk(v, node.Argument)
+		}
+
+	case *BlockStatement:
+		walkStatementList(v, node.List)
+
+	case *BreakStatement:
+		if node.Label != nil {
+			Walk(v, node.Label)
+		}
+
+	case *CaseStatement:
+		if node.Test != nil {
+			Walk(v, node.Test)
+		}
+		walkStatementList(v, node.Consequent)
+
+	case *CatchStatement:
+		if node.Pattern != nil {
+			Walk(v, node.Pattern)
+		} else {
+			Walk(v, node.Parameter)
+		}
+		Walk(v, node.Body)
+
+	case *ClassStatement:
+		Walk(v, node.Class)
+
+	case *ContinueStatement:
+		if node.Label != nil {
+			Walk(v, node.Label)
+		}
+
+	case *DoWhileStatement:
+		Walk(v, node.Body)
+		Walk(v, node.Test)
+
+	case *EmptyStatement:
+		// Nothing to do
+
+	case *ExpressionStatement:
+		Walk(v, node.Expression)
+
+	case *ForInStatement:
+		Walk(v, node.Into)
+		Walk(v, node.Source)
+		Walk(v, node.Body)
+
+	case *ForOfStatement:
+		Walk(v, node.Into)
+		Walk(v, node.Source)
+		Walk(v, node.Body)
+
+	case *ForStatement:
+		if node.Initializer != nil {
+			Walk(v, node.Initializer)
+		}
+		if node.Test != nil {
+			Walk(v, node.Test)
+		}
+		if node.Update != nil {
+			Walk(v, node.Update)
+		}
+		Walk(v, node.Body)
+
+	case *FunctionStatement:
+		Walk(v, node.Function)
+
+	case *IfStatement:
+		Walk(v, node.Test)
+		Walk(v, node.Consequent)
+		if node.Alternate != nil {
+			Walk(v, node.Alternate)
+		}
+
+	case *LabelledStatement:
+		Walk(v, node.Label)
+		Walk(v, node.Statement)
+
+	case *LexicalDeclaration:
+		for _, variable := range node.List {
+			Walk(v, variable)
+		}
+
+	case *ReturnStatement:
+		if node.Argument != nil {
+			Walk(v, node.Argument)
+		}
+
+	case *SwitchStatement:
+		Walk(v, node.Discriminant)
+		for _, clause := range node.Body {
+			Walk(v, clause)
+		}
+
+	case *ThrowStatement:
+		Walk(v, node.Argument)
+
+	case *TryStatement:
+		Walk(v, node.Body)
+		if node.Catch != nil {
+			Walk(v, node.Catch)
+		}
+		if node.Finally != nil {
+			Walk(v, node.Finally)
+		}
+
+	case *VariableStatement:
+		for _, variable := range node.List {
+			Walk(v, variable)
+		}
+
+	case *WhileStatement:
+		Walk(v, node.Test)
+		Walk(v, node.Body)
+
+	case *WithStatement:
+		Walk(v, node.Object)
+		Walk(v, node.Body)
+
+	case *Program:
+		walkStatementList(v, node.Body)
+
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", node))
+	}
+
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: It starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the non-nil children of node, followed by a
+// call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -0,0 +1,66 @@
+package otto
+
+// An async function is run as a generator (see _generatorObject), where each await is
+// a yield, and the generator is resumed by the reactions of the promise awaited, so
+// that the body is suspended across jobs (see job.go) without blocking the runtime.
+
+// callAsync starts evaluate (the parameters and body of a call of an async function),
+// returning the promise of its result
+func (runtime *_runtime) callAsync(evaluate func() Value) *_object {
+	promise := runtime.newPromise()
+	self := &_generatorObject{
+		context: runtime._executionContext(0),
+	}
+	self.start(runtime, evaluate)
+	runtime.stepAsync(self, promise, generatorNext, UndefinedValue())
+	return promise
+}
+
+// stepAsync resumes the body of an async function until the next await, where it waits
+// on the promise of the value awaited, or until it completes, settling promise
+func (runtime *_runtime) stepAsync(self *_generatorObject, promise *_object, kind _generatorResumptionKind, value Value) {
+	completion := self.resume(runtime, kind, value)
+	switch {
+	case completion.caught != nil:
+		runtime.rejectPromiseWithPanic(promise, completion.caught)
+		return
+	case completion.done:
+		runtime.resolvePromise(promise, completion.value)
+		return
+	}
+
+	awaited, exception := runtime.tryCatchEvaluate(func() Value {
+		return runtime.promiseResolve(runtime.Global.Promise, completion.value)
+	})
+	if exception {
+		runtime.stepAsync(self, promise, generatorThrow, awaited)
+		return
+	}
+	onFulfilled := runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+		runtime.stepAsync(self, promise, generatorNext, call.Argument(0))
+		return UndefinedValue()
+	})
+	onRejected := runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+		runtime.stepAsync(self, promise, generatorThrow, call.Argument(0))
+		return UndefinedValue()
+	})
+	runtime.performPromiseThen(awaited._object(), toValue_object(onFulfilled), toValue_object(onRejected), nil)
+}
+
+// rejectPromiseWithPanic rejects promise with what was thrown (as caught by recover),
+// keeping where it was thrown, or panics again if it was not an exception (e.g. an interrupt)
+func (runtime *_runtime) rejectPromiseWithPanic(promise *_object, caught interface{}) {
+	position, stack := _position{}, []_frame(nil)
+	switch caught := caught.(type) {
+	case *_exception:
+		position, stack = caught.position, caught.stack
+	case _error:
+		position, stack = caught.position, caught.stack
+	}
+	reason, _ := runtime.tryCatchEvaluate(func() Value {
+		panic(caught)
+	})
+	runtime.rejectPromise(promise, reason)
+	self := promise.promiseValue()
+	self.position, self.stack = position, stack
+}
@@ -0,0 +1,204 @@
+package otto
+
+import (
+	. "./terst"
+	"runtime"
+	"testing"
+)
+
+func TestAsync(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	// The body runs as far as the first await with the call, and the rest in jobs
+	test(`
+        var abc = [];
+        async function def(ghi) {
+            abc.push("start " + ghi);
+            var jkl = await ghi;
+            abc.push("resumed " + jkl);
+            return jkl + 1;
+        }
+        def(1).then(function(value) {
+            abc.push("result " + value);
+        });
+        abc.push("script");
+        abc.length;
+    `, "2")
+	test(`abc`, "start 1,script,resumed 1,result 2")
+
+	test(`
+        var abc = [];
+        async function def() {
+            try {
+                await Promise.reject(new Error("xyzzy"));
+            } catch (error) {
+                abc.push(error.message);
+            } finally {
+                abc.push("finally");
+            }
+            return "done";
+        }
+        def().then(function(value) {
+            abc.push(value);
+        });
+    `)
+	test(`abc`, "xyzzy,finally,done")
+
+	test(`
+        var abc = [];
+        var async = function(def) {
+            return def + 1;
+        };
+        class Def {
+            constructor() {
+                this.value = 1;
+            }
+            async ghi() {
+                var jkl = async mno => this.value + await mno;
+                return await jkl(2) + await { then: function(resolve) { resolve(3) } } + async(3);
+            }
+            static async async() {
+                return "async";
+            }
+        }
+        new Def().ghi().then(function(value) {
+            abc.push(value);
+        });
+        Def.async().then(function(value) {
+            abc.push(value);
+        });
+    `)
+	test(`abc`, "async,10")
+
+	// An exception rejects the promise (rather than being thrown by the call)
+	test(`
+        var abc = [];
+        async function def(ghi = jkl) {
+            abc.push("body");
+        }
+        async function mno() {
+            await null;
+            null.xyzzy;
+        }
+        def().catch(function(error) {
+            abc.push(error.name);
+        });
+        mno().catch(function(error) {
+            abc.push(error.name);
+        });
+        abc.push("script");
+    `)
+	test(`abc`, "script,ReferenceError,TypeError")
+
+	test(`
+        var abc = async function() {};
+        [ typeof abc().then, abc.hasOwnProperty("prototype"), Object.prototype.toString.call(abc()) ].join(",");
+    `, "function,false,[object Promise]")
+
+	test(`raise: new (async function() {})`, "TypeError: [function] is not a constructor")
+
+	// A rejection without a handler is thrown once the jobs have been run
+	test(`raise:
+        async function abc() {
+            await 1;
+            throw new TypeError("xyzzy");
+        }
+        abc();
+    `, "TypeError: xyzzy")
+}
+
+func TestAsync_Go(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+	promise, resolve, _ := Otto.NewPromise()
+	Otto.Set("abc", promise)
+
+	_, err := Otto.Run(`
+        var def;
+        (async function() {
+            def = await abc;
+        })();
+    `)
+	Is(err, nil)
+
+	resolve("Nothing happens.")
+	err = Otto.RunJobs()
+	Is(err, nil)
+	value, _ := Otto.Get("def")
+	Is(value, "Nothing happens.")
+
+	{
+		promise, resolve, _ := Otto.NewPromise()
+		Otto.Set("abc", promise)
+		_, err := Otto.Run(`
+            async function def() {
+                var ghi = await abc;
+                throw new RangeError(ghi);
+            }
+            def();
+        `)
+		Is(err, nil)
+
+		resolve("xyzzy")
+		err = Otto.RunJobs()
+		Is(err.(*Error).Name, "RangeError")
+		Is(err.(*Error).Message, "xyzzy")
+		Is(err.(*Error).Position().String(), "4:17")
+		Is(err.(*Error).String(), "RangeError: xyzzy\n    at def (4:17)\n    at 6:13")
+
+		// The rejection is only thrown once
+		err = Otto.RunJobs()
+		Is(err, nil)
+	}
+}
+
+func TestAsyncClose(t *testing.T) {
+	Terst(t)
+
+	goroutines := numGoroutine(0)
+	for index := 0; index < 10; index++ {
+		vm := New()
+		_, err := vm.Run(`
+            var abc = [];
+            async function def() {
+                await new Promise(function() {});
+                abc.push("resumed");
+            }
+            def();
+            (async function() {
+                await def();
+            })();
+        `)
+		Is(err, nil)
+		Is(runtime.NumGoroutine() >= goroutines+3, true)
+
+		vm.Close()
+		err = vm.RunJobs()
+		Is(err, nil)
+		value, _ := vm.Get("abc")
+		Is(value, "")
+	}
+	Is(numGoroutine(goroutines) <= goroutines, true)
+}
+
+func TestAsyncSweep(t *testing.T) {
+	Terst(t)
+
+	goroutines := numGoroutine(0)
+	vm := New()
+	vm.SetMemoryLimit(vm.MemoryUsage() + 4*1024*1024)
+	_, err := vm.Run(`
+        async function abc() {
+            await new Promise(function() {});
+        }
+        for (var def = 0; def < 1000; def++) {
+            abc();
+        }
+    `)
+	Is(err, nil)
+	Is(numGoroutine(goroutines+600) <= goroutines+600, true)
+	vm.Close()
+}
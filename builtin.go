@@ -32,6 +32,13 @@ func builtinGlobal_eval(call FunctionCall) Value {
 		runtime.EnterEvalExecutionContext(call)
 		defer runtime.LeaveExecutionContext()
 	}
+	if executionContext := runtime._executionContext(0); program.strict || executionContext.strict {
+		// Strict eval code has its own variable environment,
+		// so declarations do not escape into the caller (10.4.2)
+		environment := runtime.newDeclarativeEnvironment(executionContext.LexicalEnvironment)
+		executionContext.LexicalEnvironment = environment
+		executionContext.VariableEnvironment = environment
+	}
 	returnValue := runtime.evaluate(program)
 	if returnValue.isEmpty() {
 		return UndefinedValue()
@@ -343,26 +350,5 @@ func builtinError_toString(call FunctionCall) Value {
 	if thisObject == nil {
 		panic(newTypeError())
 	}
-
-	name := "Error"
-	nameValue := thisObject.get("name")
-	if nameValue.IsDefined() {
-		name = toString(nameValue)
-	}
-
-	message := ""
-	messageValue := thisObject.get("message")
-	if messageValue.IsDefined() {
-		message = toString(messageValue)
-	}
-
-	if len(name) == 0 {
-		return toValue_string(message)
-	}
-
-	if len(message) == 0 {
-		return toValue_string(name)
-	}
-
-	return toValue_string(fmt.Sprintf("%s: %s", name, message))
+	return toValue_string(errorString(thisObject))
 }
@@ -69,7 +69,7 @@ func builtinArray_concat(call FunctionCall) Value {
 		switch item._valueType {
 		case valueObject:
 			object := item._object()
-			if isArray(object) {
+			if isArrayOrProxy(object, "Array.prototype.concat") {
 				length := toInteger(object.get("length")).value
 				for index := int64(0); index < length; index += 1 {
 					name := strconv.FormatInt(index, 10)
@@ -152,6 +152,7 @@ func builtinArray_join(call FunctionCall) Value {
 		return toValue_string("")
 	}
 	stringList := make([]string, 0, length)
+	size := len(separator) * int(length-1)
 	for index := int64(0); index < length; index += 1 {
 		value := thisObject.get(arrayIndexToString(index))
 		stringValue := ""
@@ -161,7 +162,9 @@ func builtinArray_join(call FunctionCall) Value {
 			stringValue = toString(value)
 		}
 		stringList = append(stringList, stringValue)
+		size += len(stringValue)
 	}
+	call.runtime.allocate(size)
 	return toValue_string(strings.Join(stringList, separator))
 }
 
@@ -456,7 +459,7 @@ func builtinArray_sort(call FunctionCall) Value {
 }
 
 func builtinArray_isArray(call FunctionCall) Value {
-	return toValue_bool(isArray(call.Argument(0)._object()))
+	return toValue_bool(isArrayOrProxy(call.Argument(0)._object(), "Array.isArray"))
 }
 
 func builtinArray_indexOf(call FunctionCall) Value {
@@ -670,3 +673,15 @@ func builtinArray_reduceRight(call FunctionCall) Value {
 	}
 	panic(newTypeError())
 }
+
+func builtinArray_values(call FunctionCall) Value {
+	return toValue_object(call.runtime.newArrayIterator(call.thisObject(), "values"))
+}
+
+func builtinArray_keys(call FunctionCall) Value {
+	return toValue_object(call.runtime.newArrayIterator(call.thisObject(), "keys"))
+}
+
+func builtinArray_entries(call FunctionCall) Value {
+	return toValue_object(call.runtime.newArrayIterator(call.thisObject(), "entries"))
+}
@@ -1,9 +1,7 @@
 package otto
 
 func (runtime *_runtime) newEvalError(message Value) *_object {
-	self := runtime.newErrorObject(message)
-	self.prototype = runtime.Global.EvalErrorPrototype
-	return self
+	return runtime.newErrorObject(runtime.Global.EvalErrorPrototype, message)
 }
 
 func builtinEvalError(call FunctionCall) Value {
@@ -15,9 +13,7 @@ func builtinNewEvalError(self *_object, _ Value, argumentList []Value) Value {
 }
 
 func (runtime *_runtime) newTypeError(message Value) *_object {
-	self := runtime.newErrorObject(message)
-	self.prototype = runtime.Global.TypeErrorPrototype
-	return self
+	return runtime.newErrorObject(runtime.Global.TypeErrorPrototype, message)
 }
 
 func builtinTypeError(call FunctionCall) Value {
@@ -29,9 +25,7 @@ func builtinNewTypeError(self *_object, _ Value, argumentList []Value) Value {
 }
 
 func (runtime *_runtime) newRangeError(message Value) *_object {
-	self := runtime.newErrorObject(message)
-	self.prototype = runtime.Global.RangeErrorPrototype
-	return self
+	return runtime.newErrorObject(runtime.Global.RangeErrorPrototype, message)
 }
 
 func builtinRangeError(call FunctionCall) Value {
@@ -43,15 +37,11 @@ func builtinNewRangeError(self *_object, _ Value, argumentList []Value) Value {
 }
 
 func (runtime *_runtime) newURIError(message Value) *_object {
-	self := runtime.newErrorObject(message)
-	self.prototype = runtime.Global.URIErrorPrototype
-	return self
+	return runtime.newErrorObject(runtime.Global.URIErrorPrototype, message)
 }
 
 func (runtime *_runtime) newReferenceError(message Value) *_object {
-	self := runtime.newErrorObject(message)
-	self.prototype = runtime.Global.ReferenceErrorPrototype
-	return self
+	return runtime.newErrorObject(runtime.Global.ReferenceErrorPrototype, message)
 }
 
 func builtinReferenceError(call FunctionCall) Value {
@@ -63,9 +53,7 @@ func builtinNewReferenceError(self *_object, _ Value, argumentList []Value) Valu
 }
 
 func (runtime *_runtime) newSyntaxError(message Value) *_object {
-	self := runtime.newErrorObject(message)
-	self.prototype = runtime.Global.SyntaxErrorPrototype
-	return self
+	return runtime.newErrorObject(runtime.Global.SyntaxErrorPrototype, message)
 }
 
 func builtinSyntaxError(call FunctionCall) Value {
@@ -1,9 +1,11 @@
 package otto
 
 import (
-	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/robertkrimen/otto/file"
+	"github.com/robertkrimen/otto/parser"
 )
 
 // Function
@@ -27,27 +29,21 @@ func argumentList2parameterList(argumentList []Value) []string {
 	return parameterList
 }
 
-var matchIdentifier = regexp.MustCompile(`^[$_\p{L}][$_\p{L}\d}]*$`)
-
 func builtinNewFunctionNative(runtime *_runtime, argumentList []Value) *_object {
 	parameterList := []string(nil)
 	bodySource := ""
 	argumentCount := len(argumentList)
 	if argumentCount > 0 {
 		parameterList = argumentList2parameterList(argumentList[0 : argumentCount-1])
-		for _, value := range parameterList {
-			// TODO: This is extremely hacky... maybe go through the parser directly?
-			if !matchIdentifier.MatchString(value) || keywordTable[value] {
-				panic(newSyntaxError(value))
-			}
-		}
 		bodySource = toString(argumentList[argumentCount-1])
 	}
 
-	parser := newParser()
-	parser.lexer.Source = bodySource
-	_programNode := parser.ParseAsFunction()
-	return runtime.newNodeFunction(_programNode.toFunction(parameterList), runtime.GlobalEnvironment)
+	function, err := parser.ParseFunction(parameterList, bodySource)
+	if err != nil {
+		panic(parseError(err))
+	}
+	compiler := &_compiler{file: file.NewFile("", bodySource)}
+	return runtime.newNodeFunction(compiler.compileFunction(function, false), runtime.GlobalLexicalEnvironment)
 }
 
 func builtinFunction_toString(call FunctionCall) Value {
@@ -60,10 +56,6 @@ func builtinFunction_apply(call FunctionCall) Value {
 		panic(newTypeError())
 	}
 	this := call.Argument(0)
-	if this.IsUndefined() {
-		// FIXME Not ECMA5
-		this = toValue_object(call.runtime.GlobalObject)
-	}
 	argumentList := call.Argument(1)
 	switch argumentList._valueType {
 	case valueUndefined, valueNull:
@@ -89,10 +81,6 @@ func builtinFunction_call(call FunctionCall) Value {
 	}
 	thisObject := call.thisObject()
 	this := call.Argument(0)
-	if this.IsUndefined() {
-		// FIXME Not ECMA5
-		this = toValue_object(call.runtime.GlobalObject)
-	}
 	if len(call.ArgumentList) >= 1 {
 		return thisObject.Call(this, call.ArgumentList[1:])
 	}
@@ -0,0 +1,25 @@
+package otto
+
+// Generator
+
+// thisGeneratorObject returns the state of this, which must be a generator
+func thisGeneratorObject(call FunctionCall, name string) *_generatorObject {
+	if object := call.This._object(); object != nil {
+		if value, valid := object.value.(*_generatorObject); valid {
+			return value
+		}
+	}
+	panic(newTypeError("Method Generator.prototype.%s called on incompatible receiver %v", name, call.This))
+}
+
+func builtinGenerator_next(call FunctionCall) Value {
+	return call.runtime.resumeGenerator(thisGeneratorObject(call, "next"), generatorNext, call.Argument(0))
+}
+
+func builtinGenerator_return(call FunctionCall) Value {
+	return call.runtime.resumeGenerator(thisGeneratorObject(call, "return"), generatorReturn, call.Argument(0))
+}
+
+func builtinGenerator_throw(call FunctionCall) Value {
+	return call.runtime.resumeGenerator(thisGeneratorObject(call, "throw"), generatorThrow, call.Argument(0))
+}
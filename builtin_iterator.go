@@ -0,0 +1,76 @@
+package otto
+
+// Iterator
+
+func builtinIterator_iterator(call FunctionCall) Value {
+	return call.This
+}
+
+func builtinArrayIterator_next(call FunctionCall) Value {
+	iterator, valid := call.thisObject().value.(*_arrayIteratorObject)
+	if !valid {
+		panic(newTypeError("next method called on incompatible %v", call.This))
+	}
+	object := iterator.object
+	if object == nil {
+		return toValue_object(call.runtime.newIteratorResult(UndefinedValue(), true))
+	}
+	index := iterator.index
+	if index >= int64(toUint32(object.get("length"))) {
+		iterator.object = nil
+		return toValue_object(call.runtime.newIteratorResult(UndefinedValue(), true))
+	}
+	iterator.index++
+	value := UndefinedValue()
+	switch iterator.kind {
+	case "keys":
+		value = toValue_int64(index)
+	case "values":
+		value = object.get(arrayIndexToString(index))
+	case "entries":
+		value = toValue_object(call.runtime.newArrayOf([]Value{toValue_int64(index), object.get(arrayIndexToString(index))}))
+	}
+	return toValue_object(call.runtime.newIteratorResult(value, false))
+}
+
+func builtinStringIterator_next(call FunctionCall) Value {
+	iterator, valid := call.thisObject().value.(*_stringIteratorObject)
+	if !valid {
+		panic(newTypeError("next method called on incompatible %v", call.This))
+	}
+	if iterator.index >= len(iterator.value) {
+		return toValue_object(call.runtime.newIteratorResult(UndefinedValue(), true))
+	}
+	value := toValue_string(string(iterator.value[iterator.index]))
+	iterator.index++
+	return toValue_object(call.runtime.newIteratorResult(value, false))
+}
+
+func builtinMapIterator_next(call FunctionCall) Value {
+	return mapIteratorNext(call, "Map")
+}
+
+func builtinSetIterator_next(call FunctionCall) Value {
+	return mapIteratorNext(call, "Set")
+}
+
+func mapIteratorNext(call FunctionCall, class string) Value {
+	iterator, valid := call.thisObject().value.(*_mapIteratorObject)
+	if !valid || (iterator.object != nil && iterator.object.class != class) {
+		panic(newTypeError("next method called on incompatible %v", call.This))
+	}
+	entry := iterator.next()
+	if entry == nil {
+		return toValue_object(call.runtime.newIteratorResult(UndefinedValue(), true))
+	}
+	value := UndefinedValue()
+	switch iterator.kind {
+	case "keys":
+		value = entry.key
+	case "values":
+		value = entry.value
+	case "entries":
+		value = toValue_object(call.runtime.newArrayOf([]Value{entry.key, entry.value}))
+	}
+	return toValue_object(call.runtime.newIteratorResult(value, false))
+}
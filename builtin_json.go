@@ -113,8 +113,8 @@ func builtinJSON_stringify(call FunctionCall) Value {
 	}
 	replacer := call.Argument(1)._object()
 	if replacer != nil {
-		if isArray(replacer) {
-			length := objectLength(replacer)
+		if isArrayOrProxy(replacer, "JSON.stringify") {
+			length := toUint32(replacer.get("length"))
 			seen := map[string]bool{}
 			propertyList := make([]string, length)
 			length = 0
@@ -189,6 +189,7 @@ func builtinJSON_stringify(call FunctionCall) Value {
 		json.Indent(&valueJSON1, valueJSON, "", ctx.gap)
 		valueJSON = valueJSON1.Bytes()
 	}
+	call.runtime.allocate(len(valueJSON))
 	return toValue_string(string(valueJSON))
 }
 
@@ -240,8 +241,8 @@ func builtinJSON_stringifyWalk(ctx _builtinJSON_stringifyContext, key string, ho
 			ctx.stack = append(ctx.stack, value)
 			defer func() { ctx.stack = ctx.stack[:len(ctx.stack)-1] }()
 		}
-		if isArray(holder) {
-			length := holder.get("length").value.(uint32)
+		if isArrayOrProxy(holder, "JSON.stringify") {
+			length := toUint32(holder.get("length"))
 			array := make([]interface{}, length)
 			for index, _ := range array {
 				name := arrayIndexToString(int64(index))
@@ -262,6 +263,9 @@ func builtinJSON_stringifyWalk(ctx _builtinJSON_stringifyContext, key string, ho
 				// Go maps are without order, so this doesn't conform to the ECMA ordering
 				// standard, but oh well...
 				holder.enumerate(false, func(name string) bool {
+					if isSymbolKey(name) {
+						return true
+					}
 					value, exists := builtinJSON_stringifyWalk(ctx, name, holder)
 					if exists {
 						object[name] = value
@@ -0,0 +1,320 @@
+package otto
+
+// Map
+
+func builtinMap(call FunctionCall) Value {
+	panic(newTypeError("Constructor Map requires 'new'"))
+}
+
+func builtinNewMap(self *_object, _ Value, argumentList []Value) Value {
+	runtime := self.runtime
+	object := runtime.newMap()
+	iterable := valueOfArrayIndex(argumentList, 0)
+	if iterable.IsUndefined() || iterable.IsNull() {
+		return toValue_object(object)
+	}
+	adder := object.get("set")
+	if !adder.isCallable() {
+		panic(newTypeError("%v is not a function", adder))
+	}
+	runtime.addFromIterable(iterable, func(value Value) {
+		if !value.IsObject() {
+			panic(newTypeError("Iterator value %v is not an entry object", value))
+		}
+		entry := value._object()
+		runtime.Call(adder._object(), toValue_object(object), []Value{entry.get("0"), entry.get("1")}, false)
+	})
+	return toValue_object(object)
+}
+
+// addFromIterable calls add with each value of iterable (for the constructor of a
+// Map or Set), closing the iterator if add throws
+func (runtime *_runtime) addFromIterable(iterable Value, add func(Value)) {
+	iterator := runtime.getIterator(iterable)
+	done := false
+	defer iterator.closeOnPanic(&done)
+	for {
+		done = true
+		value, finished := iterator.step()
+		if finished {
+			return
+		}
+		done = false
+		add(value)
+	}
+}
+
+// thisMapObject returns the entries of this, which must be a Map (or a Set, if class is "Set")
+func thisMapObject(call FunctionCall, class string, name string) *_mapObject {
+	if object := call.This._object(); object != nil && object.class == class {
+		if value := object.mapValue(); value != nil {
+			return value
+		}
+	}
+	panic(newTypeError("Method %s.prototype.%s called on incompatible receiver %v", class, name, call.This))
+}
+
+func builtinMap_get(call FunctionCall) Value {
+	value, _ := thisMapObject(call, "Map", "get").get(call.Argument(0))
+	return value
+}
+
+func builtinMap_set(call FunctionCall) Value {
+	if thisMapObject(call, "Map", "set").set(call.Argument(0), call.Argument(1)) {
+		call.runtime.allocate(propertyMemory)
+	}
+	return call.This
+}
+
+func builtinMap_has(call FunctionCall) Value {
+	return toValue_bool(thisMapObject(call, "Map", "has").has(call.Argument(0)))
+}
+
+func builtinMap_delete(call FunctionCall) Value {
+	return toValue_bool(thisMapObject(call, "Map", "delete").delete(call.Argument(0)))
+}
+
+func builtinMap_clear(call FunctionCall) Value {
+	thisMapObject(call, "Map", "clear").clear()
+	return UndefinedValue()
+}
+
+func builtinMap_forEach(call FunctionCall) Value {
+	mapObject := thisMapObject(call, "Map", "forEach")
+	callback := call.Argument(0)
+	if !callback.isCallable() {
+		panic(newTypeError("%v is not a function", callback))
+	}
+	callThis := call.Argument(1)
+	mapObject.forEach(func(entry *_mapEntry) {
+		callback.call(callThis, entry.value, entry.key, call.This)
+	})
+	return UndefinedValue()
+}
+
+func builtinMap_keys(call FunctionCall) Value {
+	thisMapObject(call, "Map", "keys")
+	return toValue_object(call.runtime.newMapIterator(call.thisObject(), "keys"))
+}
+
+func builtinMap_values(call FunctionCall) Value {
+	thisMapObject(call, "Map", "values")
+	return toValue_object(call.runtime.newMapIterator(call.thisObject(), "values"))
+}
+
+func builtinMap_entries(call FunctionCall) Value {
+	thisMapObject(call, "Map", "entries")
+	return toValue_object(call.runtime.newMapIterator(call.thisObject(), "entries"))
+}
+
+func builtinMap_size(call FunctionCall) Value {
+	return toValue_int(thisMapObject(call, "Map", "size").size())
+}
+
+// Set
+
+func builtinSet(call FunctionCall) Value {
+	panic(newTypeError("Constructor Set requires 'new'"))
+}
+
+func builtinNewSet(self *_object, _ Value, argumentList []Value) Value {
+	runtime := self.runtime
+	object := runtime.newSet()
+	iterable := valueOfArrayIndex(argumentList, 0)
+	if iterable.IsUndefined() || iterable.IsNull() {
+		return toValue_object(object)
+	}
+	adder := object.get("add")
+	if !adder.isCallable() {
+		panic(newTypeError("%v is not a function", adder))
+	}
+	runtime.addFromIterable(iterable, func(value Value) {
+		runtime.Call(adder._object(), toValue_object(object), []Value{value}, false)
+	})
+	return toValue_object(object)
+}
+
+func builtinSet_has(call FunctionCall) Value {
+	return toValue_bool(thisMapObject(call, "Set", "has").has(call.Argument(0)))
+}
+
+func builtinSet_add(call FunctionCall) Value {
+	value := call.Argument(0)
+	if thisMapObject(call, "Set", "add").set(value, value) {
+		call.runtime.allocate(propertyMemory)
+	}
+	return call.This
+}
+
+func builtinSet_delete(call FunctionCall) Value {
+	return toValue_bool(thisMapObject(call, "Set", "delete").delete(call.Argument(0)))
+}
+
+func builtinSet_clear(call FunctionCall) Value {
+	thisMapObject(call, "Set", "clear").clear()
+	return UndefinedValue()
+}
+
+func builtinSet_entries(call FunctionCall) Value {
+	thisMapObject(call, "Set", "entries")
+	return toValue_object(call.runtime.newMapIterator(call.thisObject(), "entries"))
+}
+
+func builtinSet_forEach(call FunctionCall) Value {
+	mapObject := thisMapObject(call, "Set", "forEach")
+	callback := call.Argument(0)
+	if !callback.isCallable() {
+		panic(newTypeError("%v is not a function", callback))
+	}
+	callThis := call.Argument(1)
+	mapObject.forEach(func(entry *_mapEntry) {
+		callback.call(callThis, entry.key, entry.key, call.This)
+	})
+	return UndefinedValue()
+}
+
+func builtinSet_values(call FunctionCall) Value {
+	thisMapObject(call, "Set", "values")
+	return toValue_object(call.runtime.newMapIterator(call.thisObject(), "values"))
+}
+
+func builtinSet_size(call FunctionCall) Value {
+	return toValue_int(thisMapObject(call, "Set", "size").size())
+}
+
+// WeakMap
+
+func builtinWeakMap(call FunctionCall) Value {
+	panic(newTypeError("Constructor WeakMap requires 'new'"))
+}
+
+func builtinNewWeakMap(self *_object, _ Value, argumentList []Value) Value {
+	runtime := self.runtime
+	object := runtime.newWeakMap()
+	iterable := valueOfArrayIndex(argumentList, 0)
+	if iterable.IsUndefined() || iterable.IsNull() {
+		return toValue_object(object)
+	}
+	adder := object.get("set")
+	if !adder.isCallable() {
+		panic(newTypeError("%v is not a function", adder))
+	}
+	runtime.addFromIterable(iterable, func(value Value) {
+		if !value.IsObject() {
+			panic(newTypeError("Iterator value %v is not an entry object", value))
+		}
+		entry := value._object()
+		runtime.Call(adder._object(), toValue_object(object), []Value{entry.get("0"), entry.get("1")}, false)
+	})
+	return toValue_object(object)
+}
+
+// thisWeakMapObject returns this, which must be a WeakMap (or a WeakSet, if class is "WeakSet")
+func thisWeakMapObject(call FunctionCall, class string, name string) *_object {
+	if object := call.This._object(); object != nil && object.class == class {
+		if _, valid := object.value.(_weakMapObject); valid {
+			return object
+		}
+	}
+	panic(newTypeError("Method %s.prototype.%s called on incompatible receiver %v", class, name, call.This))
+}
+
+func builtinWeakMap_get(call FunctionCall) Value {
+	weakMap := thisWeakMapObject(call, "WeakMap", "get")
+	if key := call.Argument(0); canBeHeldWeakly(key) {
+		if value, exists := key._object().weakValue[weakMap]; exists {
+			return value
+		}
+	}
+	return UndefinedValue()
+}
+
+func builtinWeakMap_set(call FunctionCall) Value {
+	weakMap := thisWeakMapObject(call, "WeakMap", "set")
+	key := call.Argument(0)
+	if !canBeHeldWeakly(key) {
+		panic(newTypeError("Invalid value used as weak map key"))
+	}
+	call.runtime.setWeakValue(key._object(), weakMap, call.Argument(1))
+	return call.This
+}
+
+func builtinWeakMap_has(call FunctionCall) Value {
+	weakMap := thisWeakMapObject(call, "WeakMap", "has")
+	return toValue_bool(hasWeakValue(call.Argument(0), weakMap))
+}
+
+func builtinWeakMap_delete(call FunctionCall) Value {
+	weakMap := thisWeakMapObject(call, "WeakMap", "delete")
+	return toValue_bool(deleteWeakValue(call.Argument(0), weakMap))
+}
+
+// setWeakValue sets the value of key in weakMap, a WeakMap or WeakSet
+func (runtime *_runtime) setWeakValue(key *_object, weakMap *_object, value Value) {
+	if key.weakValue == nil {
+		key.weakValue = map[*_object]Value{}
+	}
+	if _, exists := key.weakValue[weakMap]; !exists {
+		runtime.allocate(propertyMemory)
+	}
+	key.weakValue[weakMap] = value
+}
+
+func hasWeakValue(key Value, weakMap *_object) bool {
+	if !canBeHeldWeakly(key) {
+		return false
+	}
+	_, exists := key._object().weakValue[weakMap]
+	return exists
+}
+
+func deleteWeakValue(key Value, weakMap *_object) bool {
+	if !hasWeakValue(key, weakMap) {
+		return false
+	}
+	delete(key._object().weakValue, weakMap)
+	return true
+}
+
+// WeakSet
+
+func builtinWeakSet(call FunctionCall) Value {
+	panic(newTypeError("Constructor WeakSet requires 'new'"))
+}
+
+func builtinNewWeakSet(self *_object, _ Value, argumentList []Value) Value {
+	runtime := self.runtime
+	object := runtime.newWeakSet()
+	iterable := valueOfArrayIndex(argumentList, 0)
+	if iterable.IsUndefined() || iterable.IsNull() {
+		return toValue_object(object)
+	}
+	adder := object.get("add")
+	if !adder.isCallable() {
+		panic(newTypeError("%v is not a function", adder))
+	}
+	runtime.addFromIterable(iterable, func(value Value) {
+		runtime.Call(adder._object(), toValue_object(object), []Value{value}, false)
+	})
+	return toValue_object(object)
+}
+
+func builtinWeakSet_add(call FunctionCall) Value {
+	weakSet := thisWeakMapObject(call, "WeakSet", "add")
+	value := call.Argument(0)
+	if !canBeHeldWeakly(value) {
+		panic(newTypeError("Invalid value used in weak set"))
+	}
+	call.runtime.setWeakValue(value._object(), weakSet, toValue_bool(true))
+	return call.This
+}
+
+func builtinWeakSet_has(call FunctionCall) Value {
+	weakSet := thisWeakMapObject(call, "WeakSet", "has")
+	return toValue_bool(hasWeakValue(call.Argument(0), weakSet))
+}
+
+func builtinWeakSet_delete(call FunctionCall) Value {
+	weakSet := thisWeakMapObject(call, "WeakSet", "delete")
+	return toValue_bool(deleteWeakValue(call.Argument(0), weakSet))
+}
@@ -20,7 +20,7 @@ func builtinNewObject(self *_object, _ Value, argumentList []Value) Value {
 	value := valueOfArrayIndex(argumentList, 0)
 	switch value._valueType {
 	case valueNull, valueUndefined:
-	case valueNumber, valueString, valueBoolean:
+	case valueNumber, valueString, valueBoolean, valueSymbol:
 		return toValue_object(self.runtime.toObject(value))
 	case valueObject:
 		return value
@@ -34,7 +34,7 @@ func builtinObject_valueOf(call FunctionCall) Value {
 }
 
 func builtinObject_hasOwnProperty(call FunctionCall) Value {
-	propertyName := toString(call.Argument(0))
+	propertyName := toPropertyKey(call.Argument(0))
 	thisObject := call.thisObject()
 	return toValue_bool(thisObject.hasOwnProperty(propertyName))
 }
@@ -44,19 +44,19 @@ func builtinObject_isPrototypeOf(call FunctionCall) Value {
 	if !value.IsObject() {
 		return FalseValue()
 	}
-	prototype := call.toObject(value).prototype
+	prototype := call.toObject(value).getPrototypeOf()
 	thisObject := call.thisObject()
 	for prototype != nil {
 		if thisObject == prototype {
 			return TrueValue()
 		}
-		prototype = prototype.prototype
+		prototype = prototype.getPrototypeOf()
 	}
 	return FalseValue()
 }
 
 func builtinObject_propertyIsEnumerable(call FunctionCall) Value {
-	propertyName := toString(call.Argument(0))
+	propertyName := toPropertyKey(call.Argument(0))
 	thisObject := call.thisObject()
 	property := thisObject.getOwnProperty(propertyName)
 	if property != nil && property.enumerable() {
@@ -72,7 +72,14 @@ func builtinObject_toString(call FunctionCall) Value {
 	} else if call.This.IsNull() {
 		result = "[object Null]"
 	} else {
-		result = fmt.Sprintf("[object %s]", call.thisObject().class)
+		class := call.thisObject().class
+		if class == "Object" && isArrayOrProxy(call.thisObject(), "Object.prototype.toString") {
+			class = "Array"
+		}
+		if tag := call.thisObject().get(symbolKeyToStringTag); tag.IsString() {
+			class = toString(tag)
+		}
+		result = fmt.Sprintf("[object %s]", class)
 	}
 	return toValue_string(result)
 }
@@ -92,11 +99,12 @@ func builtinObject_getPrototypeOf(call FunctionCall) Value {
 		panic(newTypeError())
 	}
 
-	if object.prototype == nil {
+	prototype := object.getPrototypeOf()
+	if prototype == nil {
 		return NullValue()
 	}
 
-	return toValue_object(object.prototype)
+	return toValue_object(prototype)
 }
 
 func builtinObject_getOwnPropertyDescriptor(call FunctionCall) Value {
@@ -106,7 +114,7 @@ func builtinObject_getOwnPropertyDescriptor(call FunctionCall) Value {
 		panic(newTypeError())
 	}
 
-	name := toString(call.Argument(1))
+	name := toPropertyKey(call.Argument(1))
 	descriptor := object.getOwnProperty(name)
 	if descriptor == nil {
 		return UndefinedValue()
@@ -120,7 +128,7 @@ func builtinObject_defineProperty(call FunctionCall) Value {
 	if object == nil {
 		panic(newTypeError())
 	}
-	name := toString(call.Argument(1))
+	name := toPropertyKey(call.Argument(1))
 	descriptor := toPropertyDescriptor(call.Argument(2))
 	object.defineOwnProperty(name, descriptor, true)
 	return objectValue
@@ -168,7 +176,7 @@ func builtinObject_create(call FunctionCall) Value {
 func builtinObject_isExtensible(call FunctionCall) Value {
 	object := call.Argument(0)
 	if object := object._object(); object != nil {
-		return toValue_bool(object.extensible)
+		return toValue_bool(object.isExtensible())
 	}
 	panic(newTypeError())
 }
@@ -176,7 +184,9 @@ func builtinObject_isExtensible(call FunctionCall) Value {
 func builtinObject_preventExtensions(call FunctionCall) Value {
 	object := call.Argument(0)
 	if object := object._object(); object != nil {
-		object.extensible = false
+		if !object.preventExtensions() {
+			panic(newTypeError("Cannot prevent extensions"))
+		}
 	} else {
 		panic(newTypeError())
 	}
@@ -186,13 +196,13 @@ func builtinObject_preventExtensions(call FunctionCall) Value {
 func builtinObject_isSealed(call FunctionCall) Value {
 	object := call.Argument(0)
 	if object := object._object(); object != nil {
-		if object.extensible {
+		if object.isExtensible() {
 			return toValue_bool(false)
 		}
 		result := true
 		object.enumerate(true, func(name string) bool {
 			property := object.getProperty(name)
-			if property.configurable() {
+			if property != nil && property.configurable() {
 				result = false
 			}
 			return true
@@ -212,7 +222,7 @@ func builtinObject_seal(call FunctionCall) Value {
 			}
 			return true
 		})
-		object.extensible = false
+		object.preventExtensions()
 	} else {
 		panic(newTypeError())
 	}
@@ -222,13 +232,13 @@ func builtinObject_seal(call FunctionCall) Value {
 func builtinObject_isFrozen(call FunctionCall) Value {
 	object := call.Argument(0)
 	if object := object._object(); object != nil {
-		if object.extensible {
+		if object.isExtensible() {
 			return toValue_bool(false)
 		}
 		result := true
 		object.enumerate(true, func(name string) bool {
 			property := object.getProperty(name)
-			if property.configurable() || property.writable() {
+			if property != nil && (property.configurable() || property.writable()) {
 				result = false
 			}
 			return true
@@ -241,23 +251,7 @@ func builtinObject_isFrozen(call FunctionCall) Value {
 func builtinObject_freeze(call FunctionCall) Value {
 	object := call.Argument(0)
 	if object := object._object(); object != nil {
-		object.enumerate(true, func(name string) bool {
-			if property, update := object.getOwnProperty(name), false; nil != property {
-				if property.isDataDescriptor() && property.writable() {
-					property.writeOff()
-					update = true
-				}
-				if property.configurable() {
-					property.configureOff()
-					update = true
-				}
-				if update {
-					object.defineOwnProperty(name, *property, true)
-				}
-			}
-			return true
-		})
-		object.extensible = false
+		object.freeze()
 	} else {
 		panic(newTypeError())
 	}
@@ -267,7 +261,9 @@ func builtinObject_freeze(call FunctionCall) Value {
 func builtinObject_keys(call FunctionCall) Value {
 	if object, keys := call.Argument(0)._object(), []Value(nil); nil != object {
 		object.enumerate(false, func(name string) bool {
-			keys = append(keys, toValue_string(name))
+			if !isSymbolKey(name) {
+				keys = append(keys, toValue_string(name))
+			}
 			return true
 		})
 		return toValue_object(call.runtime.newArrayOf(keys))
@@ -278,7 +274,7 @@ func builtinObject_keys(call FunctionCall) Value {
 func builtinObject_getOwnPropertyNames(call FunctionCall) Value {
 	if object, propertyNames := call.Argument(0)._object(), []Value(nil); nil != object {
 		object.enumerate(true, func(name string) bool {
-			if object.hasOwnProperty(name) {
+			if object.hasOwnProperty(name) && !isSymbolKey(name) {
 				propertyNames = append(propertyNames, toValue_string(name))
 			}
 			return true
@@ -287,3 +283,16 @@ func builtinObject_getOwnPropertyNames(call FunctionCall) Value {
 	}
 	panic(newTypeError())
 }
+
+func builtinObject_getOwnPropertySymbols(call FunctionCall) Value {
+	if object, propertySymbols := call.Argument(0)._object(), []Value(nil); nil != object {
+		object.enumerate(true, func(name string) bool {
+			if isSymbolKey(name) {
+				propertySymbols = append(propertySymbols, toValue_symbol(symbolOfKey(name)))
+			}
+			return true
+		})
+		return toValue_object(call.runtime.newArrayOf(propertySymbols))
+	}
+	panic(newTypeError())
+}
@@ -0,0 +1,198 @@
+package otto
+
+// Promise
+
+func builtinPromise(call FunctionCall) Value {
+	panic(newTypeError("Promise constructor cannot be invoked without 'new'"))
+}
+
+func builtinNewPromise(self *_object, _ Value, argumentList []Value) Value {
+	runtime := self.runtime
+	executor := valueOfArrayIndex(argumentList, 0)
+	if !executor.isCallable() {
+		panic(newTypeError("Promise resolver %v is not a function", executor))
+	}
+
+	promise := runtime.newPromise()
+	resolve, reject := runtime.newResolvingFunctions(promise)
+	value, exception := runtime.tryCatchEvaluate(func() Value {
+		return runtime.Call(executor._object(), UndefinedValue(), []Value{toValue_object(resolve), toValue_object(reject)}, false)
+	})
+	if exception {
+		runtime.Call(reject, UndefinedValue(), []Value{value}, false)
+	}
+	return toValue_object(promise)
+}
+
+// speciesConstructor returns the constructor of object, or defaultConstructor
+// if object does not have one
+func (runtime *_runtime) speciesConstructor(object *_object, defaultConstructor *_object) *_object {
+	constructor := object.get("constructor")
+	if constructor.IsUndefined() {
+		return defaultConstructor
+	}
+	if !constructor.IsObject() {
+		panic(newTypeError("The constructor of %v is not an object", toValue_object(object)))
+	}
+	return constructor._object()
+}
+
+func builtinPromise_then(call FunctionCall) Value {
+	runtime := call.runtime
+	promise := call.thisObject()
+	if promise.promiseValue() == nil {
+		panic(newTypeError("Promise.prototype.then called on a non-promise"))
+	}
+	capability := runtime.newPromiseCapability(toValue_object(runtime.speciesConstructor(promise, runtime.Global.Promise)))
+	return runtime.performPromiseThen(promise, call.Argument(0), call.Argument(1), capability)
+}
+
+func builtinPromise_catch(call FunctionCall) Value {
+	return call.runtime.invoke(call.This, "then", UndefinedValue(), call.Argument(0))
+}
+
+func builtinPromise_finally(call FunctionCall) Value {
+	runtime := call.runtime
+	if !call.This.IsObject() {
+		panic(newTypeError("Promise.prototype.finally called on a non-object"))
+	}
+	constructor := runtime.speciesConstructor(call.This._object(), runtime.Global.Promise)
+
+	onFinally := call.Argument(0)
+	thenFinally, catchFinally := onFinally, onFinally
+	if onFinally.isCallable() {
+		// Call onFinally, wait on the result, and then pass through the
+		// original value (or reason)
+		finally := func(passThrough *_object) Value {
+			result := runtime.Call(onFinally._object(), UndefinedValue(), nil, false)
+			promise := runtime.promiseResolve(constructor, result)
+			return runtime.invoke(promise, "then", toValue_object(passThrough))
+		}
+		thenFinally = toValue_object(runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+			value := call.Argument(0)
+			return finally(runtime.newBuiltinFunction(0, func(FunctionCall) Value {
+				return value
+			}))
+		}))
+		catchFinally = toValue_object(runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+			reason := call.Argument(0)
+			return finally(runtime.newBuiltinFunction(0, func(FunctionCall) Value {
+				panic(newException(reason))
+			}))
+		}))
+	}
+	return runtime.invoke(call.This, "then", thenFinally, catchFinally)
+}
+
+func builtinPromise_resolve(call FunctionCall) Value {
+	if !call.This.IsObject() {
+		panic(newTypeError("Promise.resolve called on a non-object"))
+	}
+	return call.runtime.promiseResolve(call.This._object(), call.Argument(0))
+}
+
+func builtinPromise_reject(call FunctionCall) Value {
+	runtime := call.runtime
+	capability := runtime.newPromiseCapability(call.This)
+	runtime.Call(capability.reject._object(), UndefinedValue(), []Value{call.Argument(0)}, false)
+	return capability.promise
+}
+
+// promiseCombine implements Promise.all, Promise.race, and Promise.allSettled:
+// combine is called with the elements of the iterable, a function to resolve each
+// one (with the resolve of the constructor), and the capability of the result
+//
+// If anything throws, the result is rejected (rather than throwing).
+func promiseCombine(call FunctionCall, combine func(valueList []Value, resolve func(Value) Value, capability *_promiseCapability)) Value {
+	runtime := call.runtime
+	capability := runtime.newPromiseCapability(call.This)
+	constructor := call.This
+
+	value, exception := runtime.tryCatchEvaluate(func() Value {
+		resolve := constructor._object().get("resolve")
+		if !resolve.isCallable() {
+			panic(newTypeError("Promise resolve is not a function"))
+		}
+		combine(runtime.iterableToList(call.Argument(0)), func(value Value) Value {
+			return runtime.Call(resolve._object(), constructor, []Value{value}, false)
+		}, capability)
+		return UndefinedValue()
+	})
+	if exception {
+		runtime.Call(capability.reject._object(), UndefinedValue(), []Value{value}, false)
+	}
+	return capability.promise
+}
+
+// promiseCombineEach calls settle for each element of valueList, with the
+// index of the element, and (once all of them have settled) calls done
+func promiseCombineEach(valueList []Value, settle func(index int, value Value, settled func()), done func()) {
+	remaining := 1
+	settled := func() {
+		remaining--
+		if remaining == 0 {
+			done()
+		}
+	}
+	for index, value := range valueList {
+		remaining++
+		settle(index, value, settled)
+	}
+	settled()
+}
+
+func builtinPromise_all(call FunctionCall) Value {
+	runtime := call.runtime
+	return promiseCombine(call, func(valueList []Value, resolve func(Value) Value, capability *_promiseCapability) {
+		resultList := make([]Value, len(valueList))
+		promiseCombineEach(valueList, func(index int, value Value, settled func()) {
+			alreadyCalled := false
+			onFulfilled := runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+				if !alreadyCalled {
+					alreadyCalled = true
+					resultList[index] = call.Argument(0)
+					settled()
+				}
+				return UndefinedValue()
+			})
+			runtime.invoke(resolve(value), "then", toValue_object(onFulfilled), capability.reject)
+		}, func() {
+			runtime.Call(capability.resolve._object(), UndefinedValue(), []Value{toValue_object(runtime.newArrayOf(resultList))}, false)
+		})
+	})
+}
+
+func builtinPromise_allSettled(call FunctionCall) Value {
+	runtime := call.runtime
+	return promiseCombine(call, func(valueList []Value, resolve func(Value) Value, capability *_promiseCapability) {
+		resultList := make([]Value, len(valueList))
+		promiseCombineEach(valueList, func(index int, value Value, settled func()) {
+			alreadyCalled := false
+			onSettled := func(status string, key string) *_object {
+				return runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+					if !alreadyCalled {
+						alreadyCalled = true
+						result := runtime.newObject()
+						result.put("status", toValue_string(status), true)
+						result.put(key, call.Argument(0), true)
+						resultList[index] = toValue_object(result)
+						settled()
+					}
+					return UndefinedValue()
+				})
+			}
+			runtime.invoke(resolve(value), "then", toValue_object(onSettled("fulfilled", "value")), toValue_object(onSettled("rejected", "reason")))
+		}, func() {
+			runtime.Call(capability.resolve._object(), UndefinedValue(), []Value{toValue_object(runtime.newArrayOf(resultList))}, false)
+		})
+	})
+}
+
+func builtinPromise_race(call FunctionCall) Value {
+	runtime := call.runtime
+	return promiseCombine(call, func(valueList []Value, resolve func(Value) Value, capability *_promiseCapability) {
+		for _, value := range valueList {
+			runtime.invoke(resolve(value), "then", capability.resolve, capability.reject)
+		}
+	})
+}
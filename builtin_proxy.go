@@ -0,0 +1,24 @@
+package otto
+
+// Proxy
+
+func builtinProxy(call FunctionCall) Value {
+	panic(newTypeError("Constructor Proxy requires 'new'"))
+}
+
+func builtinNewProxy(self *_object, _ Value, argumentList []Value) Value {
+	return toValue_object(self.runtime.newProxy(valueOfArrayIndex(argumentList, 0), valueOfArrayIndex(argumentList, 1)))
+}
+
+func builtinProxy_revocable(call FunctionCall) Value {
+	runtime := call.runtime
+	proxy := runtime.newProxy(call.Argument(0), call.Argument(1))
+	revoke := runtime.newBuiltinFunction(0, func(FunctionCall) Value {
+		proxy.proxyValue().revoke()
+		return UndefinedValue()
+	})
+	result := runtime.newObject()
+	result.put("proxy", toValue_object(proxy), true)
+	result.put("revoke", toValue_object(revoke), true)
+	return toValue_object(result)
+}
@@ -0,0 +1,126 @@
+package otto
+
+// Reflect
+
+// reflectTarget returns the first argument, which must be an object
+func reflectTarget(call FunctionCall, name string) *_object {
+	if object := call.Argument(0)._object(); object != nil {
+		return object
+	}
+	panic(newTypeError("Reflect.%s called on non-object", name))
+}
+
+// listFromArrayLike returns the elements of value, an object with a length (e.g. an array)
+func listFromArrayLike(value Value) []Value {
+	object := value._object()
+	if object == nil {
+		panic(newTypeError("%v is not an object", value))
+	}
+	length := int64(toUint32(object.get("length")))
+	valueArray := make([]Value, length)
+	for index := int64(0); index < length; index++ {
+		valueArray[index] = object.get(arrayIndexToString(index))
+	}
+	return valueArray
+}
+
+func builtinReflect_apply(call FunctionCall) Value {
+	function := call.Argument(0)
+	if !function.isCallable() {
+		panic(newTypeError("%v is not a function", function))
+	}
+	return call.runtime.Call(function._object(), call.Argument(1), listFromArrayLike(call.Argument(2)), false)
+}
+
+func builtinReflect_construct(call FunctionCall) Value {
+	constructor := call.Argument(0)
+	if !constructor.isCallable() || constructor._object().functionValue().construct == nil {
+		panic(newTypeError("%v is not a constructor", constructor))
+	}
+	newTarget := constructor
+	if value, exists := call.getArgument(2); exists {
+		newTarget = value
+		if !newTarget.isCallable() || newTarget._object().functionValue().construct == nil {
+			panic(newTypeError("%v is not a constructor", newTarget))
+		}
+	}
+	return call.runtime.construct(constructor._object(), newTarget._object(), listFromArrayLike(call.Argument(1)))
+}
+
+func builtinReflect_defineProperty(call FunctionCall) Value {
+	object := reflectTarget(call, "defineProperty")
+	name := toPropertyKey(call.Argument(1))
+	descriptor := toPropertyDescriptor(call.Argument(2))
+	return toValue_bool(object.defineOwnProperty(name, descriptor, false))
+}
+
+func builtinReflect_deleteProperty(call FunctionCall) Value {
+	object := reflectTarget(call, "deleteProperty")
+	return toValue_bool(object.delete(toPropertyKey(call.Argument(1)), false))
+}
+
+func builtinReflect_get(call FunctionCall) Value {
+	object := reflectTarget(call, "get")
+	receiver := call.Argument(0)
+	if value, exists := call.getArgument(2); exists {
+		receiver = value
+	}
+	return object.getFor(toPropertyKey(call.Argument(1)), receiver)
+}
+
+func builtinReflect_getOwnPropertyDescriptor(call FunctionCall) Value {
+	object := reflectTarget(call, "getOwnPropertyDescriptor")
+	descriptor := object.getOwnProperty(toPropertyKey(call.Argument(1)))
+	if descriptor == nil {
+		return UndefinedValue()
+	}
+	return toValue_object(call.runtime.fromPropertyDescriptor(*descriptor))
+}
+
+func builtinReflect_getPrototypeOf(call FunctionCall) Value {
+	prototype := reflectTarget(call, "getPrototypeOf").getPrototypeOf()
+	if prototype == nil {
+		return NullValue()
+	}
+	return toValue_object(prototype)
+}
+
+func builtinReflect_has(call FunctionCall) Value {
+	object := reflectTarget(call, "has")
+	return toValue_bool(object.hasProperty(toPropertyKey(call.Argument(1))))
+}
+
+func builtinReflect_isExtensible(call FunctionCall) Value {
+	return toValue_bool(reflectTarget(call, "isExtensible").isExtensible())
+}
+
+func builtinReflect_ownKeys(call FunctionCall) Value {
+	keys := []Value{}
+	reflectTarget(call, "ownKeys").enumerate(true, func(name string) bool {
+		keys = append(keys, propertyKeyValue(name))
+		return true
+	})
+	return toValue_object(call.runtime.newArrayOf(keys))
+}
+
+func builtinReflect_preventExtensions(call FunctionCall) Value {
+	return toValue_bool(reflectTarget(call, "preventExtensions").preventExtensions())
+}
+
+func builtinReflect_set(call FunctionCall) Value {
+	object := reflectTarget(call, "set")
+	receiver := call.Argument(0)
+	if value, exists := call.getArgument(3); exists {
+		receiver = value
+	}
+	return toValue_bool(object.setFor(toPropertyKey(call.Argument(1)), call.Argument(2), receiver))
+}
+
+func builtinReflect_setPrototypeOf(call FunctionCall) Value {
+	object := reflectTarget(call, "setPrototypeOf")
+	prototype := call.Argument(1)
+	if !prototype.IsObject() && !prototype.IsNull() {
+		panic(newTypeError("Object prototype may only be an Object or null: %v", prototype))
+	}
+	return toValue_bool(object.setPrototypeOf(prototype._object()))
+}
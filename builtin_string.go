@@ -18,6 +18,10 @@ func stringValueFromStringArgumentList(argumentList []Value) Value {
 }
 
 func builtinString(call FunctionCall) Value {
+	// String(symbol) is the description of the symbol (unlike "" + symbol, which throws)
+	if value := call.Argument(0); value.IsSymbol() {
+		return toValue_string(value.value.(_symbol).String())
+	}
 	return stringValueFromStringArgumentList(call.ArgumentList)
 }
 
@@ -40,6 +44,26 @@ func builtinString_fromCharCode(call FunctionCall) Value {
 	return toValue_string16(chrList)
 }
 
+// String.raw, the tag for a template with the raw strings (without escapes, etc. interpreted)
+func builtinString_raw(call FunctionCall) Value {
+	cooked := call.runtime.toObject(call.Argument(0))
+	raw := call.runtime.toObject(cooked.get("raw"))
+	length := int64(toUint32(raw.get("length")))
+	list := []string{}
+	for index := int64(0); index < length; index++ {
+		list = append(list, toString(raw.get(arrayIndexToString(index))))
+		if index+1 < length && index+1 < int64(len(call.ArgumentList)) {
+			list = append(list, toString(call.ArgumentList[index+1]))
+		}
+	}
+	size := 0
+	for _, value := range list {
+		size += len(value)
+	}
+	call.runtime.allocate(size)
+	return toValue_string(strings.Join(list, ""))
+}
+
 func builtinString_charAt(call FunctionCall) Value {
 	checkObjectCoercible(call.This)
 	value := toString(call.This)
@@ -69,6 +93,7 @@ func builtinString_concat(call FunctionCall) Value {
 	for _, item := range call.ArgumentList {
 		value.WriteString(toString(item))
 	}
+	call.runtime.allocate(value.Len())
 	return toValue_string(value.String())
 }
 
@@ -260,6 +285,7 @@ func builtinString_replace(call FunctionCall) Value {
 			searchObject.put("lastIndex", toValue_int(lastIndex), true)
 		}
 
+		call.runtime.allocate(len(result))
 		return toValue_string(string(result))
 	}
 
@@ -489,6 +515,11 @@ func builtinString_localeCompare(call FunctionCall) Value {
 	return toValue_int(1)
 }
 
+func builtinString_iterator(call FunctionCall) Value {
+	checkObjectCoercible(call.This)
+	return toValue_object(call.runtime.newStringIterator(toString(call.This)))
+}
+
 /*
 An alternate version of String.trim
 func builtinString_trim(call FunctionCall) Value {
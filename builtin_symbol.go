@@ -0,0 +1,63 @@
+package otto
+
+// Symbol
+
+func builtinSymbol(call FunctionCall) Value {
+	return toValue_symbol(newSymbol(call.Argument(0)))
+}
+
+func builtinNewSymbol(self *_object, _ Value, argumentList []Value) Value {
+	panic(newTypeError("Symbol is not a constructor"))
+}
+
+// thisSymbolValue returns the symbol of this, a symbol or a Symbol object
+func thisSymbolValue(call FunctionCall) _symbol {
+	value := call.This
+	if !value.IsSymbol() {
+		// Will throw a TypeError if ThisObject is not a Symbol
+		value = call.thisClassObject("Symbol").primitiveValue()
+	}
+	return value.value.(_symbol)
+}
+
+func builtinSymbol_toString(call FunctionCall) Value {
+	return toValue_string(thisSymbolValue(call).String())
+}
+
+func builtinSymbol_valueOf(call FunctionCall) Value {
+	return toValue_symbol(thisSymbolValue(call))
+}
+
+func builtinSymbol_toPrimitive(call FunctionCall) Value {
+	return toValue_symbol(thisSymbolValue(call))
+}
+
+func builtinSymbol_description(call FunctionCall) Value {
+	return thisSymbolValue(call).descriptionValue()
+}
+
+func builtinSymbol_for(call FunctionCall) Value {
+	key := toString(call.Argument(0))
+	runtime := call.runtime
+	if symbol, exists := runtime.symbolRegistry[key]; exists {
+		return toValue_symbol(symbol)
+	}
+	symbol := newSymbol(toValue_string(key))
+	if runtime.symbolRegistry == nil {
+		runtime.symbolRegistry = map[string]_symbol{}
+	}
+	runtime.symbolRegistry[key] = symbol
+	return toValue_symbol(symbol)
+}
+
+func builtinSymbol_keyFor(call FunctionCall) Value {
+	value := call.Argument(0)
+	if !value.IsSymbol() {
+		panic(newTypeError("%v is not a symbol", value))
+	}
+	symbol := value.value.(_symbol)
+	if registered, exists := call.runtime.symbolRegistry[symbol.description]; exists && registered.id == symbol.id {
+		return toValue_string(symbol.description)
+	}
+	return UndefinedValue()
+}
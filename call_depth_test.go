@@ -0,0 +1,73 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestMaxCallDepth(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function abc() {
+            return abc();
+        }
+        var def;
+        try {
+            abc();
+        } catch (error) {
+            def = error;
+        }
+        [ def instanceof RangeError, def.message ];
+    `, "true,Maximum call stack size exceeded")
+
+	// Recursion through a native function
+	test(`
+        function ghi() {
+            [ 1 ].forEach(ghi);
+        }
+        try {
+            ghi();
+        } catch (error) {
+            def = error;
+        }
+        def.message;
+    `, "Maximum call stack size exceeded")
+
+	// The depth is restored after the error
+	test(`
+        function jkl(depth) {
+            return depth > 0 ? jkl(depth - 1) : "jkl";
+        }
+        jkl(1000);
+    `, "jkl")
+
+	Otto := New()
+	Otto.SetMaxCallDepth(10)
+
+	_, err := Otto.Run(`
+        function mno(depth) {
+            return depth > 0 ? mno(depth - 1) : "mno";
+        }
+    `)
+	Is(err, nil)
+
+	value, err := Otto.Run(`mno(9)`)
+	Is(err, nil)
+	Is(value, "mno")
+
+	_, err = Otto.Run(`mno(10)`)
+	Is(err, "RangeError: Maximum call stack size exceeded (line 3)")
+
+	value, err = Otto.Copy().Run(`
+        try {
+            mno(10);
+        } catch (error) {
+            error.name;
+        }
+    `)
+	Is(err, nil)
+	Is(value, "RangeError")
+}
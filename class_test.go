@@ -0,0 +1,253 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestClass(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        class Abc {
+            constructor(value) {
+                this.value = value;
+            }
+            def() {
+                return "def:" + this.value;
+            }
+            get ghi() {
+                return "ghi:" + this.value;
+            }
+            set ghi(value) {
+                this.value = value;
+            }
+            static jkl() {
+                return "jkl:" + (this === Abc);
+            }
+        }
+        var abc = new Abc(1);
+        var result = [ abc.def(), abc.ghi, Abc.jkl(), typeof Abc, abc instanceof Abc, abc.constructor === Abc ];
+        abc.ghi = 2;
+        result.push(abc.value);
+        result;
+    `, "def:1,ghi:1,jkl:true,function,true,true,2")
+
+	// Methods are not enumerable
+	test(`
+        var abc = [];
+        for (var def in new Abc(1)) {
+            abc.push(def);
+        }
+        [ abc, Object.keys(Abc.prototype).length, Object.getOwnPropertyDescriptor(Abc, "prototype").writable ];
+    `, "value,0,false")
+
+	test(`raise: Abc(1)`, "TypeError: Class constructor Abc cannot be invoked without 'new'")
+
+	test(`raise: new (new Abc(1)).def()`, "TypeError: [function] is not a constructor")
+
+	// A class declaration is like let
+	test(`raise:
+        {
+            new Mno();
+            class Mno {}
+        }
+    `, "ReferenceError: Cannot access 'Mno' before initialization")
+
+	test(`
+        var abc = class {
+            def() { return "def" }
+        };
+        var ghi = class Jkl {
+            self() { return Jkl }
+        };
+        [ new abc().def(), new ghi().self() === ghi, typeof Jkl ];
+    `, "def,true,undefined")
+
+	// Without a constructor (of its own)
+	test(`
+        class Pqr {
+            get() { return "get" }
+            static set() { return "set" }
+            static() { return "static" }
+        }
+        var abc = new Pqr();
+        [ abc.get(), Pqr.set(), abc.static(), Object.getPrototypeOf(abc) === Pqr.prototype ];
+    `, "get,set,static,true")
+
+	// Class code is strict mode code
+	test(`
+        class Stu {
+            abc() { return this }
+        }
+        (function(){
+            var abc = new Stu().abc;
+            return abc() === undefined;
+        })();
+    `, "true")
+
+	test(`raise: class Vwx { constructor() {} constructor() {} }`, "SyntaxError: A class may only have one constructor")
+
+	test(`raise: class Vwx { static prototype() {} }`, "SyntaxError: Classes may not have a static property named 'prototype'")
+
+	test(`raise: if (true) class Vwx {}`, "SyntaxError: Lexical declaration cannot appear in a single-statement context")
+}
+
+func TestClass_extends(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        class Abc {
+            constructor(value) {
+                this.value = value;
+            }
+            def() {
+                return "Abc.def:" + this.value;
+            }
+            get ghi() {
+                return "Abc.ghi";
+            }
+            static jkl() {
+                return "Abc.jkl";
+            }
+        }
+        class Def extends Abc {
+            constructor(value) {
+                super(value + 1);
+                this.other = "other";
+            }
+            def() {
+                return "Def.def/" + super.def();
+            }
+            get ghi() {
+                return "Def.ghi/" + super.ghi;
+            }
+            static jkl() {
+                return "Def.jkl/" + super.jkl();
+            }
+        }
+        var def = new Def(1);
+        [
+            def.value, def.other, def.def(), def.ghi, Def.jkl(),
+            def instanceof Def, def instanceof Abc, Object.getPrototypeOf(Def) === Abc
+        ].join(";");
+    `, "2;other;Def.def/Abc.def:2;Def.ghi/Abc.ghi;Def.jkl/Abc.jkl;true;true;true")
+
+	// The default constructor passes on the arguments
+	test(`
+        class Ghi extends Abc {}
+        var ghi = new Ghi("xyzzy");
+        [ ghi.value, ghi.def(), ghi instanceof Abc ];
+    `, "xyzzy,Abc.def:xyzzy,true")
+
+	// An arrow function has the super of where it is defined
+	test(`
+        class Jkl extends Abc {
+            def() {
+                var arrow = () => super.def();
+                return arrow();
+            }
+        }
+        new Jkl(1).def();
+    `, "Abc.def:1")
+
+	test(`raise:
+        class Mno extends Abc {
+            constructor() {
+                this.value = 1;
+                super();
+            }
+        }
+        new Mno();
+    `, "ReferenceError: Must call super constructor in derived class before accessing 'this' or returning from derived constructor")
+
+	test(`raise:
+        class Mno extends Abc {
+            constructor() {}
+        }
+        new Mno();
+    `, "ReferenceError: Must call super constructor in derived class before accessing 'this' or returning from derived constructor")
+
+	test(`raise:
+        class Mno extends Abc {
+            constructor() {
+                super();
+                super();
+            }
+        }
+        new Mno();
+    `, "ReferenceError: Super constructor may only be called once")
+
+	test(`raise: class Mno extends 1 {}`, "TypeError: Class extends value 1 is not a constructor or null")
+
+	test(`raise: class Mno { constructor() { super() } }`, "SyntaxError: 'super' keyword unexpected here")
+
+	test(`raise: function mno() { return super.abc }`, "SyntaxError: 'super' keyword unexpected here")
+
+	// An ordinary (ES5) constructor
+	test(`
+        function Pqr(value) {
+            this.value = value;
+        }
+        Pqr.prototype.def = function() {
+            return "Pqr.def:" + this.value;
+        };
+        class Stu extends Pqr {
+            def() {
+                return "Stu/" + super.def();
+            }
+        }
+        var stu = new Stu(1);
+        [ stu.def(), stu instanceof Pqr, stu instanceof Stu ];
+    `, "Stu/Pqr.def:1,true,true")
+
+	// A class can be bound
+	test(`
+        var Vwx = Abc.bind(null, "bound");
+        new Vwx().value;
+    `, "bound")
+}
+
+func TestClass_builtin(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        class Abc extends Error {
+            constructor(message) {
+                super(message);
+                this.name = "Abc";
+            }
+        }
+        var abc = new Abc("xyzzy");
+        [ abc instanceof Abc, abc instanceof Error, abc.message, String(abc) ];
+    `, "true,true,xyzzy,Abc: xyzzy")
+
+	test(`
+        try {
+            throw new Abc("Nothing happens.");
+        } catch (error) {
+            error instanceof Abc ? error.message : "";
+        }
+    `, "Nothing happens.")
+
+	test(`
+        class Def extends Array {
+            sum() {
+                var result = 0;
+                for (var index = 0; index < this.length; index++) {
+                    result += this[index];
+                }
+                return result;
+            }
+        }
+        var def = new Def(1, 2, 3);
+        def.push(4);
+        [ def.length, def.sum(), Array.isArray(def), def instanceof Def, def instanceof Array ];
+    `, "4,10,true,true,true")
+}
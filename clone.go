@@ -2,6 +2,7 @@ package otto
 
 import (
 	"fmt"
+	"weak"
 )
 
 type _clone struct {
@@ -10,22 +11,34 @@ type _clone struct {
 		object                 map[*_object]*_object
 		objectEnvironment      map[*_objectEnvironment]*_objectEnvironment
 		declarativeEnvironment map[*_declarativeEnvironment]*_declarativeEnvironment
+		mapEntry               map[*_mapEntry]*_mapEntry
 	}
 }
 
 func (runtime *_runtime) clone() *_runtime {
 
-	self := &_runtime{}
+	self := &_runtime{
+		steps:   runtime.steps,
+		stepMax: runtime.stepMax,
+
+		maxCallDepth: runtime.maxCallDepth,
+
+		memoryUsage: runtime.memoryUsage - uint64(len(runtime.generators))*generatorMemory, // A body is not copied, see _generatorObject.clone
+		memoryLimit: runtime.memoryLimit,
+	}
 	clone := &_clone{
 		runtime: self,
 	}
 	clone.stash.object = make(map[*_object]*_object)
 	clone.stash.objectEnvironment = make(map[*_objectEnvironment]*_objectEnvironment)
 	clone.stash.declarativeEnvironment = make(map[*_declarativeEnvironment]*_declarativeEnvironment)
+	clone.stash.mapEntry = make(map[*_mapEntry]*_mapEntry)
 
 	globalObject := clone.object(runtime.GlobalObject)
 	self.GlobalEnvironment = self.newObjectEnvironment(globalObject, nil)
 	self.GlobalObject = globalObject
+	clone.stash.objectEnvironment[runtime.GlobalEnvironment] = self.GlobalEnvironment
+	self.GlobalLexicalEnvironment = clone.environment(runtime.GlobalLexicalEnvironment).(*_declarativeEnvironment)
 	self.Global = _global{
 		clone.object(runtime.Global.Object),
 		clone.object(runtime.Global.Function),
@@ -44,6 +57,14 @@ func (runtime *_runtime) clone() *_runtime {
 		clone.object(runtime.Global.SyntaxError),
 		clone.object(runtime.Global.URIError),
 		clone.object(runtime.Global.JSON),
+		clone.object(runtime.Global.Promise),
+		clone.object(runtime.Global.Symbol),
+		clone.object(runtime.Global.Map),
+		clone.object(runtime.Global.Set),
+		clone.object(runtime.Global.WeakMap),
+		clone.object(runtime.Global.WeakSet),
+		clone.object(runtime.Global.Proxy),
+		clone.object(runtime.Global.Reflect),
 
 		clone.object(runtime.Global.ObjectPrototype),
 		clone.object(runtime.Global.FunctionPrototype),
@@ -60,6 +81,18 @@ func (runtime *_runtime) clone() *_runtime {
 		clone.object(runtime.Global.ReferenceErrorPrototype),
 		clone.object(runtime.Global.SyntaxErrorPrototype),
 		clone.object(runtime.Global.URIErrorPrototype),
+		clone.object(runtime.Global.PromisePrototype),
+		clone.object(runtime.Global.SymbolPrototype),
+		clone.object(runtime.Global.IteratorPrototype),
+		clone.object(runtime.Global.ArrayIteratorPrototype),
+		clone.object(runtime.Global.StringIteratorPrototype),
+		clone.object(runtime.Global.MapPrototype),
+		clone.object(runtime.Global.SetPrototype),
+		clone.object(runtime.Global.WeakMapPrototype),
+		clone.object(runtime.Global.WeakSetPrototype),
+		clone.object(runtime.Global.MapIteratorPrototype),
+		clone.object(runtime.Global.SetIteratorPrototype),
+		clone.object(runtime.Global.GeneratorPrototype),
 	}
 
 	self.EnterGlobalExecutionContext()
@@ -67,6 +100,25 @@ func (runtime *_runtime) clone() *_runtime {
 	self.eval = self.GlobalObject.property["eval"].value.(Value).value.(*_object)
 	self.GlobalObject.prototype = self.Global.ObjectPrototype
 
+	if runtime.throwTypeErrorFunction != nil {
+		self.throwTypeErrorFunction = clone.object(runtime.throwTypeErrorFunction)
+	}
+
+	if runtime.templateObjects != nil {
+		self.templateObjects = make(map[weak.Pointer[_templateObjectNode]]*_object, len(runtime.templateObjects))
+		for node, object := range runtime.templateObjects {
+			self.templateObjects[node] = clone.object(object)
+		}
+		self.templateObjectsSweep = runtime.templateObjectsSweep
+	}
+
+	if runtime.symbolRegistry != nil {
+		self.symbolRegistry = make(map[string]_symbol, len(runtime.symbolRegistry))
+		for key, symbol := range runtime.symbolRegistry {
+			self.symbolRegistry[key] = symbol
+		}
+	}
+
 	return self
 }
 func (clone *_clone) object(self0 *_object) *_object {
@@ -96,6 +148,19 @@ func (clone *_clone) objectEnvironment(self0 *_objectEnvironment) (*_objectEnvir
 	return self1, false
 }
 
+// mapEntry returns the clone of an entry of a Map (or Set), which may be reached
+// first from the Map, or from an iterator of the Map
+func (clone *_clone) mapEntry(self0 *_mapEntry) *_mapEntry {
+	if self1, exists := clone.stash.mapEntry[self0]; exists {
+		return self1
+	}
+	self1 := &_mapEntry{}
+	clone.stash.mapEntry[self0] = self1
+	self1.key = clone.value(self0.key)
+	self1.value = clone.value(self0.value)
+	return self1
+}
+
 func (clone *_clone) value(self0 Value) Value {
 	self1 := self0
 	switch value := self0.value.(type) {
@@ -122,9 +187,18 @@ func (clone *_clone) environment(self0 _environment) _environment {
 
 func (clone *_clone) property(self0 _property) _property {
 	self1 := self0
-	if value, valid := self0.value.(Value); valid {
+	switch value := self0.value.(type) {
+	case Value:
 		self1.value = clone.value(value)
-	} else {
+	case _propertyGetSet:
+		getSet := _propertyGetSet{}
+		for index, function := range value {
+			if function != nil {
+				getSet[index] = clone.object(function)
+			}
+		}
+		self1.value = getSet
+	default:
 		panic(fmt.Errorf("self0.value.(Value) != true"))
 	}
 	return self1
@@ -0,0 +1,769 @@
+package otto
+
+import (
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+	"github.com/robertkrimen/otto/parser"
+)
+
+// The parser (see the parser package) produces an ast.Program, which is
+// then compiled into the (internal) node tree that the runtime evaluates.
+
+func parse(source string) (*_programNode, interface{}) {
+	return parseFile("", source)
+}
+
+func parseFile(filename, source string) (*_programNode, interface{}) {
+	return parseFileAt(filename, 1, source)
+}
+
+func parseFileAt(filename string, line int, source string) (*_programNode, interface{}) {
+	program, err := parser.ParseFileAt(filename, line, source)
+	if err != nil {
+		return nil, parseError(err)
+	}
+	return compileProgram(program), nil
+}
+
+func mustParse(source string) *_programNode {
+	return mustParseFile("", source)
+}
+
+func mustParseFile(filename, source string) *_programNode {
+	return mustParseFileAt(filename, 1, source)
+}
+
+func mustParseFileAt(filename string, line int, source string) *_programNode {
+	program, err := parseFileAt(filename, line, source)
+	if err != nil {
+		panic(err)
+	}
+	return program
+}
+
+// parseError converts an error from the parser into something
+// that can be panicked by (and caught from) the runtime
+func parseError(err error) interface{} {
+	switch err := err.(type) {
+	case *parser.Error:
+		if err.Name == "ReferenceError" {
+			return newReferenceError(err.Message)
+		}
+		return &_syntaxError{
+			Message:   err.Message,
+			Filename:  err.Position.Filename,
+			Line:      err.Position.Line,
+			Column:    err.Position.Column,
+			Character: err.Position.Offset + 1,
+		}
+	}
+	return err
+}
+
+// _compiler compiles the nodes of a single file
+type _compiler struct {
+	file *file.File
+}
+
+// position converts the span of an ast.Node into the position of a _node
+func (self *_compiler) position(node ast.Node) _position {
+	return _position{
+		file: self.file,
+		idx0: node.Idx0(),
+		idx1: node.Idx1(),
+	}
+}
+
+func compileProgram(in *ast.Program) *_programNode {
+	return (&_compiler{file: in.File}).compileProgram(in)
+}
+
+func (self *_compiler) compileProgram(in *ast.Program) *_programNode {
+	out := newProgramNode()
+	out.setPosition(self.position(in))
+	out.Body = self.compileStatementList(in.Body)
+	out.VariableList, out.FunctionList = self.compileDeclarationList(in.DeclarationList)
+	out.LexicalList = lexicalDeclarationList(in.Body)
+	out.strict = in.Strict
+	return out
+}
+
+// lexicalDeclarationList returns the let and const declarations of a block
+func lexicalDeclarationList(in []ast.Statement) []_lexicalDeclaration {
+	var out []_lexicalDeclaration
+	for _, statement := range in {
+		switch declaration := statement.(type) {
+		case *ast.LexicalDeclaration:
+			for _, variable := range declaration.List {
+				for _, name := range declaredNames(variable) {
+					out = append(out, _lexicalDeclaration{name, declaration.Token == "const"})
+				}
+			}
+		case *ast.ClassStatement:
+			out = append(out, _lexicalDeclaration{declaration.Class.Name.Name, false})
+		}
+	}
+	return out
+}
+
+func (self *_compiler) compileDeclarationList(in []ast.Declaration) (variableList, functionList []_declaration) {
+	for _, declaration := range in {
+		switch declaration := declaration.(type) {
+		case *ast.FunctionDeclaration:
+			function := declaration.Function
+			functionList = append(functionList, _declaration{function.Name.Name, self.compileFunction(function, false)})
+		case *ast.VariableDeclaration:
+			for _, variable := range declaration.List {
+				for _, name := range declaredNames(variable) {
+					variableList = append(variableList, _declaration{name, nil})
+				}
+			}
+		default:
+			panic(hereBeDragons("%T", declaration))
+		}
+	}
+	return
+}
+
+// declaredNames returns the names declared by variable (more than one, if it is
+// a destructuring declaration)
+func declaredNames(variable *ast.VariableExpression) []string {
+	if variable.Pattern != nil {
+		return boundNames(variable.Pattern, nil)
+	}
+	return []string{variable.Name}
+}
+
+// boundNames appends the names bound by target, an identifier or a pattern, to list
+func boundNames(target ast.Expression, list []string) []string {
+	switch target := target.(type) {
+	case *ast.Identifier:
+		list = append(list, target.Name)
+	case *ast.AssignmentPattern:
+		list = boundNames(target.Target, list)
+	case *ast.ArrayPattern:
+		for _, element := range target.Elements {
+			list = boundNames(element, list)
+		}
+		list = boundNames(target.Rest, list)
+	case *ast.ObjectPattern:
+		for _, property := range target.Properties {
+			list = boundNames(property.Value, list)
+		}
+		list = boundNames(target.Rest, list)
+	}
+	return list
+}
+
+func (self *_compiler) compileFunction(in *ast.FunctionLiteral, expression bool) *_functionNode {
+	out := newFunctionNode()
+	out.setPosition(self.position(in))
+	if in.Name != nil {
+		out.name = in.Name.Name
+	}
+	names := []string{}
+	out.Length = len(in.ParameterList)
+	for index, identifier := range in.ParameterList {
+		out.AddParameter(identifier.Name)
+		if in.ParameterPatternList != nil && in.ParameterPatternList[index] != nil {
+			if out.ParameterPatternList == nil {
+				out.ParameterPatternList = make([]_node, len(in.ParameterList))
+			}
+			pattern := in.ParameterPatternList[index]
+			out.ParameterPatternList[index] = self.compileExpression(pattern)
+			if _, isDefault := pattern.(*ast.AssignmentPattern); isDefault && index < out.Length {
+				out.Length = index
+			}
+			names = boundNames(pattern, names)
+		} else {
+			names = append(names, identifier.Name)
+		}
+	}
+	if in.Rest != nil {
+		out.Rest = self.compileExpression(in.Rest)
+		names = boundNames(in.Rest, names)
+	}
+	for _, name := range names {
+		if name == "arguments" {
+			out.ArgumentsIsParameter = true
+		}
+	}
+	out.Body = self.compileStatementList(in.Body.List)
+	out.VariableList, out.FunctionList = self.compileDeclarationList(in.DeclarationList)
+	out.LexicalList = lexicalDeclarationList(in.Body.List)
+	out.strict = in.Strict
+	out.arrow = in.Arrow
+	out.generator = in.Generator
+	out.async = in.Async
+	if expression && in.Name != nil {
+		// A named function expression can refer to itself (by name) from within
+		out.FunctionList = append([]_declaration{{in.Name.Name, out}}, out.FunctionList...)
+	}
+	return out
+}
+
+func (self *_compiler) compileStatementList(in []ast.Statement) []_node {
+	out := []_node{}
+	for _, statement := range in {
+		out = append(out, self.compileStatement(statement))
+	}
+	return out
+}
+
+// compileIterationBody flattens a block that is the body of a loop
+// (unless the block has its own environment)
+func (self *_compiler) compileIterationBody(in ast.Statement) []_node {
+	if block, ok := in.(*ast.BlockStatement); ok && len(lexicalDeclarationList(block.List)) == 0 {
+		return self.compileStatementList(block.List)
+	}
+	return []_node{self.compileStatement(in)}
+}
+
+func (self *_compiler) compileBlock(in *ast.BlockStatement) *_blockNode {
+	out := newBlockNode()
+	out.setPosition(self.position(in))
+	out.Body = self.compileStatementList(in.List)
+	out.LexicalList = lexicalDeclarationList(in.List)
+	if len(out.LexicalList) > 0 {
+		// The functions (which are also hoisted) are instantiated again in the
+		// environment of the block, so that they can refer to its let and const
+		for _, statement := range in.List {
+			if statement, ok := statement.(*ast.FunctionStatement); ok {
+				function := statement.Function
+				out.FunctionList = append(out.FunctionList, _declaration{function.Name.Name, self.compileFunction(function, false)})
+			}
+		}
+	}
+	return out
+}
+
+func (self *_compiler) compileLexicalDeclaration(in *ast.LexicalDeclaration) *_lexicalDeclarationNode {
+	out := newLexicalDeclarationNode(in.Token == "const")
+	out.setPosition(self.position(in))
+	for _, variable := range in.List {
+		out.VariableList = append(out.VariableList, self.compileVariableExpression(variable))
+	}
+	return out
+}
+
+func (self *_compiler) compileVariableStatement(in *ast.VariableStatement) *_variableDeclarationListNode {
+	out := newVariableDeclarationListNode()
+	out.setPosition(self.position(in))
+	for _, variable := range in.List {
+		out.VariableList = append(out.VariableList, self.compileVariableExpression(variable))
+	}
+	return out
+}
+
+func (self *_compiler) compileClass(in *ast.ClassLiteral) *_classNode {
+	name := ""
+	if in.Name != nil {
+		name = in.Name.Name
+	}
+	out := newClassNode(name)
+	out.setPosition(self.position(in))
+	if in.SuperClass != nil {
+		out.SuperClass = self.compileExpression(in.SuperClass)
+	}
+
+	constructor := in.Constructor
+	if constructor == nil {
+		// The default constructor, constructor() {}, or (if the class extends
+		// another) constructor(...arguments) { super(...arguments) }
+		constructor = &ast.FunctionLiteral{
+			Span:   in.Span,
+			Body:   &ast.BlockStatement{},
+			Strict: true,
+		}
+	}
+	out.Constructor = self.compileFunction(constructor, false)
+	out.Constructor.name = name
+	out.Constructor.class = true
+	out.Constructor.derived = in.SuperClass != nil
+	out.Constructor.implicit = in.Constructor == nil
+
+	for _, element := range in.Body {
+		function := self.compileFunction(element.Value, false)
+		function.name = element.Key
+		out.MethodList = append(out.MethodList, _classMethod{
+			Key:      element.Key,
+			Computed: self.compileComputedKey(element.Computed),
+			Kind:     element.Kind,
+			Static:   element.Static,
+			Function: function,
+		})
+	}
+	return out
+}
+
+// compileComputedKey compiles the expression of a computed key, if any
+func (self *_compiler) compileComputedKey(in ast.Expression) _node {
+	if in == nil {
+		return nil
+	}
+	return self.compileExpression(in)
+}
+
+func (self *_compiler) compileVariableExpression(in *ast.VariableExpression) *_variableDeclarationNode {
+	out := newVariableDeclarationNode(in.Name)
+	out.setPosition(self.position(in))
+	if in.Pattern != nil {
+		out.Pattern = self.compileExpression(in.Pattern)
+	}
+	if in.Initializer != nil {
+		out.Operator = "="
+		out.Initializer = self.compileExpression(in.Initializer)
+	}
+	return out
+}
+
+func (self *_compiler) compileStatement(in ast.Statement) _node {
+	switch in := in.(type) {
+
+	case *ast.BlockStatement:
+		return self.compileBlock(in)
+
+	case *ast.BreakStatement:
+		label := ""
+		if in.Label != nil {
+			label = in.Label.Name
+		}
+		return newBreakNode(label)
+
+	case *ast.ClassStatement:
+		// A class declaration is much like let Abc = class Abc {}
+		variable := newVariableDeclarationNode(in.Class.Name.Name)
+		variable.setPosition(self.position(in))
+		variable.Operator = "="
+		variable.Initializer = self.compileClass(in.Class)
+		out := newLexicalDeclarationNode(false)
+		out.setPosition(self.position(in))
+		out.VariableList = append(out.VariableList, variable)
+		return out
+
+	case *ast.ContinueStatement:
+		label := ""
+		if in.Label != nil {
+			label = in.Label.Name
+		}
+		return newContinueNode(label)
+
+	case *ast.DoWhileStatement:
+		out := newDoWhileNode(self.compileExpression(in.Test), self.compileIterationBody(in.Body))
+		out.setPosition(self.position(in))
+		out.labelSet[""] = true
+		return out
+
+	case *ast.EmptyStatement:
+		out := newEmptyNode()
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.ExpressionStatement:
+		return self.compileExpression(in.Expression)
+
+	case *ast.ForInStatement:
+		var into _node
+		if variable, ok := in.Into.(*ast.VariableExpression); ok {
+			into = self.compileVariableExpression(variable)
+		} else {
+			into = self.compileExpression(in.Into)
+		}
+		out := newForInNode(into, self.compileExpression(in.Source), self.compileIterationBody(in.Body))
+		out.setPosition(self.position(in))
+		switch in.Declaration {
+		case "let", "const":
+			for _, name := range declaredNames(in.Into.(*ast.VariableExpression)) {
+				out.LexicalList = append(out.LexicalList, _lexicalDeclaration{name, in.Declaration == "const"})
+			}
+		}
+		out.labelSet[""] = true
+		return out
+
+	case *ast.ForOfStatement:
+		var into _node
+		if variable, ok := in.Into.(*ast.VariableExpression); ok {
+			into = self.compileVariableExpression(variable)
+		} else {
+			into = self.compileExpression(in.Into)
+		}
+		out := newForOfNode(into, self.compileExpression(in.Source), self.compileIterationBody(in.Body))
+		out.setPosition(self.position(in))
+		switch in.Declaration {
+		case "let", "const":
+			for _, name := range declaredNames(in.Into.(*ast.VariableExpression)) {
+				out.LexicalList = append(out.LexicalList, _lexicalDeclaration{name, in.Declaration == "const"})
+			}
+		}
+		out.labelSet[""] = true
+		return out
+
+	case *ast.ForStatement:
+		var initial, test, update _node
+		var lexicalList []_lexicalDeclaration
+		switch initializer := in.Initializer.(type) {
+		case nil:
+		case *ast.VariableStatement:
+			initial = self.compileVariableStatement(initializer)
+		case *ast.LexicalDeclaration:
+			initial = self.compileLexicalDeclaration(initializer)
+			lexicalList = lexicalDeclarationList([]ast.Statement{initializer})
+		case ast.Expression:
+			initial = self.compileExpression(initializer)
+		}
+		if in.Test != nil {
+			test = self.compileExpression(in.Test)
+		}
+		if in.Update != nil {
+			update = self.compileExpression(in.Update)
+		}
+		out := newForNode(initial, test, update, self.compileIterationBody(in.Body))
+		out.setPosition(self.position(in))
+		out.LexicalList = lexicalList
+		out.labelSet[""] = true
+		return out
+
+	case *ast.FunctionStatement:
+		// The function itself is hoisted (see compileDeclarationList)
+		out := newEmptyNode()
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.IfStatement:
+		out := newIfNode(self.compileExpression(in.Test), self.compileStatement(in.Consequent))
+		out.setPosition(self.position(in))
+		if in.Alternate != nil {
+			out.Alternate = self.compileStatement(in.Alternate)
+		}
+		return out
+
+	case *ast.LexicalDeclaration:
+		return self.compileLexicalDeclaration(in)
+
+	case *ast.LabelledStatement:
+		out := self.compileStatement(in.Statement)
+		var labelSet _labelSet
+		switch out := out.(type) {
+		case *_blockNode:
+			labelSet = out.labelSet
+		case *_doWhileNode:
+			labelSet = out.labelSet
+		case *_whileNode:
+			labelSet = out.labelSet
+		case *_switchNode:
+			labelSet = out.labelSet
+		case *_forNode:
+			labelSet = out.labelSet
+		case *_forInNode:
+			labelSet = out.labelSet
+		case *_forOfNode:
+			labelSet = out.labelSet
+		}
+		if labelSet != nil {
+			labelSet[in.Label.Name] = true
+		}
+		return out
+
+	case *ast.ReturnStatement:
+		out := newReturnNode()
+		out.setPosition(self.position(in))
+		if in.Argument != nil {
+			out.Argument = self.compileExpression(in.Argument)
+		}
+		return out
+
+	case *ast.SwitchStatement:
+		out := newSwitchNode(self.compileExpression(in.Discriminant))
+		out.setPosition(self.position(in))
+		out.Default = in.Default
+		list := []ast.Statement{}
+		for _, clause := range in.Body {
+			list = append(list, clause.Consequent...)
+		}
+		out.LexicalList = lexicalDeclarationList(list)
+		for _, clause := range in.Body {
+			var caseNode *_caseNode
+			if clause.Test == nil {
+				caseNode = newDefaultCaseNode()
+			} else {
+				caseNode = newCaseNode(self.compileExpression(clause.Test))
+			}
+			caseNode.setPosition(self.position(clause))
+			caseNode.Body = self.compileStatementList(clause.Consequent)
+			out.AddCase(caseNode)
+		}
+		out.labelSet[""] = true
+		return out
+
+	case *ast.ThrowStatement:
+		out := newThrowNode(self.compileExpression(in.Argument))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.TryStatement:
+		out := newTryCatchNode(self.compileBlock(in.Body))
+		out.setPosition(self.position(in))
+		if in.Catch != nil {
+			out.Catch = newCatchNode(in.Catch.Parameter.Name, self.compileBlock(in.Catch.Body))
+			out.Catch.setPosition(self.position(in.Catch))
+			if in.Catch.Pattern != nil {
+				out.Catch.Pattern = self.compileExpression(in.Catch.Pattern)
+			}
+		}
+		if in.Finally != nil {
+			out.Finally = self.compileBlock(in.Finally)
+		}
+		return out
+
+	case *ast.VariableStatement:
+		return self.compileVariableStatement(in)
+
+	case *ast.WhileStatement:
+		out := newWhileNode(self.compileExpression(in.Test), self.compileIterationBody(in.Body))
+		out.setPosition(self.position(in))
+		out.labelSet[""] = true
+		return out
+
+	case *ast.WithStatement:
+		out := newWithNode(self.compileExpression(in.Object), self.compileStatement(in.Body))
+		out.setPosition(self.position(in))
+		return out
+	}
+
+	panic(hereBeDragons("%T", in))
+}
+
+func (self *_compiler) compileExpressionList(in []ast.Expression) []_node {
+	out := []_node{}
+	for _, expression := range in {
+		out = append(out, self.compileExpression(expression))
+	}
+	return out
+}
+
+func (self *_compiler) compileExpression(in ast.Expression) _node {
+	switch in := in.(type) {
+
+	case *ast.ArrayLiteral:
+		list := []_node{}
+		for _, value := range in.Value {
+			if value == nil {
+				// An array hole, e.g. [ 1, , 3 ]
+				list = append(list, newEmptyNode())
+				continue
+			}
+			list = append(list, self.compileExpression(value))
+		}
+		out := newArrayNode(list)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.ArrayPattern:
+		out := newArrayPatternNode()
+		out.setPosition(self.position(in))
+		for _, element := range in.Elements {
+			if element == nil {
+				out.ElementList = append(out.ElementList, nil)
+			} else {
+				out.ElementList = append(out.ElementList, self.compileExpression(element))
+			}
+		}
+		if in.Rest != nil {
+			out.Rest = self.compileExpression(in.Rest)
+		}
+		return out
+
+	case *ast.AssignExpression:
+		out := newAssignmentNode(in.Operator, self.compileExpression(in.Left), self.compileExpression(in.Right))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.AssignmentPattern:
+		out := newAssignmentPatternNode(self.compileExpression(in.Target), self.compileExpression(in.Initializer))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.BinaryExpression:
+		var out _node
+		switch in.Operator {
+		case "<", ">", "<=", ">=", "==", "!=", "===", "!==":
+			out = newComparisonNode(in.Operator, self.compileExpression(in.Left), self.compileExpression(in.Right))
+		default:
+			out = newBinaryOperationNode(in.Operator, self.compileExpression(in.Left), self.compileExpression(in.Right))
+		}
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.BooleanLiteral:
+		out := newBooleanNode(in.Literal)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.BracketExpression:
+		out := newBracketMemberNode(self.compileExpression(in.Left), self.compileExpression(in.Member))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.CallExpression:
+		if _, ok := in.Callee.(*ast.SuperExpression); ok {
+			out := newSuperCallNode()
+			out.setPosition(self.position(in))
+			out.ArgumentList = self.compileExpressionList(in.ArgumentList)
+			return out
+		}
+		out := newCallNode(self.compileExpression(in.Callee))
+		out.setPosition(self.position(in))
+		out.ArgumentList = self.compileExpressionList(in.ArgumentList)
+		return out
+
+	case *ast.ClassLiteral:
+		return self.compileClass(in)
+
+	case *ast.ConditionalExpression:
+		out := newConditionalNode(self.compileExpression(in.Test), self.compileExpression(in.Consequent), self.compileExpression(in.Alternate))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.DotExpression:
+		out := newDotMemberNode(self.compileExpression(in.Left), in.Identifier)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.FunctionLiteral:
+		return self.compileFunction(in, true)
+
+	case *ast.Identifier:
+		out := newIdentifierNode(in.Name)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.NewExpression:
+		out := newNewNode(self.compileExpression(in.Callee))
+		out.setPosition(self.position(in))
+		out.ArgumentList = self.compileExpressionList(in.ArgumentList)
+		return out
+
+	case *ast.NullLiteral:
+		out := newNullNode(in.Literal)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.NumberLiteral:
+		out := newNumberNode(in.Literal)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.ObjectLiteral:
+		out := newObjectNode()
+		out.setPosition(self.position(in))
+		for _, property := range in.Value {
+			var value _node
+			if function, valid := property.Value.(*ast.FunctionLiteral); valid && property.Kind != "value" {
+				// A method (or getter or setter), which has the object as its home, see evaluateObject
+				method := self.compileFunction(function, false)
+				method.name = property.Key
+				value = method
+			} else {
+				value = self.compileExpression(property.Value)
+			}
+			propertyNode := newObjectPropertyNode(property.Key, property.Kind, value)
+			propertyNode.Computed = self.compileComputedKey(property.Computed)
+			propertyNode.setPosition(self.position(property))
+			out.AddProperty(propertyNode)
+		}
+		return out
+
+	case *ast.ObjectPattern:
+		out := newObjectPatternNode()
+		out.setPosition(self.position(in))
+		for _, property := range in.Properties {
+			out.PropertyList = append(out.PropertyList, _patternProperty{property.Key, self.compileComputedKey(property.Computed), self.compileExpression(property.Value)})
+		}
+		if in.Rest != nil {
+			out.Rest = self.compileExpression(in.Rest)
+		}
+		return out
+
+	case *ast.RegExpLiteral:
+		out := newRegExpNode(in.Pattern, in.Flags)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.SequenceExpression:
+		out := newCommaNode(self.compileExpressionList(in.Sequence))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.SpreadElement:
+		out := newSpreadNode(self.compileExpression(in.Argument))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.StringLiteral:
+		out := newStringNode(in.Value)
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.SuperExpression:
+		out := newSuperNode()
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.TemplateLiteral:
+		cooked, raw := []string{}, []string{}
+		for _, element := range in.Elements {
+			cooked = append(cooked, element.Value)
+			raw = append(raw, element.Raw)
+		}
+		if in.Tag == nil {
+			out := newTemplateNode(cooked, self.compileExpressionList(in.Expressions))
+			out.setPosition(self.position(in))
+			return out
+		}
+		// A tagged template is a call of the tag with the strings (and then the substitutions)
+		strings := newTemplateObjectNode(cooked, raw)
+		strings.setPosition(self.position(in))
+		out := newCallNode(self.compileExpression(in.Tag))
+		out.setPosition(self.position(in))
+		out.ArgumentList = append([]_node{strings}, self.compileExpressionList(in.Expressions)...)
+		return out
+
+	case *ast.ThisExpression:
+		out := newThisNode()
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.UnaryExpression:
+		operator := in.Operator
+		switch operator {
+		case "++", "--":
+			if in.Postfix {
+				operator = "=" + operator // =++ or =--
+			} else {
+				operator = operator + "=" // ++= or --=
+			}
+		}
+		out := newUnaryOperationNode(operator, self.compileExpression(in.Operand))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.VariableExpression:
+		return self.compileVariableExpression(in)
+
+	case *ast.AwaitExpression:
+		out := newAwaitNode(self.compileExpression(in.Argument))
+		out.setPosition(self.position(in))
+		return out
+
+	case *ast.YieldExpression:
+		var argument _node
+		if in.Argument != nil {
+			argument = self.compileExpression(in.Argument)
+		}
+		out := newYieldNode(argument, in.Delegate)
+		out.setPosition(self.position(in))
+		return out
+	}
+
+	panic(hereBeDragons("%T", in))
+}
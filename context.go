@@ -0,0 +1,87 @@
+package otto
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrInterrupted is the error returned by RunContext and CallContext when
+// the context is canceled before the JavaScript finishes running.
+//
+// If the deadline of the context is exceeded, then the error returned is
+// context.DeadlineExceeded instead.
+var ErrInterrupted = errors.New("otto: execution interrupted")
+
+// _interrupt is panicked by evaluate to unwind the runtime when the context
+// of a run is done. It cannot be caught by JavaScript (try/catch).
+type _interrupt struct {
+	err error
+}
+
+// RunContext is like Run, except that the run is interrupted (and
+// ErrInterrupted or context.DeadlineExceeded is returned) if ctx is done
+// before the JavaScript finishes running.
+func (self Otto) RunContext(ctx context.Context, src interface{}) (Value, error) {
+	defer self.runtime.watchContext(ctx)()
+	return self.Run(src)
+}
+
+// CallContext is like Call, except that the call is interrupted (and
+// ErrInterrupted or context.DeadlineExceeded is returned) if ctx is done
+// before the JavaScript finishes running.
+func (self Otto) CallContext(ctx context.Context, source interface{}, this interface{}, argumentList ...interface{}) (Value, error) {
+	defer self.runtime.watchContext(ctx)()
+	return self.Call(source, this, argumentList...)
+}
+
+// watchContext interrupts the runtime when ctx is done, until the
+// returned function is called
+func (self *_runtime) watchContext(ctx context.Context) func() {
+	done := ctx.Done()
+	if done == nil {
+		return func() {} // A context that is never done (e.g. context.Background)
+	}
+
+	reset := func() {
+		atomic.StoreInt32(&self.interrupt, 0)
+		self.interruptErr = nil
+	}
+
+	if ctx.Err() != nil {
+		// Already done, so do not run at all
+		self.interruptWith(ctx.Err())
+		return reset
+	}
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-done:
+			self.interruptWith(ctx.Err())
+		case <-stop:
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+		reset()
+	}
+}
+
+func (self *_runtime) interruptWith(err error) {
+	if err != context.DeadlineExceeded {
+		err = ErrInterrupted
+	}
+	self.interruptErr = err
+	atomic.StoreInt32(&self.interrupt, 1)
+}
+
+// checkInterrupt unwinds the runtime if the context of the current run is done
+func (self *_runtime) checkInterrupt() {
+	if atomic.LoadInt32(&self.interrupt) != 0 {
+		panic(_interrupt{err: self.interruptErr})
+	}
+}
@@ -0,0 +1,91 @@
+package otto
+
+import (
+	. "./terst"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunContext(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	{
+		value, err := Otto.RunContext(context.Background(), `1 + 1`)
+		Is(err, nil)
+		Is(value, "2")
+	}
+
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := Otto.RunContext(ctx, `
+            while (true) {
+                try {
+                    for (;;) {}
+                } catch (error) {
+                    // An interruption cannot be caught
+                }
+            }
+        `)
+		Is(err == context.DeadlineExceeded, true)
+	}
+
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		_, err := Otto.RunContext(ctx, `
+            function abc() {
+                return abc();
+            }
+            while (true) {}
+        `)
+		Is(err == ErrInterrupted, true)
+	}
+
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := Otto.RunContext(ctx, `xyzzy = 1`)
+		Is(err == ErrInterrupted, true)
+		value, _ := Otto.Run(`typeof xyzzy`)
+		Is(value, "undefined")
+	}
+
+	// The runtime is still usable afterwards
+	{
+		value, err := Otto.Run(`[ 1, 2, 3 ].join("")`)
+		Is(err, nil)
+		Is(value, "123")
+	}
+}
+
+func TestCallContext(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+	Otto.Run(`
+        function abc(def) {
+            while (def) {}
+            return "ghi";
+        }
+    `)
+
+	value, err := Otto.CallContext(context.Background(), "abc", nil, false)
+	Is(err, nil)
+	Is(value, "ghi")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = Otto.CallContext(ctx, "abc", nil, true)
+	Is(err == context.DeadlineExceeded, true)
+
+	value, err = Otto.Call("abc", nil, false)
+	Is(err, nil)
+	Is(value, "ghi")
+}
@@ -0,0 +1,141 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestDestructuring(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var [ abc, , def = "def", ...ghi ] = [ 1, 2, undefined, 4, 5 ];
+        [ abc, def, ghi.length, ghi ].join(";");
+    `, "1;def;2;4,5")
+
+	test(`
+        var { abc, def: { ghi }, jkl = "jkl", ...mno } = { abc: 1, def: { ghi: 2 }, pqr: 3, stu: 4 };
+        [ abc, ghi, jkl, Object.keys(mno) ].join(";");
+    `, "1;2;jkl;pqr,stu")
+
+	// The default is only evaluated if the value is undefined
+	test(`
+        var abc = 0;
+        var [ def = abc++, ghi = abc++ ] = [ null ];
+        [ def, ghi, abc ];
+    `, ",0,1")
+
+	// Strings (and other array-like values) can be destructured as arrays
+	test(`
+        var [ abc, def ] = "xyzzy";
+        abc + def;
+    `, "xy")
+
+	test(`raise: var { abc } = null;`, "TypeError: Cannot destructure 'null' as it is null.")
+
+	test(`raise: var [ abc ] = undefined;`, "TypeError: Cannot destructure 'undefined' as it is undefined.")
+}
+
+func TestDestructuring_lexical(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        const { abc, def: [ ghi, jkl ] } = { abc: 1, def: [ 2, 3 ] };
+        var sum;
+        {
+            let [ abc, def ] = [ 4, 5 ];
+            sum = ghi + abc + def;
+        }
+        [ abc, sum, jkl, typeof def ];
+    `, "1,11,3,undefined")
+
+	test(`raise:
+        const [ mno ] = [ 1 ];
+        mno = 2;
+    `, "TypeError: Assignment to constant variable.")
+
+	test(`
+        var pqr = [];
+        for (const [ def, ghi ] in { xy: 1, zw: 2 }) {
+            pqr.push(function(){ return ghi + def });
+        }
+        [ pqr[0](), pqr[1](), typeof ghi ];
+    `, "yx,wz,number")
+}
+
+func TestDestructuring_assignment(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = 1, def = 2;
+        [ abc, def ] = [ def, abc ];
+        [ abc, def ];
+    `, "2,1")
+
+	test(`
+        var abc = {}, def, ghi, jkl;
+        var result = ({ def, ghi: abc.ghi, jkl: [ jkl = "jkl" ] = [] } = { def: 1, ghi: 2 });
+        [ def, abc.ghi, jkl, result.def ];
+    `, "1,2,jkl,1")
+
+	test(`
+        var abc, def;
+        [ abc, ...def ] = [ 1, 2, 3 ];
+        [ abc, def.length ];
+    `, "1,2")
+
+	test(`
+        var abc = [];
+        var def;
+        for ([ def ] in { xyzzy: 1 }) {
+            abc.push(def);
+        }
+        abc;
+    `, "x")
+}
+
+func TestDestructuring_parameter(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function abc({ def, ghi = "ghi" }, [ jkl, mno ], pqr) {
+            return [ def, ghi, jkl, mno, pqr, arguments.length ].join(";");
+        }
+        abc({ def: 1 }, [ 2, 3 ], 4);
+    `, "1;ghi;2;3;4;3")
+
+	test(`
+        var abc = ({ def }, [ ghi ]) => def + ghi;
+        abc({ def: 1 }, [ 2 ]);
+    `, "3")
+
+	test(`raise:
+        function abc({ def }) {}
+        abc();
+    `, "TypeError: Cannot destructure 'undefined' as it is undefined.")
+
+	// arguments does not alias the parameters
+	test(`
+        function abc(def, [ ghi ]) {
+            def = 2;
+            return arguments[0];
+        }
+        abc(1, []);
+    `, "1")
+
+	test(`
+        try {
+            throw new TypeError("xyzzy");
+        } catch ({ name, message }) {
+            name + ": " + message;
+        }
+    `, "TypeError: xyzzy")
+}
@@ -159,7 +159,9 @@ func (self *_objectEnvironment) Outer() _environment {
 }
 
 func (self *_objectEnvironment) newReference(name string, strict bool) _reference {
-	return newPropertyReference(self.Object, name, strict, nil)
+	reference := newPropertyReference(self.Object, name, strict, nil)
+	reference.environment = self
+	return reference
 }
 
 // _declarativeEnvironment
@@ -193,7 +195,7 @@ type _declarativeProperty struct {
 	value     Value
 	mutable   bool
 	deletable bool
-	readable  bool
+	readable  bool // false for a let or const binding that has not been initialized yet
 }
 
 type _declarativeEnvironment struct {
@@ -216,21 +218,46 @@ func (self *_declarativeEnvironment) CreateMutableBinding(name string, deletable
 		value:     UndefinedValue(),
 		mutable:   true,
 		deletable: deletable,
+		readable:  true,
+	}
+}
+
+// CreateLexicalBinding creates (or replaces) the let or const (not mutable) binding
+// of the given name, which is not readable until it is initialized (see InitializeBinding)
+func (self *_declarativeEnvironment) CreateLexicalBinding(name string, mutable bool) {
+	self.property[name] = _declarativeProperty{
+		value:     UndefinedValue(),
+		mutable:   mutable,
+		deletable: false,
 		readable:  false,
 	}
 }
 
+func (self *_declarativeEnvironment) InitializeBinding(name string, value Value) {
+	property, exists := self.property[name]
+	if !exists {
+		panic(fmt.Errorf("InitializeBinding: %s: missing", name))
+	}
+	property.value = value
+	property.readable = true
+	self.property[name] = property
+}
+
 func (self *_declarativeEnvironment) SetMutableBinding(name string, value Value, strict bool) {
 	property, exists := self.property[name]
 	if !exists {
 		panic(fmt.Errorf("SetMutableBinding: %s: missing", name))
 	}
-	if property.mutable {
-		property.value = value
-		self.property[name] = property
-	} else {
-		typeErrorResult(strict)
+	if !property.readable {
+		// Assignment in the temporal dead zone (before the declaration)
+		panic(newReferenceError("Cannot access '%s' before initialization", name))
+	}
+	if !property.mutable {
+		// Even outside of strict mode code
+		panic(newTypeError("Assignment to constant variable."))
 	}
+	property.value = value
+	self.property[name] = property
 }
 
 func (self *_declarativeEnvironment) SetValue(name string, value Value, throw bool) {
@@ -245,11 +272,8 @@ func (self *_declarativeEnvironment) GetBindingValue(name string, strict bool) V
 	if !exists {
 		panic(fmt.Errorf("GetBindingValue: %s: missing", name))
 	}
-	if !property.mutable && !property.readable {
-		if strict {
-			panic(newTypeError())
-		}
-		return UndefinedValue()
+	if !property.readable {
+		panic(newReferenceError("Cannot access '%s' before initialization", name))
 	}
 	return property.value
 }
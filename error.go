@@ -1,12 +1,15 @@
 package otto
 
 import (
-	"errors"
 	"fmt"
+
+	"github.com/robertkrimen/otto/file"
 )
 
 type _exception struct {
-	value interface{}
+	value    interface{}
+	position _position // Where the exception was thrown
+	stack    []_frame
 }
 
 func newException(value interface{}) *_exception {
@@ -25,7 +28,8 @@ type _error struct {
 	Name    string
 	Message string
 
-	Line int // Hackish -- line where the error/exception occurred
+	position _position // Where the error occurred
+	stack    []_frame
 }
 
 var messageDetail map[string]string = map[string]string{
@@ -74,10 +78,9 @@ func newError(name string, argumentList ...interface{}) _error {
 	error := _error{
 		Name:    name,
 		Message: messageFromDescription(description, argumentList...),
-		Line:    -1,
 	}
 	if node != nil {
-		error.Line = node.position()
+		error.position = node.position()
 	}
 	return error
 }
@@ -109,26 +112,166 @@ func typeErrorResult(throw bool) bool {
 	return false
 }
 
+// Error is the error returned when JavaScript cannot be parsed, or when
+// it throws an exception (or causes an error) that is not caught.
+//
+// Name is the name of the JavaScript error (e.g. "TypeError", "SyntaxError", or
+// the name of a user-defined error), and Message is its message. If a value
+// that is not an Error object was thrown (e.g. throw "Xyzzy"), then Name is
+// empty and Message is the value converted to a string.
+//
+// A Go function (called from JavaScript) can throw an Error (e.g. from Call)
+// again, as it was originally thrown, by panicking with it.
+type Error struct {
+	Name    string
+	Message string
+
+	value       Value // The value that was thrown, if any
+	description string
+	position    *file.Position
+	end         *file.Position
+	stack       []_frame
+
+	thrown interface{} // What the runtime panicked with (an _exception, _error, or *_syntaxError), see exception
+}
+
+// Error returns a description of the error, which includes the line
+// where the error occurred (unless a value was thrown).
+func (self *Error) Error() string {
+	return self.description
+}
+
+// String returns a description of the error and a JavaScript stack trace,
+// in the same format as the stack of an Error object:
+//
+//	TypeError: Nothing happens.
+//	    at xyzzy (xyzzy.js:3:9)
+//	    at xyzzy.js:6:1
+func (self *Error) String() string {
+	str := self.Name
+	if str == "" {
+		str = self.Message
+	} else if self.Message != "" {
+		str = fmt.Sprintf("%s: %s", str, self.Message)
+	}
+	if len(self.stack) > 0 {
+		return formatStack(str, self.stack)
+	}
+	if self.position != nil {
+		str += "\n    at " + self.position.String()
+	}
+	return str
+}
+
+// Value returns the value that was thrown, or undefined if the error was
+// raised by the runtime itself (a ReferenceError, for example) or by the parser.
+func (self *Error) Value() Value {
+	return self.value
+}
+
+// Position returns where the error occurred: the start of the failing
+// expression or statement, or the start of the offending token for a
+// SyntaxError. It returns nil if the position is not known.
+func (self *Error) Position() *file.Position {
+	return self.position
+}
+
+// End returns where the failing expression or statement ends, or nil
+// if that is not known (as is the case for a SyntaxError).
+func (self *Error) End() *file.Position {
+	return self.end
+}
+
+// exception returns what to panic with to throw the error again
+func (self *Error) exception() interface{} {
+	switch thrown := self.thrown.(type) {
+	case _exception:
+		return &thrown // A copy, since an _exception is ejected when caught
+	case nil:
+		if self.value.IsDefined() {
+			return self.value
+		}
+		return _error{
+			Name:    self.Name,
+			Message: self.Message,
+		}
+	}
+	return self.thrown
+}
+
+// newErrorFromValue returns the *Error for a thrown (and uncaught) value
+func newErrorFromValue(value Value, position _position, stack []_frame) *Error {
+	description := toString(value)
+	err := &Error{
+		Message:     description,
+		value:       value,
+		description: description,
+		position:    position.start(),
+		end:         position.end(),
+		stack:       stack,
+		thrown:      _exception{value: value, position: position, stack: stack},
+	}
+	if object := value._object(); object != nil && isErrorObject(object) {
+		err.Name = toString(object.get("name"))
+		err.Message = ""
+		if message := object.get("message"); message.IsDefined() {
+			err.Message = toString(message)
+		}
+	}
+	return err
+}
+
+// isErrorObject returns true if object is an Error object, or inherits from one
+// (e.g. an instance of a user-defined error)
+func isErrorObject(object *_object) bool {
+	for ; object != nil; object = object.prototype {
+		if object.class == "Error" {
+			return true
+		}
+	}
+	return false
+}
+
 func catchPanic(function func()) (err error) {
 	defer func() {
 		if caught := recover(); caught != nil {
+			position, stack := _position{}, []_frame(nil)
 			if exception, ok := caught.(*_exception); ok {
+				position, stack = exception.position, exception.stack
 				caught = exception.eject()
 			}
 			switch caught := caught.(type) {
 			case *_syntaxError:
-				err = errors.New(fmt.Sprintf("%s (line %d)", caught.String(), caught.Line+0))
+				err = &Error{
+					Name:        "SyntaxError",
+					Message:     caught.Message,
+					value:       UndefinedValue(),
+					description: fmt.Sprintf("%s (line %d)", caught.String(), caught.Line),
+					position:    caught.position(),
+					thrown:      caught,
+				}
 				return
 			case _error:
-				if caught.Line == -1 {
-					err = errors.New(caught.String())
-				} else {
-					// We're 0-based (for now), hence the + 1
-					err = errors.New(fmt.Sprintf("%s (line %d)", caught.String(), caught.Line+1))
+				description := caught.String()
+				if start := caught.position.start(); start != nil {
+					description = fmt.Sprintf("%s (line %d)", description, start.Line)
+				}
+				err = &Error{
+					Name:        caught.Name,
+					Message:     caught.Message,
+					value:       UndefinedValue(),
+					description: description,
+					position:    caught.position.start(),
+					end:         caught.position.end(),
+					stack:       caught.stack,
+					thrown:      caught,
 				}
 				return
 			case Value:
-				err = errors.New(toString(caught))
+				err = newErrorFromValue(caught, position, stack)
+				return
+			case _interrupt:
+				err = caught.err
 				return
 			}
 			panic(caught)
@@ -142,9 +285,22 @@ func catchPanic(function func()) (err error) {
 
 type _syntaxError struct {
 	Message   string
+	Filename  string
 	Line      int
 	Column    int
-	Character int
+	Character int // The (1-based) byte offset
+}
+
+func (self _syntaxError) position() *file.Position {
+	if self.Line == 0 {
+		return nil
+	}
+	return &file.Position{
+		Filename: self.Filename,
+		Offset:   self.Character - 1,
+		Line:     self.Line,
+		Column:   self.Column,
+	}
 }
 
 func (self _syntaxError) String() string {
@@ -154,12 +310,3 @@ func (self _syntaxError) String() string {
 	}
 	return fmt.Sprintf("%s: %s", name, self.Message)
 }
-
-func (self _token) newSyntaxError(description string, argumentList ...interface{}) *_syntaxError {
-	return &_syntaxError{
-		Message:   messageFromDescription(description, argumentList...),
-		Line:      self.Line,
-		Column:    self.Column,
-		Character: self.Character,
-	}
-}
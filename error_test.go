@@ -44,6 +44,52 @@ func TestPanicValue(t *testing.T) {
     `, "false,,3.14159")
 }
 
+func TestPanicError(t *testing.T) {
+	Terst(t)
+
+	// An *Error is thrown again as it was
+	Otto := New()
+	Otto.Set("abc", func(call FunctionCall) Value {
+		script, err := call.Otto.Compile("xyzzy.js", call.Argument(0).String())
+		if err == nil {
+			_, err = call.Otto.Run(script)
+		}
+		panic(err)
+	})
+
+	value, err := Otto.Run(`
+        var def = { ghi: 1 };
+        var jkl = [];
+        try {
+            abc("throw def");
+        } catch (error) {
+            jkl.push(error === def);
+        }
+        try {
+            abc("null.mno");
+        } catch (error) {
+            jkl.push(error instanceof TypeError, error.stack.split("\n")[1]);
+        }
+        try {
+            abc("abc abc");
+        } catch (error) {
+            jkl.push(error.name);
+        }
+        jkl;
+    `)
+	Is(err, nil)
+	Is(value, "true,true,    at xyzzy.js:1:1,SyntaxError")
+
+	_, err = Otto.Run(`abc("\n  throw new RangeError('xyzzy')")`)
+	Is(err.(*Error).Name, "RangeError")
+	Is(err.(*Error).Message, "xyzzy")
+	Is(err.(*Error).Position(), "xyzzy.js:2:3")
+
+	_, err = Otto.Run(`abc("\n  pqr")`)
+	Is(err, "ReferenceError: pqr is not defined (line 2)")
+	Is(err.(*Error).Position(), "xyzzy.js:2:3")
+}
+
 func Test_catchPanic(t *testing.T) {
 	Terst(t)
 
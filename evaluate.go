@@ -30,15 +30,27 @@ func (self *_runtime) evaluate(node _node) Value {
 		if caught := recover(); caught != nil {
 			switch caught := caught.(type) {
 			case _error:
-				if caught.Line == -1 {
-					caught.Line = node.position()
+				if !caught.position.isValid() {
+					caught.position = node.position()
+				}
+				if caught.stack == nil {
+					caught.stack = self.captureStack(caught.position)
 				}
 				panic(caught) // Panic the modified _error
+			case *_exception:
+				if !caught.position.isValid() {
+					caught.position = node.position()
+					caught.stack = self.captureStack(caught.position)
+				}
 			}
 			panic(caught)
 		}
 	}()
 
+	// Count the step, and allow interpreter interruption by a step
+	// limit (see SetStepLimit) or a context (see RunContext)
+	self.step()
+
 	// Allow interpreter interruption
 	// If the Interrupt channel is nil, then
 	// we avoid runtime.Gosched() overhead (if any)
@@ -59,7 +71,30 @@ func (self *_runtime) evaluate(node _node) Value {
 	case *_variableDeclarationNode:
 		return self.evaluateVariableDeclaration(node)
 
+	case *_lexicalDeclarationNode:
+		return self.evaluateLexicalDeclaration(node)
+
 	case *_programNode:
+		if node.strict {
+			executionContext := self._executionContext(0)
+			strict := executionContext.strict
+			executionContext.strict = true
+			defer func() {
+				executionContext.strict = strict
+			}()
+		}
+		if len(node.LexicalList) > 0 {
+			executionContext := self._executionContext(0)
+			if executionContext.LexicalEnvironment == _environment(self.GlobalLexicalEnvironment) && !executionContext.eval {
+				// At the top-level, like a REPL, a later program can declare the same name again
+				for _, declaration := range node.LexicalList {
+					self.GlobalLexicalEnvironment.CreateLexicalBinding(declaration.Name, !declaration.Const)
+				}
+			} else {
+				// e.g. eval, where the bindings do not outlive the program
+				defer self.leaveLexicalEnvironment(self.enterLexicalEnvironment(node.LexicalList, nil))
+			}
+		}
 		self.declare("function", node.FunctionList)
 		self.declare("variable", node.VariableList)
 		return self.evaluateBody(node.Body)
@@ -117,6 +152,9 @@ func (self *_runtime) evaluate(node _node) Value {
 	case *_forInNode:
 		return self.evaluateForIn(node)
 
+	case *_forOfNode:
+		return self.evaluateForOf(node)
+
 	case *_breakNode:
 		return toValue(newBreakResult(node.Target))
 
@@ -144,6 +182,12 @@ func (self *_runtime) evaluate(node _node) Value {
 	case *_arrayNode:
 		return self.evaluateArray(node)
 
+	case *_templateNode:
+		return self.evaluateTemplate(node)
+
+	case *_templateObjectNode:
+		return self.evaluateTemplateObject(node)
+
 	case *_newNode:
 		return self.evaluateNew(node)
 
@@ -151,7 +195,16 @@ func (self *_runtime) evaluate(node _node) Value {
 		return self.evaluateConditional(node)
 
 	case *_thisNode:
-		return toValue_object(self._executionContext(0).this)
+		return self.evaluateThis()
+
+	case *_classNode:
+		return self.evaluateClass(node)
+
+	case *_superCallNode:
+		return self.evaluateSuperCall(node)
+
+	case *_superNode:
+		panic(newSyntaxError("'super' keyword unexpected here"))
 
 	case *_commaNode:
 		return self.evaluateComma(node)
@@ -159,6 +212,12 @@ func (self *_runtime) evaluate(node _node) Value {
 	case *_withNode:
 		return self.evaluateWith(node)
 
+	case *_yieldNode:
+		return self.evaluateYield(node)
+
+	case *_awaitNode:
+		return self.evaluateAwait(node)
+
 	}
 
 	panic(fmt.Sprintf("evaluate: Here be dragons: %T %v", node, node))
@@ -3,6 +3,7 @@ package otto
 import (
 	"math"
 	"strings"
+	"weak"
 )
 
 func (self *_runtime) evaluateConditional(node *_conditionalNode) Value {
@@ -17,14 +18,12 @@ func (self *_runtime) evaluateConditional(node *_conditionalNode) Value {
 func (self *_runtime) evaluateNew(node *_newNode) Value {
 	callee := self.evaluate(node.Callee)
 	calleeValue := self.GetValue(callee)
-	argumentList := []Value{}
-	for _, argumentNode := range node.ArgumentList {
-		argumentList = append(argumentList, self.GetValue(self.evaluate(argumentNode)))
-	}
+	argumentList := self.evaluateArgumentList(node.ArgumentList)
 	this := UndefinedValue()
 	if !calleeValue.IsFunction() {
 		panic(newTypeError("%v is not a function", calleeValue))
 	}
+	self._executionContext(0).position = node.position()
 	return calleeValue._object().Construct(this, argumentList)
 }
 
@@ -33,6 +32,11 @@ func (self *_runtime) evaluateArray(node *_arrayNode) Value {
 	valueArray := []Value{}
 
 	for _, node := range node.nodeList {
+		if spread, valid := node.(*_spreadNode); valid {
+			// [ 1, ...abc ]
+			valueArray = append(valueArray, self.iterableToList(self.GetValue(self.evaluate(spread.Argument)))...)
+			continue
+		}
 		valueArray = append(valueArray, self.GetValue(self.evaluate(node)))
 	}
 
@@ -46,16 +50,191 @@ func (self *_runtime) evaluateObject(node *_objectNode) Value {
 	result := self.newObject()
 
 	for _, property := range node.propertyList {
-		result.defineProperty(property.Key, self.GetValue(self.evaluate(property.Value)), 0111, false)
+		key := self.evaluatePropertyKey(property.Key, property.Computed)
+		if property.Kind == "value" {
+			result.defineProperty(key, self.GetValue(self.evaluate(property.Value)), 0111, false)
+			continue
+		}
+		function := self.newMethod(property.Value.(*_functionNode), self.LexicalEnvironment(), result)
+		switch property.Kind {
+		case "get":
+			// The setter (if any) is kept, see objectDefineOwnProperty
+			result.defineOwnProperty(key, _property{_propertyGetSet{function, nil}, 0211}, false)
+		case "set":
+			result.defineOwnProperty(key, _property{_propertyGetSet{nil, function}, 0211}, false)
+		default:
+			result.defineProperty(key, toValue_object(function), 0111, false)
+		}
 	}
 
 	return toValue_object(result)
 }
 
+// evaluatePropertyKey returns the name of a property (or method), which is key,
+// unless the key is computed
+func (self *_runtime) evaluatePropertyKey(key string, computed _node) string {
+	if computed == nil {
+		return key
+	}
+	return toPropertyKey(self.GetValue(self.evaluate(computed)))
+}
+
 func (self *_runtime) evaluateRegExp(node *_regExpNode) Value {
 	return toValue_object(self._newRegExp(node.Pattern, node.Flags))
 }
 
+func (self *_runtime) evaluateThis() Value {
+	this := self._executionContext(0).this
+	if this._valueType == valueEmpty {
+		// In the constructor of a class that extends another, before super()
+		panic(newReferenceError("Must call super constructor in derived class before accessing 'this' or returning from derived constructor"))
+	}
+	return this
+}
+
+func (self *_runtime) evaluateClass(node *_classNode) Value {
+	if node.Name != "" {
+		// The class can refer to itself (by name) from within
+		previous := self.enterLexicalEnvironment([]_lexicalDeclaration{{node.Name, true}}, nil)
+		defer self.leaveLexicalEnvironment(previous)
+	}
+
+	constructorParent, prototypeParent := self.Global.FunctionPrototype, self.Global.ObjectPrototype
+	if node.SuperClass != nil {
+		superClass := self.GetValue(self.evaluate(node.SuperClass))
+		switch {
+		case superClass.IsNull():
+			prototypeParent = nil
+		case superClass.IsFunction() && superClass._object().functionValue().construct != nil:
+			constructorParent = superClass._object()
+			switch prototype := constructorParent.get("prototype"); {
+			case prototype.IsNull():
+				prototypeParent = nil
+			case prototype.IsObject():
+				prototypeParent = prototype._object()
+			default:
+				panic(newTypeError("Class extends value does not have valid prototype property %v", prototype))
+			}
+		default:
+			panic(newTypeError("Class extends value %v is not a constructor or null", superClass))
+		}
+	}
+
+	prototype := self.newObject()
+	prototype.prototype = prototypeParent
+	environment := self.LexicalEnvironment()
+	constructor := self.newClass(node.Constructor, environment, prototype, constructorParent)
+
+	for _, method := range node.MethodList {
+		home := prototype
+		if method.Static {
+			home = constructor
+		}
+		key := self.evaluatePropertyKey(method.Key, method.Computed)
+		if method.Static && key == "prototype" {
+			panic(newTypeError("Classes may not have a static property named 'prototype'"))
+		}
+		function := self.newMethod(method.Function, environment, home)
+		switch method.Kind {
+		case "get":
+			// The setter (if any) is kept, see objectDefineOwnProperty
+			home.defineOwnProperty(key, _property{_propertyGetSet{function, nil}, 0201}, false)
+		case "set":
+			home.defineOwnProperty(key, _property{_propertyGetSet{nil, function}, 0201}, false)
+		default:
+			home.defineProperty(key, toValue_object(function), 0101, false)
+		}
+	}
+
+	if node.Name != "" {
+		environment.(*_declarativeEnvironment).InitializeBinding(node.Name, toValue_object(constructor))
+	}
+	return toValue_object(constructor)
+}
+
+func (self *_runtime) evaluateSuperCall(node *_superCallNode) Value {
+	executionContext := self._executionContext(0)
+	if executionContext.newTarget == nil {
+		// e.g. In an arrow function (which does not have the new.target of where it is defined)
+		panic(newSyntaxError("'super' keyword unexpected here"))
+	}
+	argumentList := self.evaluateArgumentList(node.ArgumentList)
+	executionContext.position = node.position()
+	this := self.construct(executionContext.function.prototype, executionContext.newTarget, argumentList)
+	if executionContext.this._valueType != valueEmpty {
+		panic(newReferenceError("Super constructor may only be called once"))
+	}
+	executionContext.this = this
+	return this
+}
+
+// evaluateSuperMember returns a reference to the property (name) of the prototype
+// of the object that the current method belongs to, super.abc or super[abc]
+func (self *_runtime) evaluateSuperMember(name string, node _node) Value {
+	executionContext := self._executionContext(0)
+	var home *_object
+	if executionContext.function != nil {
+		home = executionContext.function.homeObject()
+	}
+	if home == nil {
+		panic(newSyntaxError("'super' keyword unexpected here"))
+	}
+	return toValue(newSuperReference(home.prototype, name, self.evaluateThis(), node))
+}
+
+func (self *_runtime) evaluateTemplate(node *_templateNode) Value {
+	list := []string{node.Cooked[0]}
+	length := len(node.Cooked[0])
+	for index, expression := range node.Expressions {
+		value := toString(self.GetValue(self.evaluate(expression)))
+		list = append(list, value, node.Cooked[index+1])
+		length += len(value) + len(node.Cooked[index+1])
+	}
+	self.allocate(length)
+	return toValue_string(strings.Join(list, ""))
+}
+
+// evaluateTemplateObject returns the (frozen) strings array of a tagged template,
+// with the (frozen) raw strings as its raw property, which is the same array each
+// time the template is evaluated
+//
+// The arrays are cached by a weak pointer to the node, since the node outlives
+// the runtime if it is of a shared Script, and the array is only needed for as long
+// as the node can be evaluated.
+func (self *_runtime) evaluateTemplateObject(node *_templateObjectNode) Value {
+	key := weak.Make(node)
+	if result, exists := self.templateObjects[key]; exists {
+		return toValue_object(result)
+	}
+	toArray := func(list []string) *_object {
+		valueArray := []Value{}
+		for _, value := range list {
+			valueArray = append(valueArray, toValue_string(value))
+		}
+		return self.newArrayOf(valueArray)
+	}
+	raw := toArray(node.Raw)
+	raw.freeze()
+	result := toArray(node.Cooked)
+	result.defineProperty("raw", toValue_object(raw), 0, false)
+	result.freeze()
+	if self.templateObjects == nil {
+		self.templateObjects = map[weak.Pointer[_templateObjectNode]]*_object{}
+	}
+	if len(self.templateObjects) >= self.templateObjectsSweep {
+		// Forget the arrays of the nodes that have been collected (e.g. those of a
+		// source that was run once), so that the cache does not keep on growing
+		for key := range self.templateObjects {
+			if key.Value() == nil {
+				delete(self.templateObjects, key)
+			}
+		}
+		self.templateObjectsSweep = 2*len(self.templateObjects) + 64
+	}
+	self.templateObjects[key] = result
+	return toValue_object(result)
+}
+
 func (self *_runtime) evaluateUnaryOperation(node *_unaryOperationNode) Value {
 
 	target := self.evaluate(node.Target)
@@ -69,6 +248,15 @@ func (self *_runtime) evaluateUnaryOperation(node *_unaryOperationNode) Value {
 		}
 	}
 
+	if node.Operator == "delete" {
+		// The value is not got (which a getter, or the get trap of a proxy, would see)
+		reference := target.reference()
+		if reference == nil {
+			return TrueValue()
+		}
+		return toValue_bool(reference.Delete())
+	}
+
 	targetValue := self.GetValue(target)
 
 	switch node.Operator {
@@ -110,12 +298,6 @@ func (self *_runtime) evaluateUnaryOperation(node *_unaryOperationNode) Value {
 		return toValue_float64(oldValue)
 	case "void":
 		return UndefinedValue()
-	case "delete":
-		reference := target.reference()
-		if reference == nil {
-			return TrueValue()
-		}
-		return toValue_bool(target.reference().Delete())
 	case "typeof":
 		switch targetValue._valueType {
 		case valueUndefined:
@@ -128,6 +310,8 @@ func (self *_runtime) evaluateUnaryOperation(node *_unaryOperationNode) Value {
 			return toValue_string("number")
 		case valueString:
 			return toValue_string("string")
+		case valueSymbol:
+			return toValue_string("symbol")
 		case valueObject:
 			if targetValue._object().functionValue().call != nil {
 				return toValue_string("function")
@@ -198,7 +382,9 @@ func (self *_runtime) calculateBinaryOperation(operator string, left Value, righ
 		rightValue = toPrimitive(rightValue)
 
 		if leftValue.IsString() || rightValue.IsString() {
-			return toValue_string(strings.Join([]string{leftValue.toString(), rightValue.toString()}, ""))
+			left, right := leftValue.toString(), rightValue.toString()
+			self.allocate(len(left) + len(right))
+			return toValue_string(strings.Join([]string{left, right}, ""))
 		} else {
 			return toValue_float64(leftValue.toFloat() + rightValue.toFloat())
 		}
@@ -260,6 +446,12 @@ func (self *_runtime) calculateBinaryOperation(operator string, left Value, righ
 		if !rightValue.IsObject() {
 			panic(newTypeError("Expecting a function in instanceof check, but got: %v", rightValue))
 		}
+		if hasInstance := rightValue._object().get(symbolKeyHasInstance); hasInstance.IsDefined() && !hasInstance.IsNull() {
+			if !hasInstance.isCallable() {
+				panic(newTypeError("%v is not a function", hasInstance))
+			}
+			return toValue_bool(self.Call(hasInstance._object(), rightValue, []Value{leftValue}, false).toBoolean())
+		}
 		return toValue_bool(rightValue._object().HasInstance(leftValue))
 
 	case "in":
@@ -267,7 +459,7 @@ func (self *_runtime) calculateBinaryOperation(operator string, left Value, righ
 		if !rightValue.IsObject() {
 			panic(newTypeError())
 		}
-		return toValue_bool(rightValue._object().hasProperty(toString(leftValue)))
+		return toValue_bool(rightValue._object().hasProperty(toPropertyKey(leftValue)))
 	}
 
 	panic(hereBeDragons(operator))
@@ -275,6 +467,14 @@ func (self *_runtime) calculateBinaryOperation(operator string, left Value, righ
 
 func (self *_runtime) evaluateAssignment(node *_assignmentNode) Value {
 
+	switch node.Left.(type) {
+	case *_arrayPatternNode, *_objectPatternNode:
+		// [ abc, def ] = ghi
+		result := self.GetValue(self.evaluate(node.Right))
+		self.destructure(node.Left, result, nil)
+		return result
+	}
+
 	left := self.evaluate(node.Left)
 	right := self.evaluate(node.Right)
 	rightValue := self.GetValue(right)
@@ -289,6 +489,103 @@ func (self *_runtime) evaluateAssignment(node *_assignmentNode) Value {
 	return result
 }
 
+// destructure assigns the parts of value to the targets of pattern (an array or
+// object pattern, or a target with a default), recursively. If bind is not nil,
+// each name is bound with it (as for a let or const declaration, or a parameter),
+// otherwise each target is assigned to as a reference (as for a var declaration,
+// or an assignment)
+func (self *_runtime) destructure(pattern _node, value Value, bind func(name string, value Value)) {
+	switch pattern := pattern.(type) {
+	case *_assignmentPatternNode:
+		if value.IsUndefined() {
+			value = self.GetValue(self.evaluate(pattern.Initializer))
+		}
+		self.destructure(pattern.Target, value, bind)
+
+	case *_arrayPatternNode:
+		self.checkDestructure(value)
+		self.destructureIterator(pattern, self.getIterator(value), bind)
+
+	case *_objectPatternNode:
+		self.checkDestructure(value)
+		object := self.toObject(value)
+		taken := map[string]bool{}
+		for _, property := range pattern.PropertyList {
+			key := self.evaluatePropertyKey(property.Key, property.Computed)
+			taken[key] = true
+			self.destructure(property.Target, object.get(key), bind)
+		}
+		if pattern.Rest != nil {
+			// The (own, enumerable) properties that were not taken by the pattern
+			rest := self.newObject()
+			object.enumerate(false, func(name string) bool {
+				if !taken[name] {
+					rest.put(name, object.get(name), true)
+				}
+				return true
+			})
+			self.destructure(pattern.Rest, toValue_object(rest), bind)
+		}
+
+	case *_identifierNode:
+		if bind != nil {
+			bind(pattern.Value, value)
+			return
+		}
+		self.PutValue(self.evaluate(pattern).reference(), value)
+
+	default:
+		// abc.def or abc[def]
+		self.PutValue(self.evaluate(pattern).reference(), value)
+	}
+}
+
+// destructureIterator assigns the values of iterator to the targets of pattern,
+// stepping the iterator only as far as the pattern needs
+func (self *_runtime) destructureIterator(pattern *_arrayPatternNode, iterator *_iterator, bind func(name string, value Value)) {
+	done := false
+	defer iterator.closeOnPanic(&done)
+	next := func() Value {
+		if done {
+			return UndefinedValue()
+		}
+		done = true // The iterator is not closed if stepping throws
+		value, finished := iterator.step()
+		done = finished
+		return value
+	}
+	for _, target := range pattern.ElementList {
+		element := next()
+		if target == nil {
+			continue // A hole, e.g. [ , abc ]
+		}
+		self.destructure(target, element, bind)
+	}
+	if pattern.Rest != nil {
+		rest := []Value{}
+		for {
+			element := next()
+			if done {
+				break
+			}
+			rest = append(rest, element)
+		}
+		self.destructure(pattern.Rest, toValue_object(self.newArrayOf(rest)), bind)
+	}
+	if !done {
+		done = true
+		iterator.close()
+	}
+}
+
+// checkDestructure checks that value can be destructured (is not undefined or null)
+func (self *_runtime) checkDestructure(value Value) {
+	switch value._valueType {
+	case valueUndefined, valueNull:
+		panic(newTypeError("Cannot destructure '%s' as it is %s.", value, value))
+	}
+}
+
 func valueKindDispatchKey(left _valueType, right _valueType) int {
 	return (int(left) << 2) + int(right)
 }
@@ -425,6 +722,9 @@ func (self *_runtime) calculateComparison(comparator string, left Value, right V
 			result = self.calculateComparison("==", toPrimitive(x), y)
 		} else if y._valueType == valueObject {
 			result = self.calculateComparison("==", x, toPrimitive(y))
+		} else if x._valueType == valueSymbol || y._valueType == valueSymbol {
+			// A symbol is only equal to itself
+			result = false
 		} else {
 			panic(hereBeDragons("Unable to test for equality: %v ==? %v", x, y))
 		}
@@ -448,6 +748,8 @@ func (self *_runtime) calculateComparison(comparator string, left Value, right V
 			result = x.toBoolean() == y.toBoolean()
 		case valueObject:
 			result = x._object() == y._object()
+		case valueSymbol:
+			result = x.value.(_symbol).id == y.value.(_symbol).id
 		default:
 			goto ERROR
 		}
@@ -495,6 +797,20 @@ func (self *_runtime) evaluateBinaryOperation(node *_binaryOperationNode) Value
 	return self.calculateBinaryOperation(node.Operator, leftValue, self.evaluate(node.Right))
 }
 
+// evaluateArgumentList evaluates the arguments of a call (or new), where the
+// elements of each spread argument, e.g. ...abc, are spread out
+func (self *_runtime) evaluateArgumentList(list []_node) []Value {
+	argumentList := []Value{}
+	for _, node := range list {
+		if spread, valid := node.(*_spreadNode); valid {
+			argumentList = append(argumentList, self.iterableToList(self.GetValue(self.evaluate(spread.Argument)))...)
+			continue
+		}
+		argumentList = append(argumentList, self.GetValue(self.evaluate(node)))
+	}
+	return argumentList
+}
+
 func (self *_runtime) evaluateCall(node *_callNode, withArgumentList []interface{}) Value {
 	callee := self.evaluate(node.Callee)
 	calleeValue := self.GetValue(callee)
@@ -502,57 +818,106 @@ func (self *_runtime) evaluateCall(node *_callNode, withArgumentList []interface
 	if withArgumentList != nil {
 		argumentList = self.toValueArray(withArgumentList...)
 	} else {
-		for _, argumentNode := range node.ArgumentList {
-			argumentList = append(argumentList, self.GetValue(self.evaluate(argumentNode)))
-		}
+		argumentList = self.evaluateArgumentList(node.ArgumentList)
 	}
 	this := UndefinedValue()
+	implicitThis := true
 	calleeReference := callee.reference()
 	evalHint := false
 	if calleeReference != nil {
-		if calleeReference.IsPropertyReference() {
+		if reference, super := calleeReference.(*_superReference); super {
+			this = reference.this // super.abc() is called with the this of the caller
+			implicitThis = false
+		} else if reference, valid := calleeReference.(*_propertyReference); valid && reference.environment != nil {
+			// ImplicitThisValue, which is undefined (for the global object, say) unless
+			// the environment is that of a with statement
+			if object := reference.environment.ImplicitThisValue(); object != nil {
+				this = toValue_object(object)
+				implicitThis = false
+			}
+		} else if calleeReference.IsPropertyReference() {
 			calleeObject := calleeReference.GetBase().(*_object)
 			this = toValue_object(calleeObject)
-		} else {
-			// TODO ImplictThisValue
+			implicitThis = false
 		}
 		if calleeReference.GetName() == "eval" {
 			evalHint = true // Possible direct eval
 		}
 	}
 	if !calleeValue.IsFunction() {
+		if reference, super := calleeReference.(*_superReference); super {
+			panic(newTypeError("super.%v is not a function", propertyKeyValue(reference.GetName())))
+		}
 		panic(newTypeError("%v is not a function", calleeValue))
 	}
+	if _, native := calleeValue._object().functionValue().call.(_nativeCallFunction); native && implicitThis {
+		// A Go function is not strict mode code, so it gets the global object
+		this = toValue_object(self.GlobalObject)
+	}
+	self._executionContext(0).position = node.position()
 	return self.Call(calleeValue._object(), this, argumentList, evalHint)
 }
 
 func (self *_runtime) evaluateFunction(node *_functionNode) Value {
+	if node.arrow {
+		executionContext := self._executionContext(0)
+		var home *_object
+		if executionContext.function != nil {
+			home = executionContext.function.homeObject()
+		}
+		return toValue_object(self.newArrowFunction(node, self.LexicalEnvironment(), executionContext.this, home))
+	}
 	return toValue_object(self.newNodeFunction(node, self.LexicalEnvironment()))
 }
 
+func (self *_runtime) evaluateYield(node *_yieldNode) Value {
+	value := UndefinedValue()
+	if node.Argument != nil {
+		value = self.GetValue(self.evaluate(node.Argument))
+	}
+	channel := self._executionContext(0).generator
+	if node.Delegate {
+		return self.yieldDelegate(channel, value)
+	}
+	return channel.suspend(_generatorCompletion{value: value}).receive()
+}
+
+// evaluateAwait suspends the body of an async function (like a yield), until the promise
+// of the value awaited is settled (see stepAsync)
+func (self *_runtime) evaluateAwait(node *_awaitNode) Value {
+	value := self.GetValue(self.evaluate(node.Argument))
+	channel := self._executionContext(0).generator
+	return channel.suspend(_generatorCompletion{value: value}).receive()
+}
+
 func (self *_runtime) evaluateDotMember(node *_dotMemberNode) Value {
+	if _, super := node.Target.(*_superNode); super {
+		return self.evaluateSuperMember(node.Member, node)
+	}
 	target := self.evaluate(node.Target)
 	targetValue := self.GetValue(target)
 	// TODO Pass in base value as-is, and defer toObject till later?
-	return toValue(newPropertyReference(self.toObject(targetValue), node.Member, false, node))
+	return toValue(newPropertyReference(self.toObject(targetValue), node.Member, self._executionContext(0).strict, node))
 }
 
 func (self *_runtime) evaluateBracketMember(node *_bracketMemberNode) Value {
+	if _, super := node.Target.(*_superNode); super {
+		return self.evaluateSuperMember(toPropertyKey(self.GetValue(self.evaluate(node.Member))), node)
+	}
 	target := self.evaluate(node.Target)
 	targetValue := self.GetValue(target)
 	member := self.evaluate(node.Member)
 	memberValue := self.GetValue(member)
 
 	// TODO Pass in base value as-is, and defer toObject till later?
-	return toValue(newPropertyReference(self.toObject(targetValue), toString(memberValue), false, node))
+	return toValue(newPropertyReference(self.toObject(targetValue), toPropertyKey(memberValue), self._executionContext(0).strict, node))
 }
 
 func (self *_runtime) evaluateIdentifier(node *_identifierNode) Value {
 	name := node.Value
-	// TODO Should be true or false (strictness) depending on context
 	// getIdentifierReference should not return nil, but we check anyway and panic
 	// so as not to propagate the nil into something else
-	reference := getIdentifierReference(self.LexicalEnvironment(), name, false, node)
+	reference := getIdentifierReference(self.LexicalEnvironment(), name, self._executionContext(0).strict, node)
 	if reference == nil {
 		// Should never get here!
 		panic(hereBeDragons("referenceError == nil: " + name))
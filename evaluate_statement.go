@@ -1,8 +1,14 @@
 package otto
 
 func (self *_runtime) evaluateTryCatch(node *_tryCatchNode) Value {
+	evaluate := self.evaluate
+	if node.Finally != nil {
+		// The return method of a generator runs the finally block (see evaluateReturnable)
+		evaluate = self.evaluateReturnable
+	}
+
 	tryCatchValue, exception := self.tryCatchEvaluate(func() Value {
-		return self.evaluate(node.Try)
+		return evaluate(node.Try)
 	})
 
 	if exception && node.Catch != nil {
@@ -13,10 +19,14 @@ func (self *_runtime) evaluateTryCatch(node *_tryCatchNode) Value {
 		}()
 		// TODO If necessary, convert TypeError<runtime> => TypeError
 		// That, is, such errors can be thrown despite not being JavaScript "native"
-		self.localSet(node.Catch.Identifier, tryCatchValue)
+		if node.Catch.Pattern != nil {
+			self.destructure(node.Catch.Pattern, tryCatchValue, self.localSet)
+		} else {
+			self.localSet(node.Catch.Identifier, tryCatchValue)
+		}
 
 		tryCatchValue, exception = self.tryCatchEvaluate(func() Value {
-			return self.evaluate(node.Catch.Body)
+			return evaluate(node.Catch.Body)
 		})
 	}
 
@@ -42,6 +52,11 @@ func (self *_runtime) evaluateVariableDeclarationList(node *_variableDeclaration
 }
 
 func (self *_runtime) evaluateVariableDeclaration(node *_variableDeclarationNode) Value {
+	if node.Pattern != nil {
+		// var [ abc, def ] = ghi
+		self.destructure(node.Pattern, self.GetValue(self.evaluate(node.Initializer)), nil)
+		return emptyValue()
+	}
 	if node.Operator != "" {
 		// FIXME If reference is nil
 		left := getIdentifierReference(self.LexicalEnvironment(), node.Identifier, false, node)
@@ -53,6 +68,23 @@ func (self *_runtime) evaluateVariableDeclaration(node *_variableDeclarationNode
 	return toValue_string(node.Identifier)
 }
 
+func (self *_runtime) evaluateLexicalDeclaration(node *_lexicalDeclarationNode) Value {
+	// The bindings were created on entry to the block, so are in the current (lexical) environment
+	environment := self.LexicalEnvironment().(*_declarativeEnvironment)
+	for _, node := range node.VariableList {
+		value := UndefinedValue()
+		if node.Initializer != nil {
+			value = self.GetValue(self.evaluate(node.Initializer))
+		}
+		if node.Pattern != nil {
+			self.destructure(node.Pattern, value, environment.InitializeBinding)
+			continue
+		}
+		environment.InitializeBinding(node.Identifier, value)
+	}
+	return emptyValue()
+}
+
 func (self *_runtime) evaluateThrow(node *_throwNode) Value {
 	value := self.GetValue(self.evaluate(node.Argument))
 	panic(newException(value))
@@ -96,6 +128,10 @@ func (self *_runtime) evaluateBlock(node *_blockNode) Value {
 	body := node.Body
 	labelSet := node.labelSet
 
+	if len(node.LexicalList) > 0 {
+		defer self.leaveLexicalEnvironment(self.enterLexicalEnvironment(node.LexicalList, node.FunctionList))
+	}
+
 	blockValue := self.evaluateBody(body)
 	if blockValue.evaluateBreak(labelSet) == resultBreak {
 		return Value{}
@@ -176,6 +212,10 @@ func (self *_runtime) evaluateFor(node *_forNode) Value {
 	update := node.Update
 	labelSet := node.labelSet
 
+	if len(node.LexicalList) > 0 {
+		defer self.leaveLexicalEnvironment(self.enterLexicalEnvironment(node.LexicalList, nil))
+	}
+
 	if initial != nil {
 		initialResult := self.evaluate(initial)
 		self.GetValue(initialResult) // Side-effect trigger
@@ -184,6 +224,13 @@ func (self *_runtime) evaluateFor(node *_forNode) Value {
 	forValue := Value{}
 resultBreak:
 	for {
+		if len(node.LexicalList) > 0 {
+			// Each iteration has its own copy of the bindings, so that a
+			// closure (in the body) sees the bindings of its own iteration
+			self.copyLexicalEnvironment()
+		}
+		// for (;;) {} would otherwise never evaluate a node, see SetStepLimit and RunContext
+		self.step()
 		if test != nil {
 			testResult := self.evaluate(test)
 			testResultValue := self.GetValue(testResult)
@@ -207,6 +254,9 @@ resultBreak:
 			}
 		}
 	resultContinue:
+		if len(node.LexicalList) > 0 {
+			self.copyLexicalEnvironment()
+		}
 		if update != nil {
 			updateResult := self.evaluate(update)
 			self.GetValue(updateResult) // Side-effect trigger
@@ -227,23 +277,23 @@ func (self *_runtime) evaluateForIn(node *_forInNode) Value {
 
 	sourceObject := self.toObject(sourceValue)
 
-	into := node.Into
 	body := node.body
 	labelSet := node.labelSet
 
+	previous := self.LexicalEnvironment()
+	if len(node.LexicalList) > 0 {
+		defer self.leaveLexicalEnvironment(previous)
+	}
+
 	forInValue := Value{}
 	object := sourceObject
 	for object != nil {
 		enumerateValue := Value{}
 		object.enumerate(false, func(name string) bool {
-			into := self.evaluate(into)
-			// In the case of: for (var abc in def) ...
-			if into.reference() == nil {
-				identifier := toString(into)
-				// TODO Should be true or false (strictness) depending on context
-				into = toValue(getIdentifierReference(self.LexicalEnvironment(), identifier, false, node))
+			if isSymbolKey(name) {
+				return true
 			}
-			self.PutValue(into.reference(), toValue_string(name))
+			self.assignIterationValue(node.Into, node.LexicalList, previous, toValue_string(name), node)
 			for _, node := range body {
 				value := self.evaluate(node)
 				switch value.evaluateBreakContinue(labelSet) {
@@ -266,7 +316,7 @@ func (self *_runtime) evaluateForIn(node *_forInNode) Value {
 		if object == nil {
 			break
 		}
-		object = object.prototype
+		object = object.getPrototypeOf()
 		if !enumerateValue.isEmpty() {
 			forInValue = enumerateValue
 		}
@@ -274,11 +324,105 @@ func (self *_runtime) evaluateForIn(node *_forInNode) Value {
 	return forInValue
 }
 
+func (self *_runtime) evaluateForOf(node *_forOfNode) Value {
+
+	source := self.evaluate(node.Source)
+	sourceValue := self.GetValue(source)
+
+	iterator := self.getIterator(sourceValue)
+
+	body := node.body
+	labelSet := node.labelSet
+
+	previous := self.LexicalEnvironment()
+	if len(node.LexicalList) > 0 {
+		defer self.leaveLexicalEnvironment(previous)
+	}
+
+	// The iterator is closed if the loop is exited before it is done, by a break,
+	// return, or exception (other than one thrown by the iterator itself)
+	done := false
+	defer iterator.closeOnPanic(&done)
+
+	forOfValue := Value{}
+resultBreakContinue:
+	for {
+		done = true
+		value, finished := iterator.step()
+		if finished {
+			break
+		}
+		done = false
+		self.assignIterationValue(node.Into, node.LexicalList, previous, value, node)
+		for _, node := range body {
+			value := self.evaluate(node)
+			switch value.evaluateBreakContinue(labelSet) {
+			case resultReturn:
+				done = true
+				iterator.close()
+				return value
+			case resultBreak:
+				done = true
+				iterator.close()
+				break resultBreakContinue
+			case resultContinue:
+				continue resultBreakContinue
+			default: // resultNormal
+			}
+			if !value.isEmpty() {
+				forOfValue = value
+			}
+		}
+	}
+	return forOfValue
+}
+
+// assignIterationValue assigns value to into, the target of a for-in or for-of
+// loop, for an iteration. If there is a let or const declaration (lexicalList),
+// then each iteration has a new binding (in a new environment, from previous)
+func (self *_runtime) assignIterationValue(into _node, lexicalList []_lexicalDeclaration, previous _environment, value Value, node _node) {
+
+	// The pattern of for ([ abc, def ] in ...), or for (var [ abc, def ] in ...)
+	var pattern _node
+	switch into := into.(type) {
+	case *_variableDeclarationNode:
+		pattern = into.Pattern
+	case *_arrayPatternNode, *_objectPatternNode:
+		pattern = into
+	}
+
+	if len(lexicalList) > 0 {
+		// In the case of: for (let abc in def) ...
+		self.leaveLexicalEnvironment(previous)
+		self.enterLexicalEnvironment(lexicalList, nil)
+		environment := self.LexicalEnvironment().(*_declarativeEnvironment)
+		if pattern != nil {
+			self.destructure(pattern, value, environment.InitializeBinding)
+		} else {
+			environment.InitializeBinding(lexicalList[0].Name, value)
+		}
+	} else if pattern != nil {
+		self.destructure(pattern, value, nil)
+	} else {
+		into := self.evaluate(into)
+		// In the case of: for (var abc in def) ...
+		if into.reference() == nil {
+			identifier := toString(into)
+			into = toValue(getIdentifierReference(self.LexicalEnvironment(), identifier, self._executionContext(0).strict, node))
+		}
+		self.PutValue(into.reference(), value)
+	}
+}
+
 func (self *_runtime) evaluateSwitch(node *_switchNode) Value {
 
 	discriminantResult := self.evaluate(node.Discriminant)
 	target := node.Default
 
+	if len(node.LexicalList) > 0 {
+		defer self.leaveLexicalEnvironment(self.enterLexicalEnvironment(node.LexicalList, nil))
+	}
+
 	for index, clause := range node.CaseList {
 		test := clause.Test
 		if test != nil {
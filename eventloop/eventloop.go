@@ -0,0 +1,431 @@
+/*
+Package eventloop runs an otto runtime in an event loop, providing setTimeout, setInterval, and setImmediate.
+
+	loop := eventloop.New(otto.New())
+	err := loop.Run(`
+	    setTimeout(function(){
+	        console.log("Hello, World.");
+	    }, 100);
+	`)
+
+Run returns once the loop is idle: when there are no more timers, no more scheduled functions,
+and no outstanding reservations (see Reserve). RunContext will also return when a context is done.
+
+The runtime is owned by the loop, so other goroutines should not use it directly while the loop is running.
+Instead, they can use Schedule (or Reserve) to run a function on the loop:
+
+	go func() {
+	    result := slowComputation()
+	    loop.Schedule(func(vm *otto.Otto) error {
+	        _, err := vm.Call("handleResult", nil, result)
+	        return err
+	    })
+	}()
+
+NewPromise returns a promise that a goroutine can settle, which is a convenient way to expose asynchronous Go
+(e.g. I/O) to JavaScript:
+
+	vm.Set("fetch", func(call otto.FunctionCall) otto.Value {
+	    promise, resolve, reject := loop.NewPromise()
+	    go func() {
+	        ...
+	    }()
+	    return promise
+	})
+
+For deterministic tests, UseFakeClock replaces the clock of the loop with a fake one, which jumps straight to the
+next timer whenever there is nothing else to do.
+*/
+package eventloop
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+// maxDelay is the largest delay (in milliseconds) of a timer, larger delays are set to 1
+const maxDelay = math.MaxInt32
+
+// Loop is an event loop that owns an otto runtime.
+type Loop struct {
+	vm *otto.Otto
+
+	fake bool
+	now  time.Time // The time of the fake clock
+
+	timerList _timerList // The pending timers, ordered by due time
+	timerMap  map[int64]*_timer
+	timerID   int64
+	sequence  uint64
+
+	lock     sync.Mutex
+	jobList  []func(*otto.Otto) error
+	reserved int
+	wake     chan struct{}
+}
+
+type _timer struct {
+	id           int64
+	due          time.Time
+	sequence     uint64 // Timers with the same due time run in the order they were set
+	interval     time.Duration
+	repeat       bool
+	function     otto.Value
+	argumentList []interface{}
+	index        int // The index of the timer in the timerList
+}
+
+// New returns a loop that owns vm, installing setTimeout, setInterval, setImmediate
+// (and clearTimeout, clearInterval, clearImmediate) in its global object.
+func New(vm *otto.Otto) *Loop {
+	self := &Loop{
+		vm:       vm,
+		timerMap: map[int64]*_timer{},
+		wake:     make(chan struct{}, 1),
+	}
+	vm.Set("setTimeout", func(call otto.FunctionCall) otto.Value {
+		return self.setTimer(call, false)
+	})
+	vm.Set("setInterval", func(call otto.FunctionCall) otto.Value {
+		return self.setTimer(call, true)
+	})
+	vm.Set("setImmediate", func(call otto.FunctionCall) otto.Value {
+		return self.setImmediate(call)
+	})
+	vm.Set("clearTimeout", self.clearTimer)
+	vm.Set("clearInterval", self.clearTimer)
+	vm.Set("clearImmediate", self.clearTimer)
+	return self
+}
+
+// Otto returns the runtime owned by the loop.
+func (self *Loop) Otto() *otto.Otto {
+	return self.vm
+}
+
+// UseFakeClock replaces the clock of the loop with a fake clock starting at start.
+//
+// The fake clock only moves when the loop has nothing else to do, in which case it
+// moves (instantly) to the due time of the next timer. It should be set before the
+// loop is run.
+func (self *Loop) UseFakeClock(start time.Time) {
+	self.fake = true
+	self.now = start
+}
+
+// Now returns the current time of the clock of the loop.
+func (self *Loop) Now() time.Time {
+	if self.fake {
+		return self.now
+	}
+	return time.Now()
+}
+
+// Schedule runs function on the loop as soon as possible.
+//
+// It is safe to call Schedule from any goroutine. If the loop is not running, then
+// function will be run the next time it is. If function returns an error, then the
+// loop stops, and Run returns the error.
+func (self *Loop) Schedule(function func(*otto.Otto) error) {
+	self.lock.Lock()
+	self.jobList = append(self.jobList, function)
+	self.lock.Unlock()
+	self.signal()
+}
+
+// Reserve keeps the loop from becoming idle until the returned function is called,
+// which is useful when a goroutine will schedule a function on the loop later on.
+//
+// Calling the returned function is like calling Schedule, and it must be called
+// exactly once (calling it again will panic).
+func (self *Loop) Reserve() func(func(*otto.Otto) error) {
+	self.lock.Lock()
+	self.reserved++
+	self.lock.Unlock()
+
+	used := false
+	return func(function func(*otto.Otto) error) {
+		self.lock.Lock()
+		if used {
+			self.lock.Unlock()
+			panic("eventloop: reservation used more than once")
+		}
+		used = true
+		self.reserved--
+		self.jobList = append(self.jobList, function)
+		self.lock.Unlock()
+		self.signal()
+	}
+}
+
+// NewPromise is like otto.NewPromise, except that the loop is kept from becoming
+// idle until the promise is settled.
+//
+// The resolve and reject functions are safe to call from any goroutine, but (like
+// the rest of the runtime) NewPromise itself should only be called on the loop, e.g.
+// by a Go function called from JavaScript.
+func (self *Loop) NewPromise() (promise otto.Value, resolve func(interface{}), reject func(interface{})) {
+	promise, resolvePromise, rejectPromise := self.vm.NewPromise()
+	done := self.Reserve()
+	once := sync.Once{}
+	settle := func(settlePromise func(interface{})) func(interface{}) {
+		return func(value interface{}) {
+			once.Do(func() {
+				done(func(*otto.Otto) error {
+					settlePromise(value)
+					return nil
+				})
+			})
+		}
+	}
+	return promise, settle(resolvePromise), settle(rejectPromise)
+}
+
+func (self *Loop) signal() {
+	select {
+	case self.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs src (if not nil) and then runs the loop until it is idle.
+//
+// If the JavaScript throws an (uncaught) exception, then the loop stops and the
+// exception is returned as an error.
+func (self *Loop) Run(src interface{}) error {
+	return self.RunContext(context.Background(), src)
+}
+
+// RunContext is like Run, except that the loop is stopped (and otto.ErrInterrupted or
+// context.DeadlineExceeded is returned) if ctx is done before the loop is idle.
+//
+// Any remaining timers and scheduled functions are kept, and will be run the next time
+// the loop is run.
+func (self *Loop) RunContext(ctx context.Context, src interface{}) error {
+	if src != nil {
+		_, err := self.vm.RunContext(ctx, src)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return interrupted(err)
+		}
+
+		if err := self.runJobs(); err != nil {
+			return err
+		}
+
+		if err := self.runTimers(ctx); err != nil {
+			return err
+		}
+
+		self.lock.Lock()
+		pending, reserved := len(self.jobList), self.reserved
+		self.lock.Unlock()
+
+		if pending > 0 {
+			continue
+		}
+
+		if len(self.timerList) == 0 && reserved == 0 {
+			return nil // Idle
+		}
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if len(self.timerList) > 0 {
+			due := self.timerList[0].due
+			if self.fake {
+				if reserved == 0 {
+					// Nothing else can happen, so move the clock to the next timer
+					self.now = due
+					continue
+				}
+			} else {
+				timer = time.NewTimer(due.Sub(time.Now()))
+				timeout = timer.C
+			}
+		}
+
+		err := error(nil)
+		select {
+		case <-self.wake:
+		case <-timeout:
+		case <-ctx.Done():
+			err = interrupted(ctx.Err())
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func interrupted(err error) error {
+	if err != context.DeadlineExceeded {
+		return otto.ErrInterrupted
+	}
+	return err
+}
+
+func (self *Loop) runJobs() error {
+	self.lock.Lock()
+	jobList := self.jobList
+	self.jobList = nil
+	self.lock.Unlock()
+
+	for index, job := range jobList {
+		err := job(self.vm)
+		if err == nil {
+			err = self.vm.RunJobs() // e.g. The reactions of a promise settled by job
+		}
+		if err != nil {
+			// Keep the remaining jobs for the next run
+			self.lock.Lock()
+			self.jobList = append(jobList[index+1:len(jobList):len(jobList)], self.jobList...)
+			self.lock.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+// runTimers runs every timer that is due, but not the timers that are set while doing so
+func (self *Loop) runTimers(ctx context.Context) error {
+	now := self.Now()
+	sequence := self.sequence
+	for len(self.timerList) > 0 {
+		timer := self.timerList[0]
+		if timer.due.After(now) || timer.sequence >= sequence {
+			break
+		}
+		heap.Pop(&self.timerList)
+		if !timer.repeat {
+			delete(self.timerMap, timer.id)
+		}
+
+		_, err := self.vm.CallContext(ctx, timer.function, nil, timer.argumentList...)
+		if err != nil {
+			delete(self.timerMap, timer.id)
+			return err
+		}
+
+		if _, exists := self.timerMap[timer.id]; exists {
+			// An interval that was not cleared by its function
+			self.schedule(timer, timer.interval)
+		}
+	}
+	return nil
+}
+
+func (self *Loop) schedule(timer *_timer, delay time.Duration) {
+	timer.due = self.Now().Add(delay)
+	timer.sequence = self.sequence
+	self.sequence++
+	heap.Push(&self.timerList, timer)
+}
+
+func (self *Loop) newTimer(call otto.FunctionCall, repeat bool, argumentList []otto.Value) *_timer {
+	function := call.Argument(0)
+	if !function.IsFunction() {
+		value, _ := call.Otto.Call("new TypeError", nil, "Callback must be a function")
+		panic(value)
+	}
+
+	self.timerID++
+	timer := &_timer{
+		id:       self.timerID,
+		repeat:   repeat,
+		function: function,
+	}
+	for _, argument := range argumentList {
+		timer.argumentList = append(timer.argumentList, argument)
+	}
+	self.timerMap[timer.id] = timer
+	return timer
+}
+
+func (self *Loop) setTimer(call otto.FunctionCall, repeat bool) otto.Value {
+	argumentList := []otto.Value(nil)
+	if len(call.ArgumentList) > 2 {
+		argumentList = call.ArgumentList[2:]
+	}
+	timer := self.newTimer(call, repeat, argumentList)
+
+	delay, _ := call.Argument(1).ToFloat()
+	if !(delay >= 1 && delay <= maxDelay) { // Also NaN
+		delay = 1
+	}
+	timer.interval = time.Duration(delay * float64(time.Millisecond))
+	self.schedule(timer, timer.interval)
+
+	value, _ := call.Otto.ToValue(timer.id)
+	return value
+}
+
+func (self *Loop) setImmediate(call otto.FunctionCall) otto.Value {
+	argumentList := []otto.Value(nil)
+	if len(call.ArgumentList) > 1 {
+		argumentList = call.ArgumentList[1:]
+	}
+	timer := self.newTimer(call, false, argumentList)
+	self.schedule(timer, 0)
+
+	value, _ := call.Otto.ToValue(timer.id)
+	return value
+}
+
+func (self *Loop) clearTimer(call otto.FunctionCall) otto.Value {
+	id, _ := call.Argument(0).ToInteger()
+	if timer, exists := self.timerMap[id]; exists {
+		delete(self.timerMap, id)
+		if timer.index >= 0 {
+			heap.Remove(&self.timerList, timer.index)
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+// _timerList implements heap.Interface
+type _timerList []*_timer
+
+func (self _timerList) Len() int {
+	return len(self)
+}
+
+func (self _timerList) Less(i, j int) bool {
+	if self[i].due.Equal(self[j].due) {
+		return self[i].sequence < self[j].sequence
+	}
+	return self[i].due.Before(self[j].due)
+}
+
+func (self _timerList) Swap(i, j int) {
+	self[i], self[j] = self[j], self[i]
+	self[i].index = i
+	self[j].index = j
+}
+
+func (self *_timerList) Push(value interface{}) {
+	timer := value.(*_timer)
+	timer.index = len(*self)
+	*self = append(*self, timer)
+}
+
+func (self *_timerList) Pop() interface{} {
+	list := *self
+	timer := list[len(list)-1]
+	timer.index = -1 // No longer in the list (e.g. an interval that is running)
+	*self = list[:len(list)-1]
+	return timer
+}
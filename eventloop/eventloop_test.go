@@ -0,0 +1,245 @@
+package eventloop
+
+import (
+	. "../terst"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+func newFakeLoop() *Loop {
+	loop := New(otto.New())
+	loop.UseFakeClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	return loop
+}
+
+func TestTimer(t *testing.T) {
+	Terst(t)
+
+	{
+		loop := newFakeLoop()
+		start := loop.Now()
+		err := loop.Run(`
+            var abc = [];
+            setTimeout(function(){ abc.push("c") }, 300);
+            setTimeout(function(value){ abc.push(value) }, 100, "a");
+            setTimeout(function(){ abc.push("b") }, 200);
+            setImmediate(function(){ abc.push("immediate") });
+            abc.push("script");
+        `)
+		Is(err, nil)
+		value, _ := loop.Otto().Get("abc")
+		Is(value, "script,immediate,a,b,c")
+		Is(loop.Now().Sub(start), 300*time.Millisecond)
+	}
+
+	{
+		loop := newFakeLoop()
+		start := loop.Now()
+		err := loop.Run(`
+            var abc = [];
+            var def = setInterval(function(){
+                abc.push(abc.length);
+                if (abc.length == 3) {
+                    clearInterval(def);
+                }
+            }, 1000);
+            var ghi = setTimeout(function(){ abc.push("cleared") }, 500);
+            clearTimeout(ghi);
+            clearImmediate(setImmediate(function(){ abc.push("cleared") }));
+        `)
+		Is(err, nil)
+		value, _ := loop.Otto().Get("abc")
+		Is(value, "0,1,2")
+		Is(loop.Now().Sub(start), 3*time.Second)
+	}
+
+	{
+		// Timers with the same due time run in the order they were set
+		loop := newFakeLoop()
+		err := loop.Run(`
+            var abc = [];
+            setTimeout(function(){ abc.push(1) }, 0);
+            setTimeout(function(){ abc.push(2) });
+            setTimeout(function(){ abc.push(3) }, NaN);
+        `)
+		Is(err, nil)
+		value, _ := loop.Otto().Get("abc")
+		Is(value, "1,2,3")
+	}
+
+	{
+		loop := newFakeLoop()
+		err := loop.Run(`
+            var abc;
+            try {
+                setTimeout("xyzzy", 10);
+            } catch (error) {
+                abc = error instanceof TypeError;
+            }
+        `)
+		Is(err, nil)
+		value, _ := loop.Otto().Get("abc")
+		Is(value, "true")
+	}
+
+	{
+		// The real clock
+		loop := New(otto.New())
+		start := time.Now()
+		err := loop.Run(`
+            var abc = 0;
+            setTimeout(function(){ abc += 1 }, 20);
+        `)
+		Is(err, nil)
+		value, _ := loop.Otto().Get("abc")
+		Is(value, "1")
+		Is(time.Since(start) >= 20*time.Millisecond, true)
+	}
+}
+
+func TestTimer_error(t *testing.T) {
+	Terst(t)
+
+	loop := newFakeLoop()
+	err := loop.Run(`
+        var abc = 0;
+        setTimeout(function(){ abc += 1; throw new Error("xyzzy") }, 10);
+        setTimeout(function(){ abc += 1 }, 20);
+    `)
+	Is(err, "Error: xyzzy")
+	value, _ := loop.Otto().Get("abc")
+	Is(value, "1")
+
+	// The remaining timer is still pending
+	err = loop.Run(nil)
+	Is(err, nil)
+	value, _ = loop.Otto().Get("abc")
+	Is(value, "2")
+}
+
+func TestSchedule(t *testing.T) {
+	Terst(t)
+
+	loop := newFakeLoop()
+	_, err := loop.Otto().Run(`
+        var abc = [];
+        function def(value) {
+            abc.push(value);
+        }
+    `)
+	Is(err, nil)
+
+	done := make([]func(func(*otto.Otto) error), 3)
+	for index := range done {
+		done[index] = loop.Reserve()
+	}
+	for index := range done {
+		go func(index int) {
+			time.Sleep(time.Duration(index) * 10 * time.Millisecond)
+			done[index](func(vm *otto.Otto) error {
+				_, err := vm.Call("def", nil, index)
+				return err
+			})
+		}(index)
+	}
+
+	// The fake clock does not move while waiting on a reservation, so the
+	// timer runs last
+	err = loop.Run(`
+        setTimeout(function(){ def("timer") }, 1);
+    `)
+	Is(err, nil)
+	value, _ := loop.Otto().Get("abc")
+	Is(value, "0,1,2,timer")
+
+	loop.Schedule(func(vm *otto.Otto) error {
+		_, err := vm.Call("def", nil, "schedule")
+		return err
+	})
+	err = loop.Run(nil)
+	Is(err, nil)
+	value, _ = loop.Otto().Get("abc")
+	Is(value, "0,1,2,timer,schedule")
+}
+
+func TestRunContext(t *testing.T) {
+	Terst(t)
+
+	{
+		loop := New(otto.New())
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := loop.RunContext(ctx, `
+            setInterval(function(){}, 1);
+        `)
+		Is(err == context.DeadlineExceeded, true)
+	}
+
+	{
+		// A busy timer is interrupted as well
+		loop := New(otto.New())
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		err := loop.RunContext(ctx, `
+            setTimeout(function(){ for (;;) {} }, 1);
+        `)
+		Is(err == otto.ErrInterrupted, true)
+	}
+
+	{
+		// Waiting on a reservation
+		loop := newFakeLoop()
+		loop.Reserve()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := loop.RunContext(ctx, nil)
+		Is(err == context.DeadlineExceeded, true)
+	}
+}
+
+func TestPromise(t *testing.T) {
+	Terst(t)
+
+	loop := newFakeLoop()
+	loop.Otto().Set("delay", func(call otto.FunctionCall) otto.Value {
+		promise, resolve, reject := loop.NewPromise()
+		value, _ := call.Argument(0).ToString()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			if value == "reject" {
+				reject(value)
+			} else {
+				resolve(value)
+			}
+			resolve("ignored")
+		}()
+		return promise
+	})
+
+	err := loop.Run(`
+        var abc = [];
+        delay("xyzzy").then(function(value){
+            abc.push(value);
+            return delay("reject");
+        }).catch(function(reason){
+            abc.push(reason);
+            return new Promise(function(resolve){
+                setTimeout(resolve, 1000, "timeout");
+            });
+        }).then(function(value){
+            abc.push(value);
+        });
+        Promise.resolve("resolve").then(function(value){
+            abc.push(value);
+        });
+    `)
+	Is(err, nil)
+	value, _ := loop.Otto().Get("abc")
+	Is(value, "resolve,xyzzy,reject,timeout")
+}
@@ -3,11 +3,18 @@ package otto
 type _executionContext struct {
 	LexicalEnvironment  _environment
 	VariableEnvironment _environment
-	this                *_object
+	this                Value
 	eval                bool // Replace this with kind?
+	strict              bool // The code being evaluated is strict mode code
+
+	function  *_object  // The function being called, if any (for the stack trace)
+	position  _position // The position of the current call (or new) in this context
+	newTarget *_object  // The class that new was applied to, when calling the constructor of a class
+
+	generator *_generatorChannel // The generator running the body of this context, if any (for yield)
 }
 
-func newExecutionContext(lexical _environment, variable _environment, this *_object) *_executionContext {
+func newExecutionContext(lexical _environment, variable _environment, this Value) *_executionContext {
 	return &_executionContext{
 		LexicalEnvironment:  lexical,
 		VariableEnvironment: variable,
@@ -16,8 +23,9 @@ func newExecutionContext(lexical _environment, variable _environment, this *_obj
 }
 
 func (self *_executionContext) getValue(name string) Value {
-	strict := false
-	return self.LexicalEnvironment.GetValue(name, strict)
+	// The binding may be in an outer environment, e.g. the global object is
+	// outside of the (let and const) bindings of the top-level
+	return getIdentifierReference(self.LexicalEnvironment, name, self.strict, nil).GetValue()
 }
 
 func (self *_executionContext) setValue(name string, value Value, throw bool) {
@@ -38,3 +46,21 @@ func (self *_executionContext) newDeclarativeEnvironment(runtime *_runtime) _env
 	self.LexicalEnvironment = runtime.newDeclarativeEnvironment(self.LexicalEnvironment)
 	return previousLexical
 }
+
+func (self0 *_executionContext) clone(clone *_clone) *_executionContext {
+	self1 := &_executionContext{
+		LexicalEnvironment:  clone.environment(self0.LexicalEnvironment),
+		VariableEnvironment: clone.environment(self0.VariableEnvironment),
+		this:                clone.value(self0.this),
+		eval:                self0.eval,
+		strict:              self0.strict,
+		position:            self0.position,
+	}
+	if self0.function != nil {
+		self1.function = clone.object(self0.function)
+	}
+	if self0.newTarget != nil {
+		self1.newTarget = clone.object(self0.newTarget)
+	}
+	return self1
+}
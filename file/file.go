@@ -0,0 +1,113 @@
+// Package file encapsulates the file abstractions used by the ast & parser.
+package file
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Idx is a compact encoding of a source position within a file.
+//
+// An Idx is the (1-based) byte offset into the source of the file,
+// so the first character of a file is at Idx 1.
+// The zero value, 0, means "no position".
+type Idx int
+
+// Position describes an arbitrary source position
+// including the filename, line, and column location.
+type Position struct {
+	Filename string // The filename where the error occurred, if any
+	Offset   int    // The src offset (0-based, in bytes)
+	Line     int    // The line number, starting at 1
+	Column   int    // The column number, starting at 1 (The character count)
+}
+
+// IsValid reports whether the position is valid.
+func (self *Position) IsValid() bool {
+	return self.Line > 0
+}
+
+// String returns a string in one of several forms:
+//
+//	file:line:column    A valid position with filename
+//	line:column         A valid position without filename
+//	file                An invalid position with filename
+//	-                   An invalid position without filename
+func (self *Position) String() string {
+	str := self.Filename
+	if self.IsValid() {
+		if str != "" {
+			str += ":"
+		}
+		str += fmt.Sprintf("%d:%d", self.Line, self.Column)
+	}
+	if str == "" {
+		str = "-"
+	}
+	return str
+}
+
+// File is the source of a single program (or eval, or function body), and
+// the means of translating an Idx into a Position.
+type File struct {
+	name string
+	src  string
+	line int // The line number of the first line of src
+}
+
+// NewFile returns a new File for the given filename and source.
+func NewFile(filename, src string) *File {
+	return NewFileAt(filename, src, 1)
+}
+
+// NewFileAt returns a new File for the given filename and source, where the
+// first line of the source is the given line (rather than line 1).
+func NewFileAt(filename, src string, line int) *File {
+	return &File{
+		name: filename,
+		src:  src,
+		line: line,
+	}
+}
+
+func (fl *File) Name() string {
+	return fl.name
+}
+
+func (fl *File) Source() string {
+	return fl.src
+}
+
+// Position returns the Position for the given Idx, or nil if the Idx
+// is not within the file.
+func (fl *File) Position(idx Idx) *Position {
+	offset := int(idx) - 1
+	if fl == nil || offset < 0 || offset > len(fl.src) {
+		return nil
+	}
+
+	line, column := fl.line, 1
+	for index := 0; index < offset; {
+		chr, width := utf8.DecodeRuneInString(fl.src[index:])
+		index += width
+		switch chr {
+		case '\r':
+			if index < len(fl.src) && fl.src[index] == '\n' {
+				continue // \r\n is a single line terminator
+			}
+			fallthrough
+		case '\n', '\u2028', '\u2029':
+			line += 1
+			column = 1
+		default:
+			column += 1
+		}
+	}
+
+	return &Position{
+		Filename: fl.name,
+		Offset:   offset,
+		Line:     line,
+		Column:   column,
+	}
+}
@@ -0,0 +1,51 @@
+package file
+
+import (
+	. "../terst"
+	"testing"
+)
+
+func TestPosition(t *testing.T) {
+	Terst(t)
+
+	fl := NewFile("xyzzy.js", "abc\ndef\r\nghi\u2028\u00e9=1")
+	Is(fl.Name(), "xyzzy.js")
+
+	test := func(idx Idx, expect string) {
+		position := fl.Position(idx)
+		if position == nil {
+			Is("<nil>", expect)
+			return
+		}
+		Is(position.String(), expect)
+	}
+
+	test(0, "<nil>")
+	test(1, "xyzzy.js:1:1")
+	test(3, "xyzzy.js:1:3")
+	test(5, "xyzzy.js:2:1")
+	test(8, "xyzzy.js:2:4") // \r\n
+	test(10, "xyzzy.js:3:1")
+	test(13, "xyzzy.js:3:4") // \u2028
+	test(16, "xyzzy.js:4:1")
+	test(18, "xyzzy.js:4:2") // The column counts characters, not bytes
+	test(20, "xyzzy.js:4:4") // The end of the source
+	test(21, "<nil>")
+
+	Is(fl.Position(18).Offset, 17)
+
+	Is((&Position{}).String(), "-")
+	Is((&Position{Filename: "xyzzy.js"}).String(), "xyzzy.js")
+	Is((&Position{Line: 1, Column: 2}).String(), "1:2")
+	Is((&Position{Line: 1, Column: 2}).IsValid(), true)
+	Is((&Position{}).IsValid(), false)
+
+	var nilFile *File
+	Is(nilFile.Position(1) == nil, true)
+
+	// A source that begins at another line of the file
+	fl = NewFileAt("xyzzy.js", "abc(\ndef)", 0)
+	test(1, "xyzzy.js")
+	test(6, "xyzzy.js:1:1")
+	test(9, "xyzzy.js:1:4")
+}
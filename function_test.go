@@ -252,3 +252,69 @@ func TestFunction_toString(t *testing.T) {
         Function.prototype.toString.call(undefined);
     `, "TypeError")
 }
+
+func TestFunction_arrow(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = (a, b) => a + b;
+        var def = a => a * 2;
+        var ghi = () => { return "ghi"; };
+        [ abc(1, 2), def(3), ghi(), abc.length, typeof abc ];
+    `, "3,6,ghi,2,function")
+
+	test(`[ 1, 2, 3 ].map(value => value * value).filter((value) => value > 1)`, "4,9")
+
+	// An object literal body needs parentheses
+	test(`(() => ({ abc: 1 }))().abc`, "1")
+	test(`(() => { abc: 1 })()`, "undefined")
+
+	// this and arguments are those of where the function is defined
+	test(`
+        var abc = {
+            name: "abc",
+            def: function() {
+                return [ 1 ].map(() => this.name + ":" + arguments[0]);
+            },
+            ghi: () => this === abc
+        };
+        [ abc.def("xyzzy"), abc.ghi(), abc.ghi.call(abc) ];
+    `, "abc:xyzzy,false,false")
+
+	test(`
+        function abc() {
+            var nested = () => () => this.value;
+            return nested()();
+        }
+        abc.call({ value: "nested" });
+    `, "nested")
+
+	test(`
+        var abc = (x) => x + this.def;
+        var def = 1;
+        [ abc.call({ def: 2 }, 1), abc.bind({ def: 3 })(1), abc.apply(null, [ 1 ]) ];
+    `, "2,2,2")
+
+	// Not a constructor
+	test(`raise: new (() => {})`, "TypeError: [function] is not a constructor")
+	test(`raise: new ((() => {}).bind())`, "TypeError: [function] is not a constructor")
+	test(`(() => {}).hasOwnProperty("prototype")`, "false")
+
+	test(`
+        var abc = [];
+        var def = (a, b) =>
+            a + b;
+        abc.push(def(1, 2));
+        var ghi = x => { "use strict"; return this === undefined; };
+        abc.push(ghi());
+        abc;
+    `, "3,false")
+
+	test(`raise: var abc = (a, a) => a;`, "SyntaxError: Duplicate parameter name not allowed in this context")
+	test(`raise:
+        var abc = x
+            => x;
+    `, "SyntaxError: Unexpected token =>")
+}
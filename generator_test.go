@@ -0,0 +1,318 @@
+package otto
+
+import (
+	. "./terst"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestGenerator(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function* abc(def) {
+            var ghi = yield def;
+            var jkl = yield ghi * 2;
+            return jkl + 1;
+        }
+        var mno = abc(1);
+        [ mno.next("ignored"), mno.next(2), mno.next(3), mno.next() ].map(function(result) {
+            return result.value + ":" + result.done;
+        }).join(",");
+    `, "1:false,4:false,4:true,undefined:true")
+
+	test(`
+        var abc = function*() {
+            for (var def = 0; def < 3; def++) {
+                yield def;
+            }
+        };
+        [ ...abc() ];
+    `, "0,1,2")
+
+	// The body is only run by next, not by the call
+	test(`
+        var abc = [];
+        function* def() {
+            abc.push("body");
+            yield;
+        }
+        var ghi = def();
+        abc.push("call");
+        ghi.next();
+        abc;
+    `, "call,body")
+
+	test(`
+        function* abc() {
+            yield 1;
+            yield* [ 2, 3 ];
+            var def = yield* ghi();
+            yield def;
+        }
+        function* ghi() {
+            yield 4;
+            return 5;
+        }
+        var jkl = [];
+        for (var mno of abc()) {
+            jkl.push(mno);
+        }
+        jkl;
+    `, "1,2,3,4,5")
+
+	test(`
+        function* abc() {
+            var def = 0;
+            while (true) {
+                try {
+                    yield def++;
+                } catch (error) {
+                    def = error;
+                }
+            }
+        }
+        var ghi = abc();
+        [ ghi.next().value, ghi.next().value, ghi.throw(10).value, ghi.next().value ].join(",");
+    `, "0,1,10,11")
+
+	test(`
+        var abc = [];
+        function* def() {
+            try {
+                yield 1;
+                yield 2;
+            } finally {
+                abc.push("finally");
+            }
+        }
+        var ghi = def();
+        ghi.next();
+        abc.push(ghi.return(3).value, ghi.next().done);
+        for (var jkl of def()) {
+            break;
+        }
+        abc;
+    `, "finally,3,true,finally")
+
+	// A finally block can yield (or return) during a return
+	test(`
+        function* abc() {
+            try {
+                yield 1;
+            } finally {
+                yield 2;
+                return 3;
+            }
+        }
+        var def = abc();
+        def.next();
+        [ def.return(4).value, def.next().value, def.next().done ].join(",");
+    `, "2,3,true")
+
+	// The return and throw of a generator that has not been started
+	test(`
+        var abc = [];
+        function* def() {
+            abc.push("body");
+            yield 1;
+        }
+        var ghi = def();
+        var jkl = def();
+        try {
+            jkl.throw("xyzzy");
+        } catch (error) {
+            abc.push(error);
+        }
+        [ ghi.return(2).value, ghi.next().done, jkl.next().done, abc ].join(",");
+    `, "2,true,true,xyzzy")
+
+	test(`
+        function* abc() {
+            throw new Error("xyzzy");
+        }
+        var def = abc();
+        var ghi;
+        try {
+            def.next();
+        } catch (error) {
+            ghi = error.message;
+        }
+        [ ghi, def.next().done ].join(",");
+    `, "xyzzy,true")
+
+	test(`
+        function* abc() {
+            yield this.def;
+            yield arguments.length;
+        }
+        var ghi = abc.call({ def: "xyzzy" }, 1, 2);
+        [ ghi.next().value, ghi.next().value ].join(",");
+    `, "xyzzy,2")
+
+	test(`
+        function* abc() {}
+        var def = abc();
+        [ Object.getPrototypeOf(def) === abc.prototype, def instanceof abc, typeof def.next, def[Symbol.iterator]() === def, Object.prototype.toString.call(def), abc.prototype.hasOwnProperty("constructor") ].join(",");
+    `, "true,true,function,true,[object Generator],false")
+
+	test(`
+        class Abc {
+            constructor(def) {
+                this.def = def;
+            }
+            *values() {
+                yield* this.def;
+            }
+            static *ghi() {
+                yield 1;
+            }
+        }
+        Abc.prototype[Symbol.iterator] = Abc.prototype.values;
+        [ ...new Abc([ 1, 2 ]), ...Abc.ghi() ];
+    `, "1,2,1")
+
+	test(`raise: new (function*() {})`, "TypeError: [function] is not a constructor")
+
+	test(`raise:
+        function* abc() {
+            abc.next();
+        }
+        abc = abc();
+        abc.next();
+    `, "TypeError: Generator is already running")
+
+	test(`raise:
+        function* abc() {
+            var def = {};
+            def[Symbol.iterator] = function() {
+                return { next: function() { return { done: false } } };
+            };
+            yield* def;
+        }
+        var def = abc();
+        def.next();
+        def.throw(1);
+    `, "TypeError: The iterator does not provide a 'throw' method")
+
+	test(`raise: (function*() {}).prototype.next.call({})`, "TypeError: Method Generator.prototype.next called on incompatible receiver [object Object]")
+}
+
+func TestGeneratorClone(t *testing.T) {
+	Terst(t)
+
+	vm := New()
+	_, err := vm.Run(`
+        function* abc() {
+            yield 1;
+            yield 2;
+        }
+        var def = abc();
+        var ghi = abc();
+        ghi.next();
+    `)
+	Is(err, nil)
+
+	clone := vm.Copy()
+	value, err := clone.Run(`[ def.next().value, ghi.next().done ].join(",")`)
+	Is(err, nil)
+	Is(value, "1,true")
+
+	// The original is unaffected
+	value, err = vm.Run(`[ def.next().value, ghi.next().value ].join(",")`)
+	Is(err, nil)
+	Is(value, "1,2")
+}
+
+func TestGeneratorClose(t *testing.T) {
+	Terst(t)
+
+	goroutines := numGoroutine(0)
+	for index := 0; index < 10; index++ {
+		vm := New()
+		_, err := vm.Run(`
+            function* abc() {
+                try {
+                    yield 1;
+                } finally {
+                    xyzzy = "Nothing happens.";
+                }
+            }
+            var def = abc();
+            def.next();
+            for (var ghi = 0; ghi < 10; ghi++) {
+                (function() {
+                    abc().next();
+                })();
+            }
+            var xyzzy;
+        `)
+		Is(err, nil)
+		Is(runtime.NumGoroutine() >= goroutines+11, true)
+
+		// The bodies are unwound (without running the finally block), and the runtime is still usable
+		vm.Close()
+		value, err := vm.Run(`[ def.next().done, xyzzy, abc().next().value ].join(",")`)
+		Is(err, nil)
+		Is(value, "true,,1")
+		vm.Close()
+	}
+	// The goroutines of the discarded runtimes have ended
+	Is(numGoroutine(goroutines) <= goroutines, true)
+}
+
+func TestGeneratorSweep(t *testing.T) {
+	Terst(t)
+
+	// A generator that is gone is unwound without Close, before a new one would exceed the memory limit
+	goroutines := numGoroutine(0)
+	vm := New()
+	vm.SetMemoryLimit(vm.MemoryUsage() + 4*1024*1024)
+	_, err := vm.Run(`
+        function* abc() {
+            yield 1;
+        }
+        for (var def = 0; def < 1000; def++) {
+            (function*() {
+                yield 1;
+            })().next();
+            (function() {
+                var ghi = abc();
+                ghi.next();
+            })();
+        }
+    `)
+	Is(err, nil)
+	Is(numGoroutine(goroutines+600) <= goroutines+600, true)
+
+	// ...unlike one that is still reachable
+	_, err = vm.Run(`
+        var jkl = [];
+        for (var def = 0; def < 1000; def++) {
+            jkl.push(abc());
+            jkl[def].next();
+        }
+    `)
+	_, valid := err.(*MemoryLimitError)
+	Is(valid, true)
+
+	vm.Close()
+	Is(numGoroutine(goroutines) <= goroutines, true)
+}
+
+// numGoroutine returns the number of goroutines once it is no more than count, or once
+// it has settled, as a goroutine that is ending is only gone once it has exited
+func numGoroutine(count int) int {
+	number := runtime.NumGoroutine()
+	for index := 0; index < 100 && number > count; index++ {
+		time.Sleep(10 * time.Millisecond)
+		previous := number
+		if number = runtime.NumGoroutine(); number == previous {
+			break
+		}
+	}
+	return number
+}
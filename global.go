@@ -48,10 +48,13 @@ var (
 
 func newContext() *_runtime {
 
-	self := &_runtime{}
+	self := &_runtime{
+		maxCallDepth: defaultMaxCallDepth,
+	}
 
 	self.GlobalEnvironment = self.newObjectEnvironment(nil, nil)
 	self.GlobalObject = self.GlobalEnvironment.Object
+	self.GlobalLexicalEnvironment = self.newDeclarativeEnvironment(self.GlobalEnvironment)
 
 	self.EnterGlobalExecutionContext()
 
@@ -137,6 +140,12 @@ func (runtime *_runtime) newNumber(value Value) *_object {
 	return self
 }
 
+func (runtime *_runtime) newSymbol(value Value) *_object {
+	self := runtime.newPrimitiveObject("Symbol", value)
+	self.prototype = runtime.Global.SymbolPrototype
+	return self
+}
+
 func (runtime *_runtime) newRegExp(patternValue Value, flagsValue Value) *_object {
 
 	pattern := ""
@@ -190,14 +199,51 @@ func (runtime *_runtime) newError(name string, message Value) *_object {
 		return runtime.newURIError(message)
 	}
 
-	self = runtime.newErrorObject(message)
-	self.prototype = runtime.Global.ErrorPrototype
+	self = runtime.newErrorObject(runtime.Global.ErrorPrototype, message)
 	if name != "" {
 		self.defineProperty("name", toValue_string(name), 0111, false)
 	}
 	return self
 }
 
+func (runtime *_runtime) newPromise() *_object {
+	self := runtime.newPromiseObject()
+	self.prototype = runtime.Global.PromisePrototype
+	return self
+}
+
+func (runtime *_runtime) newMap() *_object {
+	self := runtime.newMapObject("Map")
+	self.prototype = runtime.Global.MapPrototype
+	return self
+}
+
+func (runtime *_runtime) newSet() *_object {
+	self := runtime.newMapObject("Set")
+	self.prototype = runtime.Global.SetPrototype
+	return self
+}
+
+func (runtime *_runtime) newWeakMap() *_object {
+	self := runtime.newWeakMapObject("WeakMap")
+	self.prototype = runtime.Global.WeakMapPrototype
+	return self
+}
+
+func (runtime *_runtime) newWeakSet() *_object {
+	self := runtime.newWeakMapObject("WeakSet")
+	self.prototype = runtime.Global.WeakSetPrototype
+	return self
+}
+
+// newBuiltinFunction returns a function without a prototype (like the builtin
+// functions, e.g. the resolve function of a promise)
+func (runtime *_runtime) newBuiltinFunction(length int, _nativeFunction _nativeFunction) *_object {
+	self := runtime.newNativeFunctionObject(_nativeFunction, length)
+	self.prototype = runtime.Global.FunctionPrototype
+	return self
+}
+
 func (runtime *_runtime) newNativeFunction(_nativeFunction _nativeFunction) *_object {
 	self := runtime.newNativeFunctionObject(_nativeFunction, 0)
 	self.prototype = runtime.Global.FunctionPrototype
@@ -211,8 +257,68 @@ func (runtime *_runtime) newNodeFunction(node *_functionNode, scopeEnvironment _
 	// TODO Implement 13.2 fully
 	self := runtime.newNodeFunctionObject(node, scopeEnvironment)
 	self.prototype = runtime.Global.FunctionPrototype
+	if node.strict {
+		runtime.defineThrowTypeError(self, "caller", "arguments")
+	}
+	if node.generator {
+		runtime.defineGeneratorPrototype(self)
+		return self
+	}
+	if node.async {
+		return self
+	}
 	prototype := runtime.newObject()
 	self.defineProperty("prototype", toValue_object(prototype), 0100, false)
 	prototype.defineProperty("constructor", toValue_object(self), 0101, false)
 	return self
 }
+
+// newArrowFunction is newNodeFunction for an arrow function, which is called
+// with the given (lexical) this (and super of home), and is not a constructor
+// (so has no prototype)
+func (runtime *_runtime) newArrowFunction(node *_functionNode, scopeEnvironment _environment, this Value, home *_object) *_object {
+	self := runtime.newClassObject("Function")
+	call := newNodeCallFunction(node, scopeEnvironment)
+	call.this = this
+	call.home = home
+	self.value = _functionObject{
+		call: call,
+	}
+	self.defineProperty("length", toValue_int(node.Length), 0000, false)
+	self.prototype = runtime.Global.FunctionPrototype
+	return self
+}
+
+// newClass is newNodeFunction for the constructor of a class, with the given prototype,
+// where parent is the class that it extends (or Function.prototype)
+func (runtime *_runtime) newClass(node *_functionNode, scopeEnvironment _environment, prototype *_object, parent *_object) *_object {
+	self := runtime.newClassObject("Function")
+	call := newNodeCallFunction(node, scopeEnvironment)
+	call.home = prototype
+	self.value = _functionObject{
+		call:      call,
+		construct: classConstructFunction,
+	}
+	self.defineProperty("length", toValue_int(node.Length), 0000, false)
+	self.prototype = parent
+	self.defineProperty("prototype", toValue_object(prototype), 0000, false)
+	prototype.defineProperty("constructor", toValue_object(self), 0101, false)
+	return self
+}
+
+// newMethod is newNodeFunction for a method (or getter or setter) of home, which
+// is not a constructor (so has no prototype, unless it is a generator method)
+func (runtime *_runtime) newMethod(node *_functionNode, scopeEnvironment _environment, home *_object) *_object {
+	self := runtime.newClassObject("Function")
+	call := newNodeCallFunction(node, scopeEnvironment)
+	call.home = home
+	self.value = _functionObject{
+		call: call,
+	}
+	self.defineProperty("length", toValue_int(node.Length), 0000, false)
+	self.prototype = runtime.Global.FunctionPrototype
+	if node.generator {
+		runtime.defineGeneratorPrototype(self)
+	}
+	return self
+}
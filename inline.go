@@ -642,6 +642,28 @@ func _newContext(runtime *_runtime) {
 				call: _nativeCallFunction(builtinObject_getOwnPropertyNames),
 			},
 		}
+		getOwnPropertySymbols_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinObject_getOwnPropertySymbols),
+			},
+		}
 		runtime.Global.Object = &_object{
 			runtime:     runtime,
 			class:       "Function",
@@ -758,6 +780,13 @@ func _newContext(runtime *_runtime) {
 						value:      getOwnPropertyNames_function,
 					},
 				},
+				"getOwnPropertySymbols": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      getOwnPropertySymbols_function,
+					},
+				},
 			},
 			propertyOrder: []string{
 				"length",
@@ -775,6 +804,7 @@ func _newContext(runtime *_runtime) {
 				"freeze",
 				"keys",
 				"getOwnPropertyNames",
+				"getOwnPropertySymbols",
 			},
 		}
 		runtime.Global.ObjectPrototype.property["constructor"] =
@@ -1291,6 +1321,72 @@ func _newContext(runtime *_runtime) {
 				call: _nativeCallFunction(builtinArray_reduceRight),
 			},
 		}
+		values_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinArray_values),
+			},
+		}
+		keys_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinArray_keys),
+			},
+		}
+		entries_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinArray_entries),
+			},
+		}
 		isArray_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
@@ -1475,6 +1571,34 @@ func _newContext(runtime *_runtime) {
 						value:      reduceRight_function,
 					},
 				},
+				"values": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      values_function,
+					},
+				},
+				"keys": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      keys_function,
+					},
+				},
+				"entries": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      entries_function,
+					},
+				},
+				symbolKeyIterator: _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      values_function,
+					},
+				},
 			},
 			propertyOrder: []string{
 				"length",
@@ -1499,6 +1623,10 @@ func _newContext(runtime *_runtime) {
 				"filter",
 				"reduce",
 				"reduceRight",
+				"values",
+				"keys",
+				"entries",
+				symbolKeyIterator,
 			},
 		}
 		runtime.Global.Array = &_object{
@@ -2034,6 +2162,28 @@ func _newContext(runtime *_runtime) {
 				call: _nativeCallFunction(builtinString_toLocaleUpperCase),
 			},
 		}
+		iterator_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinString_iterator),
+			},
+		}
 		fromCharCode_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
@@ -2056,6 +2206,28 @@ func _newContext(runtime *_runtime) {
 				call: _nativeCallFunction(builtinString_fromCharCode),
 			},
 		}
+		raw_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinString_raw),
+			},
+		}
 		runtime.Global.StringPrototype = &_object{
 			runtime:     runtime,
 			class:       "String",
@@ -2225,6 +2397,13 @@ func _newContext(runtime *_runtime) {
 						value:      toLocaleUpperCase_function,
 					},
 				},
+				symbolKeyIterator: _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      iterator_function,
+					},
+				},
 			},
 			propertyOrder: []string{
 				"length",
@@ -2250,6 +2429,7 @@ func _newContext(runtime *_runtime) {
 				"localeCompare",
 				"toLocaleLowerCase",
 				"toLocaleUpperCase",
+				symbolKeyIterator,
 			},
 		}
 		runtime.Global.String = &_object{
@@ -2284,11 +2464,19 @@ func _newContext(runtime *_runtime) {
 						value:      fromCharCode_function,
 					},
 				},
+				"raw": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      raw_function,
+					},
+				},
 			},
 			propertyOrder: []string{
 				"length",
 				"prototype",
 				"fromCharCode",
+				"raw",
 			},
 		}
 		runtime.Global.StringPrototype.property["constructor"] =
@@ -3272,6 +3460,13 @@ func _newContext(runtime *_runtime) {
 						value:      math.Sqrt2,
 					},
 				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Math",
+					},
+				},
 			},
 			propertyOrder: []string{
 				"abs",
@@ -3300,6 +3495,7 @@ func _newContext(runtime *_runtime) {
 				"PI",
 				"SQRT1_2",
 				"SQRT2",
+				symbolKeyToStringTag,
 			},
 		}
 	}
@@ -5530,15 +5726,23 @@ func _newContext(runtime *_runtime) {
 						value:      stringify_function,
 					},
 				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "JSON",
+					},
+				},
 			},
 			propertyOrder: []string{
 				"parse",
 				"stringify",
+				symbolKeyToStringTag,
 			},
 		}
 	}
 	{
-		eval_function := &_object{
+		then_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5549,7 +5753,7 @@ func _newContext(runtime *_runtime) {
 					mode: 0,
 					value: Value{
 						_valueType: valueNumber,
-						value:      1,
+						value:      2,
 					},
 				},
 			},
@@ -5557,10 +5761,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_eval),
+				call: _nativeCallFunction(builtinPromise_then),
 			},
 		}
-		parseInt_function := &_object{
+		catch_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5571,7 +5775,7 @@ func _newContext(runtime *_runtime) {
 					mode: 0,
 					value: Value{
 						_valueType: valueNumber,
-						value:      2,
+						value:      1,
 					},
 				},
 			},
@@ -5579,10 +5783,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_parseInt),
+				call: _nativeCallFunction(builtinPromise_catch),
 			},
 		}
-		parseFloat_function := &_object{
+		finally_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5601,10 +5805,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_parseFloat),
+				call: _nativeCallFunction(builtinPromise_finally),
 			},
 		}
-		isNaN_function := &_object{
+		resolve_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5623,10 +5827,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_isNaN),
+				call: _nativeCallFunction(builtinPromise_resolve),
 			},
 		}
-		isFinite_function := &_object{
+		reject_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5645,10 +5849,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_isFinite),
+				call: _nativeCallFunction(builtinPromise_reject),
 			},
 		}
-		decodeURI_function := &_object{
+		all_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5667,10 +5871,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_decodeURI),
+				call: _nativeCallFunction(builtinPromise_all),
 			},
 		}
-		decodeURIComponent_function := &_object{
+		race_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5689,10 +5893,10 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_decodeURIComponent),
+				call: _nativeCallFunction(builtinPromise_race),
 			},
 		}
-		encodeURI_function := &_object{
+		allSettled_function := &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
@@ -5711,37 +5915,63 @@ func _newContext(runtime *_runtime) {
 				"length",
 			},
 			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_encodeURI),
+				call: _nativeCallFunction(builtinPromise_allSettled),
 			},
 		}
-		encodeURIComponent_function := &_object{
+		runtime.Global.PromisePrototype = &_object{
 			runtime:     runtime,
-			class:       "Function",
+			class:       "Promise",
 			objectClass: _classObject,
-			prototype:   runtime.Global.FunctionPrototype,
+			prototype:   runtime.Global.ObjectPrototype,
 			extensible:  true,
+			value:       nil,
 			property: map[string]_property{
-				"length": _property{
-					mode: 0,
+				"then": _property{
+					mode: 0101,
 					value: Value{
-						_valueType: valueNumber,
-						value:      1,
+						_valueType: valueObject,
+						value:      then_function,
+					},
+				},
+				"catch": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      catch_function,
+					},
+				},
+				"finally": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      finally_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Promise",
 					},
 				},
 			},
 			propertyOrder: []string{
-				"length",
-			},
-			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_encodeURIComponent),
+				"then",
+				"catch",
+				"finally",
+				symbolKeyToStringTag,
 			},
 		}
-		escape_function := &_object{
+		runtime.Global.Promise = &_object{
 			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
 			prototype:   runtime.Global.FunctionPrototype,
 			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinPromise),
+				construct: builtinNewPromise,
+			},
 			property: map[string]_property{
 				"length": _property{
 					mode: 0,
@@ -5750,16 +5980,2403 @@ func _newContext(runtime *_runtime) {
 						value:      1,
 					},
 				},
+				"prototype": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueObject,
+						value:      runtime.Global.PromisePrototype,
+					},
+				},
+				"resolve": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      resolve_function,
+					},
+				},
+				"reject": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      reject_function,
+					},
+				},
+				"all": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      all_function,
+					},
+				},
+				"race": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      race_function,
+					},
+				},
+				"allSettled": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      allSettled_function,
+					},
+				},
 			},
 			propertyOrder: []string{
 				"length",
-			},
-			value: _functionObject{
-				call: _nativeCallFunction(builtinGlobal_escape),
+				"prototype",
+				"resolve",
+				"reject",
+				"all",
+				"race",
+				"allSettled",
 			},
 		}
-		unescape_function := &_object{
-			runtime:     runtime,
+		runtime.Global.PromisePrototype.property["constructor"] =
+			_property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Promise,
+				},
+			}
+	}
+	{
+		toString_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSymbol_toString),
+			},
+		}
+		valueOf_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSymbol_valueOf),
+			},
+		}
+		description_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSymbol_description),
+			},
+		}
+		toPrimitive_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSymbol_toPrimitive),
+			},
+		}
+		for_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSymbol_for),
+			},
+		}
+		keyFor_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSymbol_keyFor),
+			},
+		}
+		runtime.Global.SymbolPrototype = &_object{
+			runtime:     runtime,
+			class:       "Symbol",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"toString": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      toString_function,
+					},
+				},
+				"valueOf": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      valueOf_function,
+					},
+				},
+				"description": _property{
+					mode:  0201,
+					value: _propertyGetSet{description_function, nil},
+				},
+				symbolKeyToPrimitive: _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      toPrimitive_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Symbol",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"toString",
+				"valueOf",
+				"description",
+				symbolKeyToPrimitive,
+				symbolKeyToStringTag,
+			},
+		}
+		runtime.Global.Symbol = &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinSymbol),
+				construct: builtinNewSymbol,
+			},
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+				"prototype": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueObject,
+						value:      runtime.Global.SymbolPrototype,
+					},
+				},
+				"for": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      for_function,
+					},
+				},
+				"keyFor": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      keyFor_function,
+					},
+				},
+				"iterator": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueSymbol,
+						value:      symbolIterator,
+					},
+				},
+				"toPrimitive": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueSymbol,
+						value:      symbolToPrimitive,
+					},
+				},
+				"toStringTag": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueSymbol,
+						value:      symbolToStringTag,
+					},
+				},
+				"hasInstance": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueSymbol,
+						value:      symbolHasInstance,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+				"prototype",
+				"for",
+				"keyFor",
+				"iterator",
+				"toPrimitive",
+				"toStringTag",
+				"hasInstance",
+			},
+		}
+		runtime.Global.SymbolPrototype.property["constructor"] =
+			_property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Symbol,
+				},
+			}
+	}
+	{
+		get_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_get),
+			},
+		}
+		set_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_set),
+			},
+		}
+		has_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_has),
+			},
+		}
+		delete_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_delete),
+			},
+		}
+		clear_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_clear),
+			},
+		}
+		forEach_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_forEach),
+			},
+		}
+		keys_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_keys),
+			},
+		}
+		values_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_values),
+			},
+		}
+		entries_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_entries),
+			},
+		}
+		size_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMap_size),
+			},
+		}
+		runtime.Global.MapPrototype = &_object{
+			runtime:     runtime,
+			class:       "Map",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"get": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      get_function,
+					},
+				},
+				"set": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      set_function,
+					},
+				},
+				"has": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      has_function,
+					},
+				},
+				"delete": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      delete_function,
+					},
+				},
+				"clear": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      clear_function,
+					},
+				},
+				"forEach": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      forEach_function,
+					},
+				},
+				"keys": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      keys_function,
+					},
+				},
+				"values": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      values_function,
+					},
+				},
+				"entries": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      entries_function,
+					},
+				},
+				"size": _property{
+					mode:  0201,
+					value: _propertyGetSet{size_function, nil},
+				},
+				symbolKeyIterator: _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      entries_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Map",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"get",
+				"set",
+				"has",
+				"delete",
+				"clear",
+				"forEach",
+				"keys",
+				"values",
+				"entries",
+				"size",
+				symbolKeyIterator,
+				symbolKeyToStringTag,
+			},
+		}
+		runtime.Global.Map = &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinMap),
+				construct: builtinNewMap,
+			},
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+				"prototype": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueObject,
+						value:      runtime.Global.MapPrototype,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+				"prototype",
+			},
+		}
+		runtime.Global.MapPrototype.property["constructor"] =
+			_property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Map,
+				},
+			}
+	}
+	{
+		has_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_has),
+			},
+		}
+		add_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_add),
+			},
+		}
+		delete_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_delete),
+			},
+		}
+		clear_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_clear),
+			},
+		}
+		entries_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_entries),
+			},
+		}
+		forEach_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_forEach),
+			},
+		}
+		values_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_values),
+			},
+		}
+		size_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSet_size),
+			},
+		}
+		runtime.Global.SetPrototype = &_object{
+			runtime:     runtime,
+			class:       "Set",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"has": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      has_function,
+					},
+				},
+				"add": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      add_function,
+					},
+				},
+				"delete": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      delete_function,
+					},
+				},
+				"clear": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      clear_function,
+					},
+				},
+				"entries": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      entries_function,
+					},
+				},
+				"forEach": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      forEach_function,
+					},
+				},
+				"values": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      values_function,
+					},
+				},
+				"size": _property{
+					mode:  0201,
+					value: _propertyGetSet{size_function, nil},
+				},
+				"keys": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      values_function,
+					},
+				},
+				symbolKeyIterator: _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      values_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Set",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"has",
+				"add",
+				"delete",
+				"clear",
+				"entries",
+				"forEach",
+				"values",
+				"size",
+				"keys",
+				symbolKeyIterator,
+				symbolKeyToStringTag,
+			},
+		}
+		runtime.Global.Set = &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinSet),
+				construct: builtinNewSet,
+			},
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+				"prototype": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueObject,
+						value:      runtime.Global.SetPrototype,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+				"prototype",
+			},
+		}
+		runtime.Global.SetPrototype.property["constructor"] =
+			_property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Set,
+				},
+			}
+	}
+	{
+		get_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakMap_get),
+			},
+		}
+		set_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakMap_set),
+			},
+		}
+		has_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakMap_has),
+			},
+		}
+		delete_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakMap_delete),
+			},
+		}
+		runtime.Global.WeakMapPrototype = &_object{
+			runtime:     runtime,
+			class:       "WeakMap",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"get": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      get_function,
+					},
+				},
+				"set": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      set_function,
+					},
+				},
+				"has": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      has_function,
+					},
+				},
+				"delete": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      delete_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "WeakMap",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"get",
+				"set",
+				"has",
+				"delete",
+				symbolKeyToStringTag,
+			},
+		}
+		runtime.Global.WeakMap = &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinWeakMap),
+				construct: builtinNewWeakMap,
+			},
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+				"prototype": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueObject,
+						value:      runtime.Global.WeakMapPrototype,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+				"prototype",
+			},
+		}
+		runtime.Global.WeakMapPrototype.property["constructor"] =
+			_property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.WeakMap,
+				},
+			}
+	}
+	{
+		add_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakSet_add),
+			},
+		}
+		has_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakSet_has),
+			},
+		}
+		delete_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinWeakSet_delete),
+			},
+		}
+		runtime.Global.WeakSetPrototype = &_object{
+			runtime:     runtime,
+			class:       "WeakSet",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"add": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      add_function,
+					},
+				},
+				"has": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      has_function,
+					},
+				},
+				"delete": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      delete_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "WeakSet",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"add",
+				"has",
+				"delete",
+				symbolKeyToStringTag,
+			},
+		}
+		runtime.Global.WeakSet = &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinWeakSet),
+				construct: builtinNewWeakSet,
+			},
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+				"prototype": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueObject,
+						value:      runtime.Global.WeakSetPrototype,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+				"prototype",
+			},
+		}
+		runtime.Global.WeakSetPrototype.property["constructor"] =
+			_property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.WeakSet,
+				},
+			}
+	}
+	{
+		revocable_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinProxy_revocable),
+			},
+		}
+		runtime.Global.Proxy = &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			value: _functionObject{
+				call:      _nativeCallFunction(builtinProxy),
+				construct: builtinNewProxy,
+			},
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+				"revocable": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      revocable_function,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+				"revocable",
+			},
+		}
+	}
+	{
+		apply_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      3,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_apply),
+			},
+		}
+		construct_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_construct),
+			},
+		}
+		defineProperty_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      3,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_defineProperty),
+			},
+		}
+		deleteProperty_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_deleteProperty),
+			},
+		}
+		get_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_get),
+			},
+		}
+		getOwnPropertyDescriptor_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_getOwnPropertyDescriptor),
+			},
+		}
+		getPrototypeOf_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_getPrototypeOf),
+			},
+		}
+		has_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_has),
+			},
+		}
+		isExtensible_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_isExtensible),
+			},
+		}
+		ownKeys_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_ownKeys),
+			},
+		}
+		preventExtensions_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_preventExtensions),
+			},
+		}
+		set_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      3,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_set),
+			},
+		}
+		setPrototypeOf_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinReflect_setPrototypeOf),
+			},
+		}
+		runtime.Global.Reflect = &_object{
+			runtime:     runtime,
+			class:       "Reflect",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"apply": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      apply_function,
+					},
+				},
+				"construct": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      construct_function,
+					},
+				},
+				"defineProperty": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      defineProperty_function,
+					},
+				},
+				"deleteProperty": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      deleteProperty_function,
+					},
+				},
+				"get": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      get_function,
+					},
+				},
+				"getOwnPropertyDescriptor": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      getOwnPropertyDescriptor_function,
+					},
+				},
+				"getPrototypeOf": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      getPrototypeOf_function,
+					},
+				},
+				"has": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      has_function,
+					},
+				},
+				"isExtensible": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      isExtensible_function,
+					},
+				},
+				"ownKeys": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      ownKeys_function,
+					},
+				},
+				"preventExtensions": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      preventExtensions_function,
+					},
+				},
+				"set": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      set_function,
+					},
+				},
+				"setPrototypeOf": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      setPrototypeOf_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Reflect",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"apply",
+				"construct",
+				"defineProperty",
+				"deleteProperty",
+				"get",
+				"getOwnPropertyDescriptor",
+				"getPrototypeOf",
+				"has",
+				"isExtensible",
+				"ownKeys",
+				"preventExtensions",
+				"set",
+				"setPrototypeOf",
+				symbolKeyToStringTag,
+			},
+		}
+	}
+	{
+		iterator_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinIterator_iterator),
+			},
+		}
+		runtime.Global.IteratorPrototype = &_object{
+			runtime:     runtime,
+			class:       "Object",
+			objectClass: _classObject,
+			prototype:   runtime.Global.ObjectPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				symbolKeyIterator: _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      iterator_function,
+					},
+				},
+			},
+			propertyOrder: []string{
+				symbolKeyIterator,
+			},
+		}
+	}
+	{
+		next_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinArrayIterator_next),
+			},
+		}
+		runtime.Global.ArrayIteratorPrototype = &_object{
+			runtime:     runtime,
+			class:       "Object",
+			objectClass: _classObject,
+			prototype:   runtime.Global.IteratorPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"next": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      next_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Array Iterator",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"next",
+				symbolKeyToStringTag,
+			},
+		}
+	}
+	{
+		next_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinStringIterator_next),
+			},
+		}
+		runtime.Global.StringIteratorPrototype = &_object{
+			runtime:     runtime,
+			class:       "Object",
+			objectClass: _classObject,
+			prototype:   runtime.Global.IteratorPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"next": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      next_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "String Iterator",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"next",
+				symbolKeyToStringTag,
+			},
+		}
+	}
+	{
+		next_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinMapIterator_next),
+			},
+		}
+		runtime.Global.MapIteratorPrototype = &_object{
+			runtime:     runtime,
+			class:       "Object",
+			objectClass: _classObject,
+			prototype:   runtime.Global.IteratorPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"next": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      next_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Map Iterator",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"next",
+				symbolKeyToStringTag,
+			},
+		}
+	}
+	{
+		next_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      0,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinSetIterator_next),
+			},
+		}
+		runtime.Global.SetIteratorPrototype = &_object{
+			runtime:     runtime,
+			class:       "Object",
+			objectClass: _classObject,
+			prototype:   runtime.Global.IteratorPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"next": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      next_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Set Iterator",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"next",
+				symbolKeyToStringTag,
+			},
+		}
+	}
+	{
+		next_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGenerator_next),
+			},
+		}
+		return_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGenerator_return),
+			},
+		}
+		throw_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGenerator_throw),
+			},
+		}
+		runtime.Global.GeneratorPrototype = &_object{
+			runtime:     runtime,
+			class:       "Object",
+			objectClass: _classObject,
+			prototype:   runtime.Global.IteratorPrototype,
+			extensible:  true,
+			value:       nil,
+			property: map[string]_property{
+				"next": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      next_function,
+					},
+				},
+				"return": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      return_function,
+					},
+				},
+				"throw": _property{
+					mode: 0101,
+					value: Value{
+						_valueType: valueObject,
+						value:      throw_function,
+					},
+				},
+				symbolKeyToStringTag: _property{
+					mode: 0001,
+					value: Value{
+						_valueType: valueString,
+						value:      "Generator",
+					},
+				},
+			},
+			propertyOrder: []string{
+				"next",
+				"return",
+				"throw",
+				symbolKeyToStringTag,
+			},
+		}
+	}
+	{
+		eval_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_eval),
+			},
+		}
+		parseInt_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      2,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_parseInt),
+			},
+		}
+		parseFloat_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_parseFloat),
+			},
+		}
+		isNaN_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_isNaN),
+			},
+		}
+		isFinite_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_isFinite),
+			},
+		}
+		decodeURI_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_decodeURI),
+			},
+		}
+		decodeURIComponent_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_decodeURIComponent),
+			},
+		}
+		encodeURI_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_encodeURI),
+			},
+		}
+		encodeURIComponent_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_encodeURIComponent),
+			},
+		}
+		escape_function := &_object{
+			runtime:     runtime,
+			class:       "Function",
+			objectClass: _classObject,
+			prototype:   runtime.Global.FunctionPrototype,
+			extensible:  true,
+			property: map[string]_property{
+				"length": _property{
+					mode: 0,
+					value: Value{
+						_valueType: valueNumber,
+						value:      1,
+					},
+				},
+			},
+			propertyOrder: []string{
+				"length",
+			},
+			value: _functionObject{
+				call: _nativeCallFunction(builtinGlobal_escape),
+			},
+		}
+		unescape_function := &_object{
+			runtime:     runtime,
 			class:       "Function",
 			objectClass: _classObject,
 			prototype:   runtime.Global.FunctionPrototype,
@@ -5977,6 +8594,62 @@ func _newContext(runtime *_runtime) {
 					value:      runtime.Global.JSON,
 				},
 			},
+			"Promise": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Promise,
+				},
+			},
+			"Symbol": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Symbol,
+				},
+			},
+			"Map": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Map,
+				},
+			},
+			"Set": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Set,
+				},
+			},
+			"WeakMap": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.WeakMap,
+				},
+			},
+			"WeakSet": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.WeakSet,
+				},
+			},
+			"Proxy": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Proxy,
+				},
+			},
+			"Reflect": _property{
+				mode: 0101,
+				value: Value{
+					_valueType: valueObject,
+					value:      runtime.Global.Reflect,
+				},
+			},
 			"undefined": _property{
 				mode: 0,
 				value: Value{
@@ -6027,6 +8700,14 @@ func _newContext(runtime *_runtime) {
 			"SyntaxError",
 			"URIError",
 			"JSON",
+			"Promise",
+			"Symbol",
+			"Map",
+			"Set",
+			"WeakMap",
+			"WeakSet",
+			"Proxy",
+			"Reflect",
 			"undefined",
 			"NaN",
 			"Infinity",
@@ -6461,3 +9142,10 @@ func toValue_object(value *_object) Value {
 		value:      value,
 	}
 }
+
+func toValue_symbol(value _symbol) Value {
+	return Value{
+		_valueType: valueSymbol,
+		value:      value,
+	}
+}
@@ -0,0 +1,297 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestForOf(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = [];
+        for (var def of [ 1, 2, 3 ]) {
+            abc.push(def * 2);
+        }
+        [ abc, def ].join(";");
+    `, "2,4,6;3")
+
+	test(`
+        var abc = [];
+        for (var def of "a😀b") {
+            abc.push(def.length);
+        }
+        abc;
+    `, "1,2,1")
+
+	test(`
+        function abc() {
+            var def = [];
+            for (var ghi of arguments) {
+                def.push(ghi);
+            }
+            return def;
+        }
+        abc(1, 2, 3);
+    `, "1,2,3")
+
+	test(`
+        var abc = [];
+        for (let def of [ 1, 2, 3 ]) {
+            abc.push(function() { return def });
+        }
+        abc.map(function(def) { return def() });
+    `, "1,2,3")
+
+	test(`
+        var abc = [];
+        for (const [ def, ghi ] of [ [ 1, 2 ], [ 3, 4 ] ]) {
+            abc.push(def + ghi);
+        }
+        abc;
+    `, "3,7")
+
+	test(`
+        var abc = [];
+        for (var [ def, ghi ] of [ "x", "y" ].entries()) {
+            abc.push(def + ":" + ghi);
+        }
+        [ abc, [ ...[ "x", "y" ].keys() ] ].join(";");
+    `, "0:x,1:y;0,1")
+
+	test(`
+        var abc = [];
+        outer: for (var def of [ 1, 2, 3 ]) {
+            for (var ghi of [ 1, 2, 3 ]) {
+                if (ghi === 2) {
+                    continue outer;
+                }
+                if (def === 3) {
+                    break outer;
+                }
+                abc.push(def + "" + ghi);
+            }
+        }
+        abc;
+    `, "11,21")
+
+	test(`raise: for (var abc of 1) {}`, "TypeError: 1 is not iterable")
+}
+
+func TestIterator(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function Range(length) {
+            this.length = length;
+            this.closed = 0;
+        }
+        Range.prototype[Symbol.iterator] = function() {
+            var self = this, index = 0;
+            return {
+                next: function() {
+                    return index < self.length ? { value: index++, done: false } : { done: true };
+                },
+                return: function() {
+                    self.closed++;
+                    return {};
+                }
+            };
+        };
+        var abc = new Range(3);
+        var def = [ ...abc ];
+        for (var ghi of abc) {
+        }
+        [ def, abc.closed ].join(";");
+    `, "0,1,2;0")
+
+	test(`
+        for (var ghi of abc) {
+            break;
+        }
+        abc.closed;
+    `, "1")
+
+	test(`
+        try {
+            for (var ghi of abc) {
+                throw "xyzzy";
+            }
+        } catch (error) {
+        }
+        (function() {
+            for (var ghi of abc) {
+                return;
+            }
+        })();
+        var [ jkl ] = abc;
+        [ abc.closed, jkl ].join(",");
+    `, "4,0")
+
+	test(`
+        var abc = [ 1, 2 ][Symbol.iterator]();
+        [ abc.next().value, abc.next().value, abc.next().done, abc[Symbol.iterator]() === abc, Object.prototype.toString.call(abc) ].join(",");
+    `, "1,2,true,true,[object Array Iterator]")
+
+	test(`
+        var abc = "ab"[Symbol.iterator]();
+        [ abc.next().value, abc.next().value, abc.next().done ].join(",");
+    `, "a,b,true")
+
+	test(`raise: [ ...{} ]`, "TypeError: [object Object] is not iterable")
+
+	// A user-defined iterable, with a computed key and method shorthand
+	test(`
+        var abc = {
+            length: 3,
+            [Symbol.iterator]() {
+                var index = 0, length = this.length;
+                return {
+                    next() {
+                        return index < length ? { value: index++, done: false } : { done: true };
+                    },
+                };
+            },
+        };
+        var def = [];
+        for (var ghi of abc) {
+            def.push(ghi);
+        }
+        [ def, [ ...abc ], abc.propertyIsEnumerable(Symbol.iterator) ].join(";");
+    `, "0,1,2;0,1,2;true")
+
+	test(`
+        var abc = {
+            *[Symbol.iterator]() {
+                yield 1;
+                yield 2;
+            },
+        };
+        [ ...abc ];
+    `, "1,2")
+
+	test(`
+        class Abc {
+            constructor(...def) {
+                this.def = def;
+            }
+            *[Symbol.iterator]() {
+                yield* this.def;
+            }
+            static *[Symbol.iterator]() {
+                yield "static";
+            }
+        }
+        var [ ghi, jkl ] = new Abc(1, 2, 3);
+        [ ghi, jkl, [ ...Abc ], Object.getOwnPropertyDescriptor(Abc.prototype, Symbol.iterator).enumerable ].join(",");
+    `, "1,2,static,false")
+}
+
+func TestComputedKey(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = "def";
+        var ghi = 0;
+        var jkl = {
+            [abc]: 1,
+            [abc + "1"]: 2,
+            [++ghi + "x"]: "one",
+            get [abc + "2"]() {
+                return 3;
+            },
+            ["mno"]() {
+                return 4;
+            },
+        };
+        [ jkl.def, jkl.def1, jkl["1x"], jkl.def2, jkl.mno(), Object.keys(jkl) ].join(";");
+    `, "1;2;one;3;4;def,def1,1x,def2,mno")
+
+	// A later property (computed or not) of the same name replaces an earlier one
+	test(`
+        "use strict";
+        ({ abc: 1, ["abc"]: 2 }).abc;
+    `, "2")
+
+	test(`
+        var abc = Symbol("abc");
+        class Def {
+            [abc]() {
+                return "abc";
+            }
+            static get ["ghi"]() {
+                return "ghi";
+            }
+            ["constructor"]() {
+                return "constructor";
+            }
+        }
+        var jkl = new Def();
+        [ jkl[abc](), Def.ghi, jkl.constructor === Def, Def.prototype.hasOwnProperty("constructor") ].join(",");
+    `, "abc,ghi,false,true")
+
+	test(`
+        var abc = "def";
+        var { [abc]: ghi, ...jkl } = { def: 1, mno: 2 };
+        var pqr;
+        ({ [abc + "1"]: pqr } = { def1: 3 });
+        [ ghi, Object.keys(jkl), pqr ].join(",");
+    `, "1,mno,3")
+
+	// A method in an object literal
+	test(`
+        var abc = {
+            def() {
+                return "abc";
+            },
+        };
+        var ghi = {
+            def() {
+                return super.def() + ",ghi";
+            },
+            get: 1,
+            set() {
+                return "set";
+            },
+            async() {
+                return "async";
+            },
+        };
+        Reflect.setPrototypeOf(ghi, abc);
+        [ ghi.def(), ghi.get, ghi.set(), ghi.async(), ghi.def.hasOwnProperty("prototype") ].join(";");
+    `, "abc,ghi;1;set;async;false")
+
+	// The home object of a method (or accessor, or an arrow function in either) of an
+	// object literal is the object, so super is its prototype
+	test(`
+        var abc = {
+            get def() {
+                return super.toString === Object.prototype.toString;
+            },
+            ghi() {
+                return (() => super.hasOwnProperty("def"))();
+            },
+        };
+        [ abc.def, abc.ghi() ].join(",");
+    `, "true,true")
+
+	test(`raise: ({ abc() { return super.abc() } }).abc()`, "TypeError: super.abc is not a function")
+
+	test(`raise: ({ abc: function() { return super.abc() } })`, "SyntaxError: 'super' keyword unexpected here")
+
+	test(`raise: new ({ abc() {} }).abc()`, "TypeError: [function] is not a constructor")
+
+	test(`raise: ({ async *abc() {} })`, "SyntaxError: Async generator functions are not supported")
+
+	test(`raise:
+        class Abc {
+            static ["prototype"]() {}
+        }
+    `, "TypeError: Classes may not have a static property named 'prototype'")
+}
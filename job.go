@@ -0,0 +1,94 @@
+package otto
+
+// A job (e.g. a promise reaction) is run once the JavaScript currently running has
+// finished, that is, at the end of the outermost Run or Call.
+//
+// Jobs that come from another goroutine (e.g. a promise settled by Go, see NewPromise)
+// are queued separately, since the runtime itself is not safe for concurrent use.
+//
+// A promise that is rejected, and still has no handler once the jobs have been run, is
+// an error, as if its reason had been thrown (so Run, Call, or RunJobs return an *Error).
+
+// enqueueJob adds job to the end of the job queue
+func (self *_runtime) enqueueJob(job func()) {
+	self.jobQueue = append(self.jobQueue, job)
+}
+
+// enqueueAsyncJob adds job to the end of the job queue, and is safe to call
+// from any goroutine
+func (self *_runtime) enqueueAsyncJob(job func()) {
+	self.asyncJobLock.Lock()
+	defer self.asyncJobLock.Unlock()
+	self.asyncJobQueue = append(self.asyncJobQueue, job)
+}
+
+// runJobs runs jobs until the job queue is empty, unless JavaScript is still
+// running (e.g. Run is called from a Go function), in which case the jobs will
+// be run later on
+func (self *_runtime) runJobs() {
+	if self.callDepth > 0 || self.runningJobs {
+		return
+	}
+	self.runningJobs = true
+	defer func() {
+		self.runningJobs = false
+	}()
+
+	for {
+		self.asyncJobLock.Lock()
+		asyncJobQueue := self.asyncJobQueue
+		self.asyncJobQueue = nil
+		self.asyncJobLock.Unlock()
+		self.jobQueue = append(self.jobQueue, asyncJobQueue...)
+
+		if len(self.jobQueue) == 0 {
+			self.checkUnhandledRejections()
+			return
+		}
+		for len(self.jobQueue) > 0 {
+			job := self.jobQueue[0]
+			self.jobQueue[0] = nil
+			self.jobQueue = self.jobQueue[1:]
+			job()
+		}
+	}
+}
+
+// RunJobs runs any pending jobs (e.g. the reactions of a promise that was settled
+// by Go after the last Run or Call), returning an error if one is thrown.
+//
+// Run and Call will run pending jobs as well, once the JavaScript they run has finished.
+func (self Otto) RunJobs() error {
+	return catchPanic(self.runtime.runJobs)
+}
+
+// NewPromise returns a new pending promise, along with functions to resolve or
+// reject it, so that the result of some asynchronous Go (e.g. I/O) can be
+// exposed to JavaScript.
+//
+// The resolve and reject functions are safe to call from any goroutine. The
+// promise is settled (and its reactions run) the next time the jobs of the
+// runtime are run, by RunJobs or at the end of a Run or Call. Only the first
+// call to either function has any effect.
+//
+//	promise, resolve, _ := Otto.NewPromise()
+//	Otto.Set("result", promise)
+//	go func() {
+//		resolve(slowComputation())
+//	}()
+//	...
+//	Otto.RunJobs()
+func (self Otto) NewPromise() (promise Value, resolve func(interface{}), reject func(interface{})) {
+	runtime := self.runtime
+	object := runtime.newPromise()
+	resolveFunction, rejectFunction := runtime.newResolvingFunctions(object)
+
+	settle := func(function *_object) func(interface{}) {
+		return func(value interface{}) {
+			runtime.enqueueAsyncJob(func() {
+				runtime.Call(function, UndefinedValue(), []Value{runtime.toValue(value)}, false)
+			})
+		}
+	}
+	return toValue_object(object), settle(resolveFunction), settle(rejectFunction)
+}
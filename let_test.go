@@ -0,0 +1,231 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestLet(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = [];
+        let def = 1;
+        {
+            let def = 2;
+            abc.push(def);
+        }
+        abc.push(def);
+        if (true) {
+            let ghi = 3;
+        }
+        abc.push(typeof ghi);
+        abc;
+    `, "2,1,undefined")
+
+	// Not a property of the global object
+	test(`[ def, this.def ]`, "1,")
+
+	// The temporal dead zone
+	test(`raise:
+        {
+            jkl;
+            let jkl = 1;
+        }
+    `, "ReferenceError: Cannot access 'jkl' before initialization")
+
+	test(`raise:
+        {
+            jkl = 1;
+            let jkl;
+        }
+    `, "ReferenceError: Cannot access 'jkl' before initialization")
+
+	test(`raise:
+        {
+            typeof jkl;
+            let jkl;
+        }
+    `, "ReferenceError: Cannot access 'jkl' before initialization")
+
+	test(`
+        (function(){
+            function abc() {
+                return def;
+            }
+            try {
+                abc();
+            } catch (error) {
+                var ghi = error instanceof ReferenceError;
+            }
+            let def;
+            return [ ghi, abc() ];
+        })();
+    `, "true,")
+
+	// A function in a block can refer to the let and const of the block
+	test(`
+        (function(){
+            {
+                const abc = "xyzzy";
+                function def() {
+                    return abc;
+                }
+                return def();
+            }
+        })();
+    `, "xyzzy")
+
+	test(`
+        var abc = 0;
+        switch (abc) {
+        case 0:
+            let def = "zero";
+        case 1:
+            def += ",one";
+            abc = def;
+        }
+        abc;
+    `, "zero,one")
+
+	test(`
+        (function(){
+            let abc = 1;
+            return eval("let abc = 2; abc") + abc;
+        })();
+    `, "3")
+
+	test(`
+        let mno = "mno";
+        [ new Function("return mno")(), typeof eval("let pqr = 1; pqr"), typeof pqr ];
+    `, "mno,number,undefined")
+}
+
+func TestConst(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        const abc = 1, def = { ghi: 2 };
+        def.ghi += abc;
+        [ abc, def.ghi ];
+    `, "1,3")
+
+	test(`raise:
+        abc = 2;
+    `, "TypeError: Assignment to constant variable.")
+
+	test(`raise:
+        abc++;
+    `, "TypeError: Assignment to constant variable.")
+
+	test(`abc`, "1")
+
+	test(`
+        (function(){
+            const abc = 1;
+            try {
+                abc = 2;
+            } catch (error) {
+                return [ error instanceof TypeError, abc ];
+            }
+        })();
+    `, "true,1")
+
+	test(`raise: const xyzzy;`, "SyntaxError: Missing initializer in const declaration")
+}
+
+func TestLet_for(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	// Each iteration has its own binding
+	test(`
+        var abc = [];
+        for (let def = 0; def < 3; def++) {
+            abc.push(function(){ return def });
+        }
+        [ abc[0](), abc[1](), abc[2](), typeof def ];
+    `, "0,1,2,undefined")
+
+	test(`
+        var abc = [];
+        for (var def = 0; def < 3; def++) {
+            abc.push(function(){ return def });
+        }
+        [ abc[0](), abc[1](), abc[2]() ];
+    `, "3,3,3")
+
+	test(`
+        var abc = [];
+        for (let def = 0; def < 6; def++) {
+            if (def % 2) {
+                continue;
+            }
+            let ghi = def * 10;
+            abc.push(function(){ return def + ":" + ghi });
+            if (def == 4) {
+                break;
+            }
+        }
+        [ abc[0](), abc[1](), abc[2]() ];
+    `, "0:0,2:20,4:40")
+
+	test(`
+        var abc = [];
+        var def = { x: 1, y: 2 };
+        for (const ghi in def) {
+            abc.push(function(){ return ghi });
+        }
+        [ abc[0](), abc[1](), typeof ghi ];
+    `, "x,y,undefined")
+
+	test(`raise:
+        for (const abc = 0; abc < 1; abc++) {}
+    `, "TypeError: Assignment to constant variable.")
+
+	test(`
+        var abc = 0;
+        while (abc < 3) {
+            let def = abc++;
+        }
+        abc;
+    `, "3")
+}
+
+func TestLet_Go(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+	_, err := Otto.Run(`
+        let abc = 1;
+        const def = 2;
+        function ghi() {
+            return abc;
+        }
+    `)
+	Is(err, nil)
+	value, _ := Otto.Get("abc")
+	Is(value, "1")
+
+	err = Otto.Set("abc", 3)
+	Is(err, nil)
+	value, _ = Otto.Run(`ghi()`)
+	Is(value, "3")
+
+	err = Otto.Set("def", 4)
+	Is(err, "TypeError: Assignment to constant variable.")
+
+	// A copy has its own bindings
+	copy := Otto.Copy()
+	_, err = copy.Run(`abc = 5`)
+	Is(err, nil)
+	value, _ = copy.Run(`ghi()`)
+	Is(value, "5")
+	value, _ = Otto.Run(`ghi()`)
+	Is(value, "3")
+}
@@ -0,0 +1,147 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = new Map([ [ "a", 1 ], [ "b", 2 ] ]);
+        var def = {};
+        abc.set(def, 3).set(NaN, 4).set(-0, 5).set("a", 6);
+        [ abc.size, abc.get("a"), abc.get(def), abc.get({}), abc.get(NaN), abc.get(0), abc.has("b"), abc.has("c") ].join(",");
+    `, "5,6,3,,4,5,true,false")
+
+	test(`
+        [ [ ...abc.keys() ].map(String), [ ...abc.values() ], 1 / [ ...abc.keys() ][4] ].join(";");
+    `, "a,b,[object Object],NaN,0;6,2,3,4,5;Infinity")
+
+	test(`
+        var ghi = [];
+        for (var [ key, value ] of new Map([ [ 1, "x" ], [ 2, "y" ] ])) {
+            ghi.push(key + value);
+        }
+        ghi;
+    `, "1x,2y")
+
+	// Entries deleted (or added) during an iteration are skipped (or visited)
+	test(`
+        var abc = new Map([ [ 1, 1 ], [ 2, 2 ], [ 3, 3 ] ]);
+        var def = [];
+        abc.forEach(function(value, key, map) {
+            def.push(key);
+            if (key === 1) {
+                map.delete(2);
+                map.set(4, 4);
+            }
+        });
+        var ghi = abc.entries();
+        ghi.next();
+        abc.clear();
+        abc.set(5, 5);
+        [ def, abc.delete(5), abc.delete(5), ghi.next().done ].join(";");
+    `, "1,3,4;true;false;true")
+
+	test(`
+        var abc = new Map([ [ 1, 1 ], [ 2, 2 ] ]);
+        var def = abc[Symbol.iterator]();
+        def.next();
+        abc.delete(1);
+        abc.delete(2);
+        abc.set(3, 3);
+        [ def.next().value, def.next().done, abc[Symbol.iterator] === abc.entries, Object.prototype.toString.call(abc) ].join(";");
+    `, "3,3;true;true;[object Map]")
+
+	test(`raise: Map()`, "TypeError: Constructor Map requires 'new'")
+
+	test(`raise: new Map([ 1 ])`, "TypeError: Iterator value 1 is not an entry object")
+
+	test(`raise: Map.prototype.get.call({}, 1)`, "TypeError: Method Map.prototype.get called on incompatible receiver [object Object]")
+}
+
+func TestSet(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = new Set([ 1, 2, 2, "2", NaN, NaN ]);
+        abc.add(3).add(1);
+        [ abc.size, abc.has(2), abc.has("2"), abc.has(NaN), abc.has(4), [ ...abc ].join(":") ].join(",");
+    `, "5,true,true,true,false,1:2:2:NaN:3")
+
+	test(`
+        var def = [];
+        abc.forEach(function(value, key) {
+            def.push(value === key || value !== value);
+        });
+        [ def.join(":"), [ ...abc.entries() ][0], abc.keys === abc.values, abc[Symbol.iterator] === abc.values ].join(",");
+    `, "true:true:true:true:true,1,1,true,true")
+
+	test(`
+        [ abc.delete(2), abc.delete(2), abc.size, (abc.clear(), abc.size), Object.prototype.toString.call(abc) ].join(",");
+    `, "true,false,4,0,[object Set]")
+
+	test(`raise: Set.prototype.add.call(new Map(), 1)`, "TypeError: Method Set.prototype.add called on incompatible receiver [object Map]")
+}
+
+func TestWeakMap(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = {}, def = {};
+        var ghi = new WeakMap([ [ abc, 1 ] ]);
+        var jkl = new WeakMap();
+        ghi.set(def, 2);
+        jkl.set(abc, 3);
+        [ ghi.get(abc), ghi.get(def), jkl.get(abc), jkl.get(def), ghi.has(abc), ghi.get(1), ghi.delete(abc), ghi.has(abc), ghi.delete(abc), jkl.has(abc) ].join(",");
+    `, "1,2,3,,true,,true,false,false,true")
+
+	test(`
+        var abc = {};
+        var def = new WeakSet([ abc ]);
+        [ def.has(abc), def.has({}), def.has(1), def.delete(abc), def.has(abc), def.add(abc) === def, "size" in def ].join(",");
+    `, "true,false,false,true,false,true,false")
+
+	test(`raise: new WeakMap().set(1, 1)`, "TypeError: Invalid value used as weak map key")
+
+	test(`raise: new WeakSet().add("abc")`, "TypeError: Invalid value used in weak set")
+}
+
+func TestMapExport(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	{
+		value := test(`new Map([ [ "abc", 1 ], [ "def", [ true ] ] ])`).export().(map[string]interface{})
+		Is(len(value), 2)
+		Is(value["abc"], 1)
+		Is(value["def"].([]interface{})[0], true)
+	}
+	{
+		value := test(`new Map([ [ 1, "abc" ], [ "def", 2 ] ])`).export().(map[interface{}]interface{})
+		Is(len(value), 2)
+		Is(value[float64(1)], "abc")
+		Is(value["def"], 2)
+	}
+	{
+		value := test(`new Set([ "abc", 1, "abc" ])`).export().([]interface{})
+		Is(len(value), 2)
+		Is(value[0], "abc")
+		Is(value[1], 1)
+	}
+
+	// A Go function is passed a Map (as a map)
+	failSet("count", func(value map[string]interface{}) int {
+		return len(value)
+	})
+	test(`count(new Map([ [ "abc", 1 ], [ "def", 2 ] ]))`, "2")
+}
@@ -0,0 +1,76 @@
+package otto
+
+import (
+	runtime_ "runtime"
+)
+
+// MemoryLimitError is the error returned when a run exceeds the memory limit
+// of the runtime (see SetMemoryLimit).
+//
+// Like an interrupt, exceeding the memory limit cannot be caught by
+// JavaScript (try/catch).
+type MemoryLimitError struct {
+	Limit uint64 // The limit of the runtime, in bytes
+	Usage uint64 // The usage of the runtime (in bytes) before the failed allocation
+	Size  uint64 // The size of the failed allocation, in bytes
+}
+
+func (self *MemoryLimitError) Error() string {
+	return "otto: memory limit exceeded"
+}
+
+// The approximate cost, in bytes, of allocating an object or property
+const (
+	objectMemory   = 128 // The _object, and its (empty) property map
+	propertyMemory = 48  // An entry in the property map and order (excluding the name)
+
+	// The goroutine (and its stack) that the body of a generator, or of a call of an async
+	// function, runs on (see _generatorObject)
+	generatorMemory = 8 * 1024
+)
+
+// SetMemoryLimit limits the (approximate) memory used by the runtime to limit
+// bytes. A run that would exceed the limit is aborted, and a *MemoryLimitError
+// is returned.
+//
+// Memory is accounted as it is allocated by the runtime: for every object
+// (including arrays and functions), every property added to an object,
+// every string built by concatenation (+, concat, join, replace, JSON.stringify,
+// ...), and every generator (or call of an async function) that is suspended.
+// Memory is not given back when it is collected by the garbage collector, so
+// the usage is an upper bound, and the limit is best thought of as a budget
+// for the lifetime of the runtime. The exception is a generator, whose memory
+// is given back once its body completes (or is unwound, see Close).
+//
+// The builtin objects (Object, Array, Math, ...) are not counted.
+//
+// A limit of 0 means no limit (the default).
+func (self *Otto) SetMemoryLimit(limit uint64) {
+	self.runtime.memoryLimit = limit
+}
+
+// MemoryUsage returns the (approximate) memory allocated by the runtime so far, in bytes.
+func (self *Otto) MemoryUsage() uint64 {
+	return self.runtime.memoryUsage
+}
+
+// allocate accounts for size bytes of memory, and unwinds the runtime if that
+// would exceed the memory limit
+func (self *_runtime) allocate(size int) {
+	if self == nil {
+		return
+	}
+	if self.memoryLimit != 0 && self.memoryUsage+uint64(size) > self.memoryLimit && len(self.generators) > 0 {
+		// Rather than exceed the limit, collect (and unwind) any generator that is gone
+		runtime_.GC()
+		self.sweepGenerators()
+	}
+	if self.memoryLimit != 0 && self.memoryUsage+uint64(size) > self.memoryLimit {
+		panic(_interrupt{err: &MemoryLimitError{
+			Limit: self.memoryLimit,
+			Usage: self.memoryUsage,
+			Size:  uint64(size),
+		}})
+	}
+	self.memoryUsage += uint64(size)
+}
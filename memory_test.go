@@ -0,0 +1,119 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+
+	{
+		usage := Otto.MemoryUsage()
+		value, err := Otto.Run(`
+            var abc = { def: 1, ghi: [ 1, 2, 3 ] };
+            abc.jkl = "mno" + "pqr";
+            abc.jkl;
+        `)
+		Is(err, nil)
+		Is(value, "mnopqr")
+		Is(Otto.MemoryUsage() > usage, true)
+	}
+
+	{
+		_, err := Otto.Run(`
+            var stu = [];
+            while (true) {
+                try {
+                    stu.push("x");
+                } catch (error) {
+                    // Exceeding the limit cannot be caught
+                }
+            }
+        `)
+		Is(err, "otto: memory limit exceeded")
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+		Is(err.(*MemoryLimitError).Usage <= err.(*MemoryLimitError).Limit, true)
+	}
+
+	Otto = New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+	{
+		_, err := Otto.Run(`
+            var vwx = "x";
+            while (true) {
+                vwx = vwx + vwx;
+            }
+        `)
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+	}
+
+	Otto = New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+	{
+		_, err := Otto.Run("var vwx = 'x'; while (true) { vwx = `${vwx}${vwx}`; }")
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+	}
+
+	Otto = New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+	{
+		_, err := Otto.Run(`
+            var vwx = "x";
+            while (true) {
+                vwx = JSON.stringify([ vwx, vwx ]);
+            }
+        `)
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+	}
+
+	Otto = New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+	{
+		_, err := Otto.Run(`
+            var xyz = [ "x" ];
+            while (true) {
+                xyz = [ xyz.join(), xyz.join() ];
+            }
+        `)
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+
+		Otto.SetMemoryLimit(0)
+		value, err := Otto.Run(`xyz = {}; xyz.length = 1; xyz.length`)
+		Is(err, nil)
+		Is(value, "1")
+	}
+
+	Otto = New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+	{
+		_, err := Otto.Run("var vwx = 'x'; while (true) { vwx = String.raw({ raw: [ vwx, vwx ] }, vwx); }")
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+	}
+
+	// Each generator that is started is counted (until the runtime is closed)
+	Otto = New()
+	Otto.SetMemoryLimit(Otto.MemoryUsage() + 64*1024)
+	{
+		_, err := Otto.Run(`
+            function* abc() {
+                yield 1;
+            }
+            while (true) {
+                abc().next();
+            }
+        `)
+		_, valid := err.(*MemoryLimitError)
+		Is(valid, true)
+		Otto.Close()
+	}
+}
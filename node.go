@@ -5,13 +5,15 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+
+	"github.com/robertkrimen/otto/file"
 )
 
 type _node interface {
 	Type() _nodeType
 	String() string
-	setPosition(int)
-	position() int
+	setPosition(_position)
+	position() _position
 }
 
 type _nodeType int
@@ -22,15 +24,42 @@ func (self _nodeType) Type() _nodeType {
 
 type _node_ struct {
 	_nodeType
-	Line int // Line in the source
+	_position _position // Where the node is in the source
+}
+
+func (self *_node_) setPosition(position _position) {
+	self._position = position
+}
+
+func (self *_node_) position() _position {
+	return self._position
+}
+
+// _position is the span of a node in the source, from idx0 (inclusive) to idx1 (exclusive)
+type _position struct {
+	file *file.File
+	idx0 file.Idx
+	idx1 file.Idx
+}
+
+func (self _position) isValid() bool {
+	return self.file != nil && self.idx0 > 0
 }
 
-func (self *_node_) setPosition(Line int) {
-	self.Line = Line
+// start returns the (file) position of the start of the span, or nil if unknown
+func (self _position) start() *file.Position {
+	if !self.isValid() {
+		return nil
+	}
+	return self.file.Position(self.idx0)
 }
 
-func (self *_node_) position() int {
-	return self.Line
+// end returns the (file) position of the end of the span, or nil if unknown
+func (self _position) end() *file.Position {
+	if !self.isValid() {
+		return nil
+	}
+	return self.file.Position(self.idx1)
 }
 
 const (
@@ -61,9 +90,11 @@ const (
 	nodeThrow
 	nodeVariableDeclaration
 	nodeVariableDeclarationList
+	nodeLexicalDeclaration
 	nodeWith
 	nodeFor
 	nodeForIn
+	nodeForOf
 	nodeDotMember
 	nodeBracketMember
 
@@ -72,12 +103,25 @@ const (
 
 	nodeArray
 	nodeRegExp
+	nodeTemplate
+	nodeTemplateObject
+
+	nodeArrayPattern
+	nodeObjectPattern
+	nodeAssignmentPattern
+	nodeSpread
 
 	nodeNew
 
+	nodeClass
+	nodeSuper
+	nodeSuperCall
+
 	nodeValue
 	nodeThis
 	nodeComma
+	nodeYield
+	nodeAwait
 )
 
 // _labelSet
@@ -104,6 +148,15 @@ type _declaration struct {
 	Definition _node
 }
 
+// _lexicalDeclaration
+
+// _lexicalDeclaration is a let or const binding of a block, which is created
+// (uninitialized) on entry to the block, and initialized by the declaration itself
+type _lexicalDeclaration struct {
+	Name  string
+	Const bool
+}
+
 // _node*String
 
 func _fmtNodeSliceString(input interface{}) string {
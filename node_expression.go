@@ -37,7 +37,7 @@ func newAssignmentNode(assignment string, left _node, right _node) *_assignmentN
 	return &_assignmentNode{
 		_nodeType:  nodeAssignment,
 		Assignment: assignment,
-		Operator:   assignmentTable[assignment],
+		Operator:   assignment[:len(assignment)-1], // = => "", += => +, ...
 		Left:       left,
 		Right:      right,
 	}
@@ -87,6 +87,35 @@ func (self _callNode) String() string {
 	return fmtNodeString("{ <call> %s %s }", self.Callee, self.ArgumentList)
 }
 
+type _classNode struct {
+	_nodeType
+	_node_
+	Name        string // The name of the class, if any
+	SuperClass  _node  // nil, if the class does not extend another
+	Constructor *_functionNode
+	MethodList  []_classMethod
+}
+
+// _classMethod is a method, getter, or setter of a class
+type _classMethod struct {
+	Key      string
+	Computed _node  // The key, if it is computed (and Key is empty)
+	Kind     string // "method", "get", or "set"
+	Static   bool
+	Function *_functionNode
+}
+
+func newClassNode(name string) *_classNode {
+	return &_classNode{
+		_nodeType: nodeClass,
+		Name:      name,
+	}
+}
+
+func (self _classNode) String() string {
+	return fmtNodeString("{ <class> %s %s }", self.Name, self.Constructor)
+}
+
 type _commaNode struct {
 	_nodeType
 	_node_
@@ -148,12 +177,24 @@ func (self _conditionalNode) String() string {
 type _functionNode struct {
 	_nodeType
 	_node_
+	name                 string // The name of the function, if any (for the stack trace)
 	_declaration         bool
 	ParameterList        []string
+	ParameterPatternList []_node // The pattern (or default) of each parameter that is not just a name (nil, if all are)
+	Rest                 _node   // The rest parameter, e.g. ...abc (nil, if there is none)
+	Length               int     // The number of parameters before the first with a default (the length of the function)
 	Body                 []_node
 	VariableList         []_declaration
 	FunctionList         []_declaration
-	ArgumentsIsParameter bool // A hint that "arguments" exists as a parameter
+	LexicalList          []_lexicalDeclaration // The let and const declarations of the body
+	ArgumentsIsParameter bool                  // A hint that "arguments" exists as a parameter
+	strict               bool                  // The function is strict mode code
+	arrow                bool                  // An arrow function, which has the this and arguments of where it is defined
+	class                bool                  // The constructor of a class, which can only be called with new (or by super())
+	derived              bool                  // The constructor of a class that extends another, where this is bound by super()
+	implicit             bool                  // The default constructor of a class without a constructor of its own
+	generator            bool                  // A generator function, where a call returns a generator (that runs the body)
+	async                bool                  // An async function, where a call returns a promise (of the result of the body)
 }
 
 func newFunctionNode() *_functionNode {
@@ -270,19 +311,25 @@ func (self *_objectNode) String() string {
 type _objectPropertyNode struct {
 	_nodeType
 	_node_
-	Key   string
-	Value _node
+	Key      string
+	Computed _node  // The key, if it is computed (and Key is empty)
+	Kind     string // "value", "method", "get" or "set"
+	Value    _node  // A *_functionNode, unless Kind is "value"
 }
 
-func newObjectPropertyNode(key string, value _node) *_objectPropertyNode {
+func newObjectPropertyNode(key string, kind string, value _node) *_objectPropertyNode {
 	return &_objectPropertyNode{
 		_nodeType: nodeObjectProperty,
 		Key:       key,
+		Kind:      kind,
 		Value:     value,
 	}
 }
 
 func (self *_objectPropertyNode) String() string {
+	if self.Kind != "value" {
+		return fmtNodeString("{ %s %s: %s }", self.Kind, self.Key, self.Value)
+	}
 	return fmtNodeString("{ %s: %s }", self.Key, self.Value)
 }
 
@@ -305,6 +352,162 @@ func (self *_regExpNode) String() string {
 	return fmtNodeString("{ /%s/%s }", self.Pattern, self.Flags)
 }
 
+// _templateNode is an (untagged) template literal, e.g. `abc ${def} ghi`
+type _templateNode struct {
+	_nodeType
+	_node_
+	Cooked      []string
+	Expressions []_node
+}
+
+func newTemplateNode(cooked []string, expressions []_node) *_templateNode {
+	return &_templateNode{
+		_nodeType:   nodeTemplate,
+		Cooked:      cooked,
+		Expressions: expressions,
+	}
+}
+
+func (self *_templateNode) String() string {
+	return fmtNodeString("{ <template> %s %s }", self.Cooked, self.Expressions)
+}
+
+// _templateObjectNode is the strings array (with its raw property) passed as
+// the first argument to the tag of a tagged template
+type _templateObjectNode struct {
+	_nodeType
+	_node_
+	Cooked []string
+	Raw    []string
+}
+
+func newTemplateObjectNode(cooked []string, raw []string) *_templateObjectNode {
+	return &_templateObjectNode{
+		_nodeType: nodeTemplateObject,
+		Cooked:    cooked,
+		Raw:       raw,
+	}
+}
+
+func (self *_templateObjectNode) String() string {
+	return fmtNodeString("{ <template-object> %s }", self.Cooked)
+}
+
+// _arrayPatternNode is an array destructuring pattern, e.g. [ abc, , def = 1, ...ghi ]
+// (see _runtime.destructure)
+type _arrayPatternNode struct {
+	_nodeType
+	_node_
+	ElementList []_node // nil for a hole
+	Rest        _node   // nil, if there is no rest element
+}
+
+func newArrayPatternNode() *_arrayPatternNode {
+	return &_arrayPatternNode{
+		_nodeType: nodeArrayPattern,
+	}
+}
+
+func (self *_arrayPatternNode) String() string {
+	return fmtNodeString("{ <array-pattern> %s ...%s }", self.ElementList, self.Rest)
+}
+
+// _objectPatternNode is an object destructuring pattern, e.g. { abc, def: ghi = 1, ...jkl }
+type _objectPatternNode struct {
+	_nodeType
+	_node_
+	PropertyList []_patternProperty
+	Rest         _node // nil, if there is no rest element
+}
+
+type _patternProperty struct {
+	Key      string
+	Computed _node // The key, if it is computed (and Key is empty)
+	Target   _node
+}
+
+func newObjectPatternNode() *_objectPatternNode {
+	return &_objectPatternNode{
+		_nodeType: nodeObjectPattern,
+	}
+}
+
+func (self *_objectPatternNode) String() string {
+	return fmtNodeString("{ <object-pattern> %s ...%s }", self.PropertyList, self.Rest)
+}
+
+// _assignmentPatternNode is a target with a default (in a pattern), e.g. abc = 1
+type _assignmentPatternNode struct {
+	_nodeType
+	_node_
+	Target      _node
+	Initializer _node
+}
+
+func newAssignmentPatternNode(target _node, initializer _node) *_assignmentPatternNode {
+	return &_assignmentPatternNode{
+		_nodeType:   nodeAssignmentPattern,
+		Target:      target,
+		Initializer: initializer,
+	}
+}
+
+func (self *_assignmentPatternNode) String() string {
+	return fmtNodeString("{ %s = %s }", self.Target, self.Initializer)
+}
+
+// _spreadNode is ...abc in an array literal, or the arguments of a call
+type _spreadNode struct {
+	_nodeType
+	_node_
+	Argument _node
+}
+
+func newSpreadNode(argument _node) *_spreadNode {
+	return &_spreadNode{
+		_nodeType: nodeSpread,
+		Argument:  argument,
+	}
+}
+
+func (self *_spreadNode) String() string {
+	return fmtNodeString("{ ...%s }", self.Argument)
+}
+
+// _superNode is super, which is only ever the target of a dot or bracket member (super.abc)
+type _superNode struct {
+	_nodeType
+	_node_
+}
+
+func newSuperNode() *_superNode {
+	return &_superNode{
+		_nodeType: nodeSuper,
+	}
+}
+
+func (self _superNode) String() string {
+	return "{ <super> }"
+}
+
+// _superCallNode is a call of the constructor of the class that a class extends, super(abc)
+type _superCallNode struct {
+	_nodeType
+	_node_
+	ArgumentList []_node
+}
+
+func newSuperCallNode() *_superCallNode {
+	return &_superCallNode{
+		_nodeType:    nodeSuperCall,
+		ArgumentList: []_node{},
+	}
+}
+
+func (self _superCallNode) String() string {
+	return fmtNodeString("{ <super> %s }", self.ArgumentList)
+}
+
 type _thisNode struct {
 	_nodeType
 	_node_
@@ -422,3 +625,44 @@ func (self *_valueNode) String() string {
 	}
 	return fmt.Sprintf("%s", self.Text)
 }
+
+// _yieldNode is yield (or yield*) in the body of a generator function
+type _yieldNode struct {
+	_nodeType
+	_node_
+	Argument _node // nil, if there is no argument
+	Delegate bool  // yield*, which yields each value of the argument (an iterable)
+}
+
+func newYieldNode(argument _node, delegate bool) *_yieldNode {
+	return &_yieldNode{
+		_nodeType: nodeYield,
+		Argument:  argument,
+		Delegate:  delegate,
+	}
+}
+
+func (self *_yieldNode) String() string {
+	if self.Delegate {
+		return fmtNodeString("{ yield* %s }", self.Argument)
+	}
+	return fmtNodeString("{ yield %s }", self.Argument)
+}
+
+// _awaitNode is await in the body of an async function
+type _awaitNode struct {
+	_nodeType
+	_node_
+	Argument _node
+}
+
+func newAwaitNode(argument _node) *_awaitNode {
+	return &_awaitNode{
+		_nodeType: nodeAwait,
+		Argument:  argument,
+	}
+}
+
+func (self *_awaitNode) String() string {
+	return fmtNodeString("{ await %s }", self.Argument)
+}
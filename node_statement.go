@@ -7,8 +7,10 @@ import (
 type _blockNode struct {
 	_nodeType
 	_node_
-	Body     []_node
-	labelSet _labelSet
+	Body         []_node
+	LexicalList  []_lexicalDeclaration // If not empty, the block has its own (declarative) environment
+	FunctionList []_declaration        // The function declarations of a block with its own environment
+	labelSet     _labelSet
 }
 
 func newBlockNode() *_blockNode {
@@ -113,10 +115,11 @@ type _forNode struct {
 	_nodeType
 	_node_
 	_iteratorNode
-	Initial  _node
-	Test     _node
-	Update   _node
-	labelSet _labelSet
+	Initial     _node
+	Test        _node
+	Update      _node
+	LexicalList []_lexicalDeclaration // The let or const declarations of Initial, copied for each iteration
+	labelSet    _labelSet
 }
 
 func newForNode(initial _node, test _node, update _node, body []_node) *_forNode {
@@ -147,9 +150,10 @@ type _forInNode struct {
 	_nodeType
 	_node_
 	_iteratorNode
-	Into     _node
-	Source   _node
-	labelSet _labelSet
+	Into        _node
+	Source      _node
+	LexicalList []_lexicalDeclaration // The let or const declaration of Into, created for each iteration
+	labelSet    _labelSet
 }
 
 func newForInNode(into _node, source _node, body []_node) *_forInNode {
@@ -174,6 +178,38 @@ func (self _forInNode) String() string {
 	)
 }
 
+type _forOfNode struct {
+	_nodeType
+	_node_
+	_iteratorNode
+	Into        _node
+	Source      _node
+	LexicalList []_lexicalDeclaration // As for _forInNode
+	labelSet    _labelSet
+}
+
+func newForOfNode(into _node, source _node, body []_node) *_forOfNode {
+	self := &_forOfNode{
+		_nodeType: nodeForOf,
+		Into:      into,
+		Source:    source,
+		_iteratorNode: _iteratorNode{
+			body: body,
+		},
+		labelSet: _labelSet{},
+	}
+	return self
+}
+
+func (self _forOfNode) String() string {
+
+	return fmtNodeString("{ <%s> %s of %s %s }", self.labelSet.label("for-of"),
+		self.Into,
+		self.Source,
+		self._iteratorNode,
+	)
+}
+
 type _whileNode struct {
 	_nodeType
 	_node_
@@ -235,6 +271,8 @@ type _programNode struct {
 	Body         []_node
 	VariableList []_declaration
 	FunctionList []_declaration
+	LexicalList  []_lexicalDeclaration
+	strict       bool // The program begins with a "use strict" directive
 }
 
 func newProgramNode() *_programNode {
@@ -247,15 +285,6 @@ func (self _programNode) String() string {
 	return fmtNodeString("{ @ %s }", self.Body)
 }
 
-func (self _programNode) toFunction(parameterList []string) *_functionNode {
-	node := newFunctionNode()
-	node.ParameterList = parameterList
-	node.Body = self.Body
-	node.VariableList = self.VariableList
-	node.FunctionList = self.FunctionList
-	return node
-}
-
 type _returnNode struct {
 	_nodeType
 	_node_
@@ -281,6 +310,7 @@ type _switchNode struct {
 	Discriminant _node
 	Default      int
 	CaseList     [](*_caseNode)
+	LexicalList  []_lexicalDeclaration // The clauses (together) are a block
 	labelSet     _labelSet
 }
 
@@ -380,6 +410,7 @@ type _catchNode struct {
 	_nodeType
 	_node_
 	Identifier string
+	Pattern    _node // The pattern of a destructured parameter, e.g. catch ({ message })
 	Body       *_blockNode
 }
 
@@ -415,6 +446,7 @@ type _variableDeclarationNode struct {
 	_nodeType
 	_node_
 	Identifier  string
+	Pattern     _node // The pattern of a destructuring declaration (instead of an Identifier)
 	Operator    string
 	Initializer _node
 }
@@ -433,6 +465,27 @@ func (self _variableDeclarationNode) String() string {
 	return fmtNodeString("{ <var> %s }", self.Identifier)
 }
 
+type _lexicalDeclarationNode struct {
+	_nodeType
+	_node_
+	Const        bool
+	VariableList []*_variableDeclarationNode
+}
+
+func newLexicalDeclarationNode(constant bool) *_lexicalDeclarationNode {
+	return &_lexicalDeclarationNode{
+		_nodeType: nodeLexicalDeclaration,
+		Const:     constant,
+	}
+}
+
+func (self _lexicalDeclarationNode) String() string {
+	if self.Const {
+		return fmtNodeString("{ <const> %s }", self.VariableList)
+	}
+	return fmtNodeString("{ <let> %s }", self.VariableList)
+}
+
 type _withNode struct {
 	_nodeType
 	_node_
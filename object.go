@@ -12,9 +12,14 @@ type _object struct {
 
 	property      map[string]_property
 	propertyOrder []string
+
+	// weakValue has the value of this object (as a key) in each WeakMap (or
+	// WeakSet) that has it, by the WeakMap
+	weakValue map[*_object]Value
 }
 
 func newObject(runtime *_runtime, class string) *_object {
+	runtime.allocate(objectMemory)
 	self := &_object{
 		runtime:     runtime,
 		class:       class,
@@ -52,6 +57,88 @@ func (self *_object) put(name string, value Value, throw bool) {
 	self.objectClass.put(self, name, value, throw)
 }
 
+// getFor gets the property name of self (as [[Get]]), where receiver is the object
+// that the property is got for: a getter is called with receiver as this, and a proxy
+// in the prototype chain is passed receiver by its get trap
+func (self *_object) getFor(name string, receiver Value) Value {
+	if proxy := self.proxyValue(); proxy != nil {
+		return proxy.get(name, receiver)
+	}
+	if receiver._object() == self {
+		return self.get(name)
+	}
+	property := self.getOwnProperty(name)
+	if property == nil {
+		if self.prototype != nil {
+			return self.prototype.getFor(name, receiver)
+		}
+		return UndefinedValue()
+	}
+	if getSet, isAccessor := property.value.(_propertyGetSet); isAccessor {
+		if getSet[0] == nil {
+			return UndefinedValue()
+		}
+		return getSet[0].callGet(receiver)
+	}
+	return property.get(self)
+}
+
+// setFor sets the property name of self (as [[Set]]), returning whether it was set,
+// where receiver is the object that the property is set for: a setter is called with
+// receiver as this, a data property is defined on receiver, and a proxy in the
+// prototype chain is passed receiver by its set trap
+func (self *_object) setFor(name string, value Value, receiver Value) bool {
+	if object, isGoStruct := self.value.(*_goStructObject); isGoStruct && receiver._object() == self {
+		if object.setValue(name, value) {
+			return true
+		}
+	}
+	if proxy := self.proxyValue(); proxy != nil {
+		return proxy.set(name, value, receiver)
+	}
+	property := self.getOwnProperty(name)
+	if property == nil {
+		if self.prototype != nil {
+			return self.prototype.setFor(name, value, receiver)
+		}
+	} else {
+		switch propertyValue := property.value.(type) {
+		case Value:
+			if !property.writable() {
+				return false
+			}
+		case _propertyGetSet:
+			if propertyValue[1] == nil {
+				return false
+			}
+			propertyValue[1].callSet(receiver, value)
+			return true
+		default:
+			panic(newTypeError())
+		}
+	}
+
+	object := receiver._object()
+	if object == nil {
+		return false
+	}
+	if object == self {
+		if property != nil {
+			property.value = value
+			return self.defineOwnProperty(name, *property, false)
+		}
+		return self.defineProperty(name, value, 0111, false)
+	}
+	if property := object.getOwnProperty(name); property != nil {
+		if _, isAccessor := property.value.(_propertyGetSet); isAccessor || !property.writable() {
+			return false
+		}
+		// Only the value of the property of receiver is changed
+		return object.defineOwnProperty(name, _property{value, modeSetMask}, false)
+	}
+	return object.defineProperty(name, value, 0111, false)
+}
+
 // 8.12.6
 func (self *_object) hasProperty(name string) bool {
 	return self.objectClass.hasProperty(self, name)
@@ -71,6 +158,24 @@ const (
 
 // 8.12.8
 func (self *_object) DefaultValue(hint _defaultValueHint) Value {
+	if toPrimitive := self.get(symbolKeyToPrimitive); toPrimitive.IsDefined() && !toPrimitive.IsNull() {
+		// The object has a Symbol.toPrimitive method of its own (or inherited)
+		if !toPrimitive.isCallable() {
+			panic(newTypeError("Symbol.toPrimitive is not a function"))
+		}
+		hintName := "default"
+		switch hint {
+		case defaultValueHintString:
+			hintName = "string"
+		case defaultValueHintNumber:
+			hintName = "number"
+		}
+		result := toPrimitive._object().Call(toValue_object(self), hintName)
+		if !result.IsPrimitive() {
+			panic(newTypeError("Cannot convert object to primitive value"))
+		}
+		return result
+	}
 	if hint == defaultValueNoHint {
 		if self.class == "Date" {
 			// Date exception
@@ -118,6 +223,44 @@ func (self *_object) enumerate(all bool, each func(string) bool) {
 	self.objectClass.enumerate(self, all, each)
 }
 
+func (self *_object) getPrototypeOf() *_object {
+	return self.objectClass.getPrototypeOf(self)
+}
+
+func (self *_object) setPrototypeOf(prototype *_object) bool {
+	return self.objectClass.setPrototypeOf(self, prototype)
+}
+
+func (self *_object) isExtensible() bool {
+	return self.objectClass.isExtensible(self)
+}
+
+func (self *_object) preventExtensions() bool {
+	return self.objectClass.preventExtensions(self)
+}
+
+// freeze makes every property of the object read-only and non-configurable, and
+// the object non-extensible (like Object.freeze)
+func (self *_object) freeze() {
+	self.enumerate(true, func(name string) bool {
+		if property, update := self.getOwnProperty(name), false; nil != property {
+			if property.isDataDescriptor() && property.writable() {
+				property.writeOff()
+				update = true
+			}
+			if property.configurable() {
+				property.configureOff()
+				update = true
+			}
+			if update {
+				self.defineOwnProperty(name, *property, true)
+			}
+		}
+		return true
+	})
+	self.preventExtensions()
+}
+
 func (self *_object) _exists(name string) bool {
 	_, exists := self.property[name]
 	return exists
@@ -133,6 +276,9 @@ func (self *_object) _write(name string, value interface{}, mode _propertyMode)
 		value = UndefinedValue()
 	}
 	_, exists := self.property[name]
+	if !exists {
+		self.runtime.allocate(propertyMemory + len(name))
+	}
 	self.property[name] = _property{value, mode}
 	if !exists {
 		self.propertyOrder = append(self.propertyOrder, name)
@@ -12,6 +12,10 @@ type _objectClass struct {
 	delete            func(*_object, string, bool) bool
 	enumerate         func(*_object, bool, func(string) bool)
 	clone             func(*_object, *_object, *_clone) *_object
+	getPrototypeOf    func(*_object) *_object
+	setPrototypeOf    func(*_object, *_object) bool
+	isExtensible      func(*_object) bool
+	preventExtensions func(*_object) bool
 }
 
 func objectEnumerate(self *_object, all bool, each func(string) bool) {
@@ -33,6 +37,7 @@ var (
 	_classGoMap,
 	_classGoArray,
 	_classGoSlice,
+	_classProxy,
 	_ *_objectClass
 )
 
@@ -49,6 +54,10 @@ func init() {
 		objectDelete,
 		objectEnumerate,
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classArray = &_objectClass{
@@ -63,6 +72,10 @@ func init() {
 		objectDelete,
 		objectEnumerate,
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classString = &_objectClass{
@@ -78,6 +91,10 @@ func init() {
 		stringEnumerate,
 		objectClone,
 		//stringClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classArguments = &_objectClass{
@@ -94,6 +111,10 @@ func init() {
 		objectEnumerate,
 		//argumentsClone
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classGoStruct = &_objectClass{
@@ -108,6 +129,10 @@ func init() {
 		objectDelete,
 		goStructEnumerate,
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classGoMap = &_objectClass{
@@ -122,6 +147,10 @@ func init() {
 		goMapDelete,
 		goMapEnumerate,
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classGoArray = &_objectClass{
@@ -136,6 +165,10 @@ func init() {
 		goArrayDelete,
 		goArrayEnumerate,
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
 	}
 
 	_classGoSlice = &_objectClass{
@@ -150,6 +183,28 @@ func init() {
 		goSliceDelete,
 		goSliceEnumerate,
 		objectClone,
+		objectGetPrototypeOf,
+		objectSetPrototypeOf,
+		objectIsExtensible,
+		objectPreventExtensions,
+	}
+
+	_classProxy = &_objectClass{
+		proxyGetOwnProperty,
+		proxyGetProperty,
+		proxyGet,
+		proxyCanPut,
+		proxyPut,
+		proxyHasProperty,
+		objectHasOwnProperty,
+		proxyDefineOwnProperty,
+		proxyDelete,
+		proxyEnumerate,
+		objectClone,
+		proxyGetPrototypeOf,
+		proxySetPrototypeOf,
+		proxyIsExtensible,
+		proxyPreventExtensions,
 	}
 }
 
@@ -179,10 +234,14 @@ func objectGetProperty(self *_object, name string) *_property {
 
 // 8.12.3
 func objectGet(self *_object, name string) Value {
-	property := self.getProperty(name)
+	property := self.getOwnProperty(name)
 	if property != nil {
 		return property.get(self)
 	}
+	if self.prototype != nil {
+		// An inherited property is got for self (see getFor)
+		return self.prototype.getFor(name, toValue_object(self))
+	}
 	return UndefinedValue()
 }
 
@@ -236,70 +295,21 @@ func _objectCanPut(self *_object, name string) (canPut bool, property *_property
 
 // 8.12.5
 func objectPut(self *_object, name string, value Value, throw bool) {
-
-	if true {
-		// Shortcut...
-		//
-		// So, right now, every class is using objectCanPut and every class
-		// is using objectPut.
-		//
-		// If that were to no longer be the case, we would have to have
-		// something to detect that here, so that we do not use an
-		// incompatible canPut routine
-		canPut, property, setter := _objectCanPut(self, name)
-		if !canPut {
-			typeErrorResult(throw)
-		} else if setter != nil {
-			setter.callSet(toValue(self), value)
-		} else if property != nil {
-			property.value = value
-			self.defineOwnProperty(name, *property, throw)
-		} else {
-			self.defineProperty(name, value, 0111, throw)
-		}
-		return
-	}
-
-	// The long way...
-	//
-	// Right now, code should never get here, see above
-	if !self.canPut(name) {
+	if !self.setFor(name, value, toValue_object(self)) {
 		typeErrorResult(throw)
-		return
-	}
-
-	property := self.getOwnProperty(name)
-	if property == nil {
-		property = self.getProperty(name)
-		if property != nil {
-			if getSet, isAccessor := property.value.(_propertyGetSet); isAccessor {
-				getSet[1].callSet(toValue(self), value)
-				return
-			}
-		}
-		self.defineProperty(name, value, 0111, throw)
-	} else {
-		switch propertyValue := property.value.(type) {
-		case Value:
-			property.value = value
-			self.defineOwnProperty(name, *property, throw)
-		case _propertyGetSet:
-			if propertyValue[1] != nil {
-				propertyValue[1].callSet(toValue(self), value)
-				return
-			}
-			if throw {
-				panic(newTypeError())
-			}
-		default:
-			panic(newTypeError())
-		}
 	}
 }
 
 // 8.12.6
 func objectHasProperty(self *_object, name string) bool {
-	return self.getProperty(name) != nil
+	if self.getOwnProperty(name) != nil {
+		return true
+	}
+	if self.prototype != nil {
+		// A proxy in the prototype chain is asked by its has trap
+		return self.prototype.hasProperty(name)
+	}
+	return false
 }
 
 func objectHasOwnProperty(self *_object, name string) bool {
@@ -462,13 +472,69 @@ func objectClone(self0 *_object, self1 *_object, clone *_clone) *_object {
 	for index, property := range self0.property {
 		self1.property[index] = clone.property(property)
 	}
+	if self0.weakValue != nil {
+		self1.weakValue = make(map[*_object]Value, len(self0.weakValue))
+		for weakMap, value := range self0.weakValue {
+			self1.weakValue[clone.object(weakMap)] = clone.value(value)
+		}
+	}
 
 	switch value := self0.value.(type) {
 	case _functionObject:
 		self1.value = value.clone(clone)
 	case _argumentsObject:
 		self1.value = value.clone(clone)
+	case *_promiseObject:
+		self1.value = value.clone(clone)
+	case *_arrayIteratorObject:
+		self1.value = value.clone(clone)
+	case *_stringIteratorObject:
+		self1.value = value.clone(clone)
+	case *_mapObject:
+		self1.value = value.clone(clone)
+	case *_mapIteratorObject:
+		self1.value = value.clone(clone)
+	case *_generatorObject:
+		self1.value = value.clone(clone)
+	case *_proxyObject:
+		self1.value = value.clone(clone)
 	}
 
 	return self1
 }
+
+func objectGetPrototypeOf(self *_object) *_object {
+	return self.prototype
+}
+
+// objectSetPrototypeOf sets the prototype of the object, returning false (rather
+// than throwing) if it cannot be set, as the object is not extensible, or the
+// prototype would be in a cycle
+func objectSetPrototypeOf(self *_object, prototype *_object) bool {
+	if prototype == self.prototype {
+		return true
+	}
+	if !self.extensible {
+		return false
+	}
+	for object := prototype; object != nil; object = object.prototype {
+		if object == self {
+			return false
+		}
+		if object.objectClass == _classProxy {
+			// The prototype of a proxy is up to its handler
+			break
+		}
+	}
+	self.prototype = prototype
+	return true
+}
+
+func objectIsExtensible(self *_object) bool {
+	return self.extensible
+}
+
+func objectPreventExtensions(self *_object) bool {
+	self.extensible = false
+	return true
+}
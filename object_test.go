@@ -607,6 +607,68 @@ func TestObjectGetterSetter(t *testing.T) {
 
 }
 
+func TestObjectLiteralGetterSetter(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = {
+            def: 1,
+            get ghi() {
+                return this.def * 2;
+            },
+            set ghi(value) {
+                this.def = value;
+            }
+        };
+        abc.ghi = 3;
+        [ abc.def, abc.ghi ];
+    `, "3,6")
+
+	test(`
+        var jkl = Object.getOwnPropertyDescriptor(abc, "ghi");
+        [ typeof jkl.get, typeof jkl.set, jkl.enumerable, jkl.configurable, "value" in jkl, "writable" in jkl ];
+    `, "function,function,true,true,false,false")
+
+	test(`
+        var mno = [];
+        for (var pqr in abc) {
+            mno.push(pqr);
+        }
+        [ mno, JSON.stringify(abc), Object.keys(abc) ];
+    `, `def,ghi,{"def":3,"ghi":6},def,ghi`)
+
+	// A getter without a setter
+	test(`
+        var stu = { get vwx() { return 1; } };
+        stu.vwx = 2;
+        stu.vwx;
+    `, "1")
+
+	test(`raise:
+        (function(){
+            "use strict";
+            stu.vwx = 2;
+        })();
+    `, "TypeError")
+
+	// Inherited, and redefined
+	test(`
+        var xyz = Object.create({ set abc(value) { this._abc = value; } });
+        xyz.abc = 1;
+        Object.defineProperty(xyz, "def", { get: function() { return 2; }, configurable: true });
+        Object.defineProperty(xyz, "def", { value: 3 });
+        [ xyz._abc, xyz.hasOwnProperty("abc"), xyz.def ];
+    `, "1,false,3")
+
+	// get and set are still valid property names
+	test(`
+        var get = { get: 1, set: 2, get if() { return 3; } };
+        [ get.get, get.set, get["if"] ];
+    `, "1,2,3")
+}
+
 func TestProperty(t *testing.T) {
 	Terst(t)
 
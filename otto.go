@@ -3,8 +3,9 @@ Package otto is a JavaScript parser and interpreter written natively in Go.
 
 http://godoc.org/github.com/robertkrimen/otto
 
-	// Create a new runtime
+	// Create a new runtime, which is closed once it is no longer needed (see below)
 	Otto := otto.New()
+	defer Otto.Close()
 
 	Otto.Run(`
 		abc = 2 + 2
@@ -63,6 +64,19 @@ Embedding a Go function in JavaScript:
 		result = twoPlus(2.0) // 4
 	`)
 
+Closing a runtime
+
+The body of a generator (or of a call of an async function) that is suspended
+runs on a goroutine of its own. The runtime unwinds the body of a generator
+that has been collected by the garbage collector, but a generator that is
+reachable from its own body (e.g. a generator kept in a variable of the
+function that declares the generator function) is never collected. Close
+unwinds every suspended body, so a runtime should be closed once it is no
+longer needed:
+
+	Otto := otto.New()
+	defer Otto.Close()
+
 You can run (Go) JavaScript from the commandline with: http://github.com/robertkrimen/otto/tree/master/otto
 
 	$ go get -v github.com/robertkrimen/otto/otto
@@ -85,7 +99,6 @@ For more information: http://github.com/robertkrimen/otto/tree/master/underscore
 Caveat Emptor
 
     * For now, otto is a hybrid ECMA3/ECMA5 interpreter. Parts of the specification are still works in progress.
-    * For example, "use strict" will parse, but does nothing.
     * Error reporting needs to be improved.
     * Does not support the (?!) or (?=) regular expression syntax (because Go does not)
     * JavaScript considers a vertical tab (\000B <VT>) to be part of the whitespace class (\s), while RE2 does not.
@@ -153,11 +166,40 @@ If you want to stop long running executions (like third-party code), you can use
         otto.Interrupt = nil
     }
 
+Alternatively, RunContext (or CallContext) will stop the execution when a context is done,
+returning ErrInterrupted (or context.DeadlineExceeded) instead of requiring a recover:
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*Time.Second)
+    defer cancel()
+    _, err := otto.RunContext(ctx, unsafe)
+    if err == context.DeadlineExceeded {
+        fmt.Fprintf(os.Stderr, "Some code took to long!\n")
+    }
+
+For a limit that does not depend on the speed of the machine, RunWithStepLimit (or CallWithStepLimit)
+will stop the execution after a number of steps, returning ErrStepLimit, and SetStepLimit will
+limit the steps of every run on the runtime:
+
+    _, steps, err := otto.RunWithStepLimit(unsafe, 1000000)
+    if err == otto.ErrStepLimit {
+        fmt.Fprintf(os.Stderr, "Some code took too many steps!\n")
+    }
+    fmt.Printf("Used %d steps\n", steps)
+
+Similarly, SetMemoryLimit will stop the execution when the runtime has allocated (approximately)
+too much memory, returning a *MemoryLimitError, and SetMaxCallDepth limits the depth of recursion
+(throwing a RangeError).
+
 Where is setTimeout/setInterval?
 
 These timing functions are not actually part of the ECMA-262 specification. Typically, they belong to the `windows` object (in the browser).
 It would not be difficult to provide something like these via Go, but you probably want to wrap otto in an event loop in that case.
 
+The eventloop package does exactly that, providing setTimeout, setInterval, and setImmediate:
+
+    loop := eventloop.New(otto.New())
+    err := loop.Run(`setTimeout(function(){ console.log("Hello, World.") }, 100)`)
+
 Here is some discussion of the problem:
 
 * http://book.mixu.net/node/ch2.html
@@ -183,7 +225,8 @@ type Otto struct {
 	runtime   *_runtime
 }
 
-// New will allocate a new JavaScript runtime
+// New will allocate a new JavaScript runtime, which should be closed (see Close)
+// once it is no longer needed
 func New() *Otto {
 	self := &Otto{
 		runtime: newContext(),
@@ -209,18 +252,23 @@ func (otto *Otto) clone() *Otto {
 // Run will allocate a new JavaScript runtime, run the given source
 // on the allocated runtime, and return the runtime, resulting value, and
 // error (if any).
-func Run(source string) (*Otto, Value, error) {
+//
+// src may be a string, a byte slice, a bytes.Buffer, an io.Reader, or a *Script (see Otto.Run).
+func Run(src interface{}) (*Otto, Value, error) {
 	otto := New()
-	value, err := otto.Run(source)
+	value, err := otto.Run(src)
 	return otto, value, err
 }
 
-// Run will run the given source (parsing it first), returning the resulting value and error (if any)
+// Run will run the given source (parsing it first if necessary), returning the resulting value and error (if any)
+//
+// src may be a string, a byte slice, a bytes.Buffer, an io.Reader, or a *Script (see Otto.Compile).
+// A *Script has already been parsed, so running it again is cheap.
 //
 // If the runtime is unable to parse source, then this function will return undefined and the parse error (nothing
 // will be evaluated in this case).
-func (self Otto) Run(source string) (Value, error) {
-	return self.runtime.runSafe(source)
+func (self Otto) Run(src interface{}) (Value, error) {
+	return self.runtime.runSafe(src)
 }
 
 // Get the value of the top-level binding of the given name.
@@ -236,6 +284,10 @@ func (self Otto) Get(name string) (Value, error) {
 }
 
 func (self Otto) getValue(name string) Value {
+	if self.runtime.GlobalLexicalEnvironment.HasBinding(name) {
+		// A top-level let or const
+		return self.runtime.GlobalLexicalEnvironment.GetValue(name, false)
+	}
 	return self.runtime.GlobalEnvironment.GetValue(name, false)
 }
 
@@ -262,6 +314,10 @@ func (self Otto) Set(name string, value interface{}) error {
 }
 
 func (self Otto) setValue(name string, value Value) {
+	if self.runtime.GlobalLexicalEnvironment.HasBinding(name) {
+		self.runtime.GlobalLexicalEnvironment.SetMutableBinding(name, value, false)
+		return
+	}
 	self.runtime.GlobalEnvironment.SetValue(name, value, false)
 }
 
@@ -277,6 +333,10 @@ func (self Otto) setValue(name string, value Value) {
 // Call will invoke the function constructor rather than performing a function call.
 // In this case, the this argument has no effect.
 //
+// source may also be a *Script (see Otto.Compile), in which case the script
+// is run and the resulting function is called, or a function Value, in which
+// case it is called directly.
+//
 //      // value is a String object                                                       
 //      value, _ := Otto.Call("Object", nil, "Hello, World.")                             
 //                                                                                        
@@ -287,30 +347,35 @@ func (self Otto) setValue(name string, value Value) {
 //      // value is [ 1, 2, 3, undefined, 4, 5, 6, 7, "abc" ]                             
 //      value, _ := Otto.Call(`[ 1, 2, 3, undefined, 4 ].concat`, nil, 5, 6, 7, "abc")    
 //
-func (self Otto) Call(source string, this interface{}, argumentList ...interface{}) (Value, error) {
+func (self Otto) Call(source interface{}, this interface{}, argumentList ...interface{}) (Value, error) {
 
 	thisValue := UndefinedValue()
 
+	src, isString := source.(string)
+
 	new_ := false
 	switch {
-	case strings.HasPrefix(source, "new "):
-		source = source[4:]
+	case isString && strings.HasPrefix(src, "new "):
+		src = src[4:]
+		source = src
 		new_ = true
 	}
 
 	if !new_ && this == nil {
-		value := UndefinedValue()
-		fallback := false
-		err := catchPanic(func() {
-			programNode := mustParse(source + "()")
-			if callNode, valid := programNode.Body[0].(*_callNode); valid {
-				value = self.runtime.evaluateCall(callNode, argumentList)
-			} else {
-				fallback = true
+		if isString {
+			// The source is called as-is (so a member is called with its object as this),
+			// unless it is not a call once "()" is appended, in which case it is run below.
+			// Once it has been called, it is never run again (even if it throws).
+			if programNode, err := parse(src + "()"); err == nil {
+				if callNode, valid := programNode.Body[0].(*_callNode); valid {
+					value := UndefinedValue()
+					err := catchPanic(func() {
+						value = self.runtime.evaluateCall(callNode, argumentList)
+						self.runtime.runJobs()
+					})
+					return value, err
+				}
 			}
-		})
-		if !fallback && err == nil {
-			return value, nil
 		}
 	} else {
 		value, err := self.ToValue(this)
@@ -320,9 +385,13 @@ func (self Otto) Call(source string, this interface{}, argumentList ...interface
 		thisValue = value
 	}
 
-	fnValue, err := self.Run(source)
-	if err != nil {
-		return UndefinedValue(), err
+	fnValue, isValue := source.(Value)
+	err := error(nil)
+	if !isValue {
+		fnValue, err = self.Run(source)
+		if err != nil {
+			return UndefinedValue(), err
+		}
 	}
 
 	value := UndefinedValue()
@@ -374,6 +443,17 @@ func (self Otto) ToValue(value interface{}) (Value, error) {
 	return self.runtime.ToValue(value)
 }
 
+// SetMaxCallDepth sets the maximum depth of (nested) function calls, beyond
+// which a call will throw a RangeError ("Maximum call stack size exceeded").
+// The RangeError can be caught by JavaScript (try/catch), like any other error.
+//
+// By default, the maximum is 10000 calls. A maximum of 0 means no maximum, in
+// which case deep (or infinite) recursion will overflow the Go stack and crash
+// the process.
+func (self *Otto) SetMaxCallDepth(depth int) {
+	self.runtime.maxCallDepth = depth
+}
+
 // Copy will create a copy/clone of the runtime.
 //
 // Copy is useful for saving some processing time when creating many similar
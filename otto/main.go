@@ -33,7 +33,7 @@ func main() {
 		underscore.Disable()
 	}
 	Otto := otto.New()
-	_, err = Otto.Run(string(script))
+	_, err = Otto.Run(script)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(64)
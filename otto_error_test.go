@@ -47,3 +47,155 @@ func TestOttoError(t *testing.T) {
 	Is(err, "ReferenceError: xyzzy is not defined (line 4)")
 
 }
+
+func TestOttoErrorPosition(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	_, err := Otto.Run(`
+		var abc = 1;
+		abc + def.ghi;
+	`)
+	Is(err, "ReferenceError: def is not defined (line 3)")
+	Is(err.(*Error).Position().String(), "3:9")
+	Is(err.(*Error).End().String(), "3:12")
+	Is(err.(*Error).Position().Offset, 24)
+
+	_, err = Otto.Run(`
+		if (true) {
+			throw "Xyzzy";
+		}
+	`)
+	Is(err, "Xyzzy")
+	Is(err.(*Error).Position().String(), "3:4")
+	Is(err.(*Error).End().String(), "3:18")
+
+	_, err = Otto.Run(`
+		var abc = {;
+	`)
+	Is(err, "SyntaxError: Unexpected token ; (line 2)")
+	Is(err.(*Error).Position().String(), "2:14")
+	Is(err.(*Error).End() == nil, true)
+}
+
+func TestOttoErrorValue(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	_, err := Otto.Run(`throw "Xyzzy"`)
+	Is(err.(*Error).Name, "")
+	Is(err.(*Error).Message, "Xyzzy")
+	Is(err.(*Error).Value(), "Xyzzy")
+
+	_, err = Otto.Run(`throw { abc: 1, def: "ghi" }`)
+	{
+		object := err.(*Error).Value().Object()
+		value, _ := object.Get("def")
+		Is(value, "ghi")
+	}
+
+	_, err = Otto.Run(`
+        throw new TypeError("Nothing happens.");
+    `)
+	Is(err, "TypeError: Nothing happens.")
+	Is(err.(*Error).Name, "TypeError")
+	Is(err.(*Error).Message, "Nothing happens.")
+	Is(err.(*Error).Value().Class(), "Error")
+	Is(err.(*Error).String(), "TypeError: Nothing happens.\n    at 2:9")
+
+	_, err = Otto.Run(`
+        function Xyzzy(message) {
+            this.message = message;
+        }
+        Xyzzy.prototype = new Error();
+        Xyzzy.prototype.name = "Xyzzy";
+        throw new Xyzzy("Nothing happens.");
+    `)
+	Is(err, "Xyzzy: Nothing happens.")
+	Is(err.(*Error).Name, "Xyzzy")
+	Is(err.(*Error).Message, "Nothing happens.")
+
+	_, err = Otto.Run(`abc.def`)
+	Is(err, "ReferenceError: abc is not defined (line 1)")
+	Is(err.(*Error).Name, "ReferenceError")
+	Is(err.(*Error).Message, "abc is not defined")
+	Is(err.(*Error).Value().IsUndefined(), true)
+	Is(err.(*Error).String(), "ReferenceError: abc is not defined\n    at 1:1")
+
+	_, err = Otto.Run(`abc = {`)
+	Is(err.(*Error).Name, "SyntaxError")
+	Is(err.(*Error).Message, "Unexpected end of input")
+
+	_, err = Otto.Call(`(function(){ null.abc })`, nil)
+	Is(err.(*Error).Name, "TypeError")
+
+	{
+		value, _ := Otto.Run(`(function(){ throw new RangeError("Xyzzy") })`)
+		_, err = value.Call(UndefinedValue())
+		Is(err.(*Error).Name, "RangeError")
+		Is(err.(*Error).Message, "Xyzzy")
+	}
+
+	{
+		object, _ := Otto.Object(`({ abc: function(){ throw 42 } })`)
+		_, err = object.Call("abc")
+		Is(err.(*Error).Name, "")
+		Is(err.(*Error).Value(), "42")
+	}
+}
+
+func TestOttoErrorStack(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	script, err := Otto.Compile("xyzzy.js", `
+        function abc() {
+            return def();
+        }
+        function def() {
+            return new TypeError("Nothing happens.");
+        }
+        var ghi = abc();
+    `)
+	Is(err, nil)
+	_, err = Otto.Run(script)
+	Is(err, nil)
+
+	value, _ := Otto.Run(`ghi.stack`)
+	Is(value, "TypeError: Nothing happens.\n    at def (xyzzy.js:6:20)\n    at abc (xyzzy.js:3:20)\n    at xyzzy.js:8:19")
+
+	value, _ = Otto.Run(`Object.keys(ghi).indexOf("stack")`)
+	Is(value, "-1")
+
+	// An error raised by the runtime, and caught
+	script, err = Otto.Compile("xyzzy.js", `
+        function jkl() {
+            return mno.pqr;
+        }
+        try {
+            jkl();
+        } catch (error) {
+            error.stack;
+        }
+    `)
+	Is(err, nil)
+	value, _ = Otto.Run(script)
+	Is(value, "ReferenceError: mno is not defined\n    at jkl (xyzzy.js:3:20)\n    at xyzzy.js:6:13")
+
+	// An uncaught error
+	script, err = Otto.Compile("xyzzy.js", `
+        function stu() {
+            [ 1 ].forEach(function vwx() {
+                throw new Error("Xyzzy");
+            });
+        }
+        stu();
+    `)
+	Is(err, nil)
+	_, err = Otto.Run(script)
+	Is(err, "Error: Xyzzy")
+	Is(err.(*Error).String(), "Error: Xyzzy\n    at vwx (xyzzy.js:4:17)\n    at stu (xyzzy.js:3:13)\n    at xyzzy.js:7:9")
+}
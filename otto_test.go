@@ -493,6 +493,34 @@ func TestOttoCall(t *testing.T) {
 	value, err = otto.Call(`[ 1, 2, 3, undefined, 4 ].concat`, nil, 5, 6, 7, "abc")
 	Is(err, nil)
 	Is(value, "1,2,3,,4,5,6,7,abc")
+
+	// A call that throws (or whose jobs fail) is not called again
+	_, err = otto.Run(`
+        var mno = 0;
+        var pqr = {
+            stu: function() {
+                mno += 1;
+                Promise.reject(new Error("xyzzy"));
+                return mno;
+            },
+            vwx: function() {
+                mno += 1;
+                throw new Error("xyzzy");
+            },
+        };
+    `)
+	Is(err, nil)
+
+	value, err = otto.Call(`pqr.stu`, nil)
+	Is(err, "Error: xyzzy")
+	Is(value, "1")
+
+	_, err = otto.Call(`pqr.vwx`, nil)
+	Is(err, "Error: xyzzy")
+
+	value, err = otto.Run(`mno`)
+	Is(err, nil)
+	Is(value, "2")
 }
 
 func TestOttoCall_new(t *testing.T) {
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/robertkrimen/otto/file"
+)
+
+// Error is the error returned when the source is not valid JavaScript.
+//
+// Name is the name of the equivalent JavaScript error, usually "SyntaxError".
+// An invalid left-hand side in an assignment (e.g. 3 = 4) is reported
+// as a "ReferenceError", as it is in the browser.
+type Error struct {
+	Name     string
+	Message  string
+	Position file.Position // Where the error occurred (the start of the offending token)
+}
+
+func (self *Error) String() string {
+	if len(self.Message) == 0 {
+		return self.Name
+	}
+	return fmt.Sprintf("%s: %s", self.Name, self.Message)
+}
+
+// Error returns a description of the error, including the line where it occurred.
+func (self *Error) Error() string {
+	return fmt.Sprintf("%s (line %d)", self.String(), self.Position.Line)
+}
+
+func (self *_lexer) newError(name string, idx file.Idx, description string, argumentList ...interface{}) *Error {
+	message := description
+	if len(argumentList) > 0 {
+		message = fmt.Sprintf(description, argumentList...)
+	}
+	err := &Error{
+		Name:    name,
+		Message: message,
+	}
+	if position := self.file.Position(idx); position != nil {
+		err.Position = *position
+	}
+	return err
+}
+
+func (self *_lexer) newSyntaxError(idx file.Idx, description string, argumentList ...interface{}) *Error {
+	return self.newError("SyntaxError", idx, description, argumentList...)
+}
+
+func (self *_parser) newSyntaxError(token _token, description string, argumentList ...interface{}) *Error {
+	return self.lexer.newError("SyntaxError", token.Idx0, description, argumentList...)
+}
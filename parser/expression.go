@@ -0,0 +1,920 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/robertkrimen/otto/ast"
+)
+
+func (self *_parser) ParsePrimaryExpression() ast.Expression {
+	token := self.Peek()
+	switch token.Kind {
+	case "identifier":
+		if self.matchAsync("function") {
+			return self.ParseFunction(false)
+		}
+		return self.ConsumeIdentifier()
+	case "string":
+		return self.ConsumeString()
+	case "boolean":
+		return self.ConsumeBoolean()
+	case "number":
+		return self.ConsumeNumber()
+	case "null":
+		return self.ConsumeNull()
+	case "function":
+		return self.ParseFunction(false)
+	case "class":
+		return self.ParseClass(false)
+	case "super":
+		return self.ParseSuper()
+	case "this":
+		node := &ast.ThisExpression{}
+		self.markToken(&node.Span, self.Next())
+		return node
+	case "{":
+		return self.ParseObjectLiteral()
+	case "[":
+		return self.ParseArrayLiteral()
+	case "(":
+		self.Expect("(")
+		result := self.ParseExpression()
+		self.Expect(")")
+		return result
+	case "/", "/=": // Here, "/" & "/=" actually indicate
+		// the beginning of a regular expression
+		return self.ParseRegExpLiteral(token)
+	case "template", "template-head":
+		return self.ParseTemplateLiteral(nil)
+	}
+
+	panic(self.Unexpected(token))
+}
+
+func (self *_parser) ParseObjectPropertyKey() string {
+	if self.Match("identifier") {
+		return self.ConsumeIdentifier().Name
+	} else if self.Match("number") {
+		return floatToString(self.ConsumeNumber().Value)
+	} else if self.Match("string") {
+		return self.ConsumeString().Value
+	}
+	token := self.Next()
+	if !isIdentifierName(token) {
+		panic(self.Unexpected(token))
+	}
+	return token.Text
+}
+
+// parsePropertyKey parses the key of a property (or method), which is either a
+// name, or the expression of a computed key, e.g. [Symbol.iterator]
+func (self *_parser) parsePropertyKey() (string, ast.Expression) {
+	if self.Accept("[") {
+		key := self.ParseAssignmentExpression()
+		self.Expect("]")
+		return "", key
+	}
+	return self.ParseObjectPropertyKey(), nil
+}
+
+// acceptModifier accepts name (e.g. get, static, or async) if it is a modifier of
+// the key that follows, rather than a key itself, e.g. get() {} or { get: 1 }
+func (self *_parser) acceptModifier(name string) bool {
+	if token := self.Peek(); token.Kind != "identifier" || token.Text != name {
+		return false
+	}
+	switch self.peekAfter().Kind {
+	case "(", ":", ",", "}", "=":
+		return false
+	}
+	self.Next()
+	return true
+}
+
+func (self *_parser) ParseObjectProperty() *ast.Property {
+	idx0 := self.idx0()
+
+	if self.Match("...") {
+		// { ...abc } is only valid as a pattern (see toPattern)
+		self.patternOnly = append(self.patternOnly, self.Unexpected(self.Next()))
+		node := &ast.Property{
+			Kind:  "rest",
+			Value: self.ParseAssignmentExpression(),
+		}
+		self.markNode(&node.Span, idx0)
+		return node
+	}
+
+	if token := self.Peek(); token.Kind == "identifier" {
+		switch self.peekAfter().Kind {
+		case ",", "}", "=":
+			return self.parseShorthandProperty()
+		}
+	}
+
+	node := &ast.Property{
+		Kind: "value",
+	}
+	generator, async := false, false
+	if self.acceptModifier("get") {
+		node.Kind = "get" // get abc() {} (11.1.5)
+	} else if self.acceptModifier("set") {
+		node.Kind = "set" // set abc(def) {}
+	} else {
+		async = self.acceptModifier("async") // async abc() {}
+		generator = self.Accept("*")         // *abc() {}
+	}
+	if async && generator {
+		panic(self.lexer.newSyntaxError(idx0, "Async generator functions are not supported"))
+	}
+	node.Key, node.Computed = self.parsePropertyKey()
+
+	if node.Kind == "value" && !async && !generator && !self.Match("(") {
+		self.Expect(":")
+		node.Value = self.parseAssignmentExpression(true)
+		self.markNode(&node.Span, idx0)
+		return node
+	}
+	if node.Kind == "value" {
+		node.Kind = "method"
+	}
+
+	function := &ast.FunctionLiteral{
+		Generator: generator,
+		Async:     async,
+	}
+	functionIdx0 := self.idx0()
+	self.parseMethodRest(function, false)
+	self.markNode(&function.Span, functionIdx0)
+
+	switch {
+	case node.Kind == "get" && len(function.ParameterList) != 0:
+		panic(self.lexer.newSyntaxError(functionIdx0, "Getter must not have any formal parameters."))
+	case node.Kind == "set" && len(function.ParameterList) != 1:
+		panic(self.lexer.newSyntaxError(functionIdx0, "Setter must have exactly one formal parameter."))
+	}
+
+	node.Value = function
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// parseShorthandProperty parses a property that is just a name, e.g. { abc }, which
+// is the same as { abc: abc }
+func (self *_parser) parseShorthandProperty() *ast.Property {
+	identifier := self.ConsumeIdentifier()
+	node := &ast.Property{
+		Key:   identifier.Name,
+		Kind:  "value",
+		Value: identifier,
+	}
+	if self.Match("=") {
+		// { abc = 1 } is only valid as a pattern (see toPattern)
+		self.patternOnly = append(self.patternOnly, self.newSyntaxError(self.Next(), "Invalid shorthand property initializer"))
+		pattern := &ast.AssignmentPattern{
+			Target:      identifier,
+			Initializer: self.ParseAssignmentExpression(),
+		}
+		self.markNode(&pattern.Span, identifier.Idx0())
+		node.Value = pattern
+	}
+	self.markNode(&node.Span, identifier.Idx0())
+	return node
+}
+
+func (self *_parser) ParseRegExpLiteral(token _token) *ast.RegExpLiteral {
+
+	pattern := self.ScanRegularExpression().Text
+
+	flags := ""
+	if self.Match("identifier") { // gim
+		flags = self.Consume()
+	}
+
+	{
+		// Test during parsing that this is a valid regular expression
+		// Sorry, (?=) and (?!) are invalid (for now)
+		pattern := TransformRegExp(pattern)
+		_, err := regexp.Compile(pattern)
+		if err != nil {
+			panic(self.newSyntaxError(token, "Invalid regular expression: %s", err.Error()[22:])) // Skip redundant "parse regexp error"
+		}
+	}
+
+	node := &ast.RegExpLiteral{
+		Pattern: pattern,
+		Flags:   flags,
+	}
+	self.markNode(&node.Span, token.Idx0)
+	return node
+}
+
+// ParseSuper parses super, which can only be called, super(abc), in the constructor of
+// a class that extends another, or have a property accessed, super.abc, in a method
+func (self *_parser) ParseSuper() *ast.SuperExpression {
+	token := self.Next()
+	valid := false
+	switch self.Peek().Kind {
+	case "(":
+		valid = self.Scope().AllowSuperCall
+	case ".", "[":
+		valid = self.Scope().AllowSuper
+	}
+	if !valid {
+		panic(self.newSyntaxError(token, "'super' keyword unexpected here"))
+	}
+	node := &ast.SuperExpression{}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+// ParseTemplateLiteral parses a template literal, which is tagged by tag (if not nil)
+func (self *_parser) ParseTemplateLiteral(tag ast.Expression) *ast.TemplateLiteral {
+	idx0 := self.idx0()
+	if tag != nil {
+		idx0 = tag.Idx0()
+	}
+
+	node := &ast.TemplateLiteral{
+		Tag: tag,
+	}
+	token := self.Next()
+	for {
+		node.Elements = append(node.Elements, self.newTemplateElement(token))
+		if token.Kind == "template" {
+			break
+		}
+		node.Expressions = append(node.Expressions, self.ParseExpression())
+		if !self.Match("}") {
+			panic(self.Unexpected(self.Next()))
+		}
+		token = self.ScanTemplateContinuation()
+		if token.Kind != "template" && token.Kind != "template-head" {
+			panic(self.Unexpected(token))
+		}
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// newTemplateElement returns the element for a template token, which begins
+// with ` or }, and ends with ` ("template") or ${ ("template-head")
+func (self *_parser) newTemplateElement(token _token) *ast.TemplateElement {
+	if token.Kind != "template" && token.Kind != "template-head" {
+		panic(self.Unexpected(token))
+	}
+	end := int(token.Idx1) - 2 // `
+	if token.Kind == "template-head" {
+		end -= 1 // ${
+	}
+	raw := self.lexer.Source[int(token.Idx0):end]
+	raw = strings.Replace(strings.Replace(raw, "\r\n", "\n", -1), "\r", "\n", -1)
+	node := &ast.TemplateElement{
+		Value: token.Text,
+		Raw:   raw,
+	}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+func (self *_parser) ParseObjectLiteral() *ast.ObjectLiteral {
+	idx0 := self.idx0()
+
+	node := &ast.ObjectLiteral{}
+
+	self.Expect("{")
+	kindOfKey := map[string]int{}
+	for !self.Match("}") {
+		property := self.ParseObjectProperty()
+		if property.Kind != "rest" {
+			self.checkObjectProperty(kindOfKey, property)
+		}
+		node.Value = append(node.Value, property)
+
+		if self.Accept(",") {
+			continue
+		}
+	}
+	self.Expect("}")
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// checkObjectProperty checks that a property does not conflict with an earlier
+// property (of the same name) in an object literal (11.1.5)
+func (self *_parser) checkObjectProperty(kindOfKey map[string]int, property *ast.Property) {
+	if property.Computed != nil {
+		return // Not known until the literal is evaluated
+	}
+	kind := map[string]int{"value": 1, "method": 1, "get": 2, "set": 4}[property.Kind]
+	seen := kindOfKey[property.Key]
+	switch {
+	case seen == 0:
+	case seen == 1 && kind == 1:
+		if self.Scope().Strict {
+			panic(self.lexer.newSyntaxError(property.Idx0(), "Duplicate data property in object literal not allowed in strict mode"))
+		}
+	case (seen|kind)&1 != 0:
+		panic(self.lexer.newSyntaxError(property.Idx0(), "Object literal may not have data and accessor property with the same name"))
+	case seen&kind != 0:
+		panic(self.lexer.newSyntaxError(property.Idx0(), "Object literal may not have multiple get/set accessors with the same name"))
+	}
+	kindOfKey[property.Key] = seen | kind
+}
+
+func (self *_parser) ParseArrayLiteral() *ast.ArrayLiteral {
+	idx0 := self.idx0()
+
+	self.Expect("[")
+	list := []ast.Expression{}
+	for !self.Match("]") {
+		if self.Accept(",") {
+			list = append(list, nil)
+			continue
+		}
+		if self.Match("...") {
+			token := self.Next()
+			element := &ast.SpreadElement{
+				Argument: self.parseAssignmentExpression(true),
+			}
+			self.markNode(&element.Span, token.Idx0)
+			list = append(list, element)
+		} else {
+			list = append(list, self.parseAssignmentExpression(true))
+		}
+		if !self.Match("]") {
+			self.Expect(",")
+		}
+	}
+	self.Expect("]")
+
+	node := &ast.ArrayLiteral{
+		Value: list,
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseArgumentList() (argumentList []ast.Expression) {
+	self.Expect("(")
+	if !self.Match(")") {
+		argumentList = make([]ast.Expression, 0)
+		for {
+			if self.Match("...") {
+				// abc(...def)
+				idx0 := self.idx0()
+				self.Next()
+				argument := &ast.SpreadElement{
+					Argument: self.ParseAssignmentExpression(),
+				}
+				self.markNode(&argument.Span, idx0)
+				argumentList = append(argumentList, argument)
+			} else {
+				argumentList = append(argumentList, self.ParseAssignmentExpression())
+			}
+			if !self.Accept(",") {
+				break
+			}
+		}
+	}
+	self.Expect(")")
+	return argumentList
+}
+
+func (self *_parser) ParseCallExpression(left ast.Expression) ast.Expression {
+	node := &ast.CallExpression{
+		Callee: left,
+	}
+	node.ArgumentList = self.ParseArgumentList()
+	self.markNode(&node.Span, left.Idx0())
+	return node
+}
+
+func (self *_parser) ParseDotMember(left ast.Expression) ast.Expression {
+	self.Expect(".")
+	token := self.Next()
+	if !isIdentifierName(token) {
+		panic(self.newSyntaxError(token, "Unexpected token %s", token.Kind))
+	}
+	node := &ast.DotExpression{
+		Left:       left,
+		Identifier: token.Text,
+	}
+	self.markNode(&node.Span, left.Idx0())
+	return node
+}
+
+func (self *_parser) ParseBracketMember(left ast.Expression) ast.Expression {
+	self.Expect("[")
+	member := self.ParseExpression()
+	self.Expect("]")
+	node := &ast.BracketExpression{
+		Left:   left,
+		Member: member,
+	}
+	self.markNode(&node.Span, left.Idx0())
+	return node
+}
+
+func (self *_parser) ParseNewExpression() ast.Expression {
+	idx0 := self.idx0()
+	self.Expect("new")
+	node := &ast.NewExpression{
+		Callee: self.ParseLeftHandSideExpression(),
+	}
+	if self.Match("(") {
+		node.ArgumentList = self.ParseArgumentList()
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseLeftHandSideExpression() ast.Expression {
+
+	var left ast.Expression
+	if self.Match("new") {
+		left = self.ParseNewExpression()
+	} else {
+		left = self.ParsePrimaryExpression()
+	}
+
+	for {
+		if self.Match(".") {
+			left = self.ParseDotMember(left)
+		} else if self.Match("[") {
+			left = self.ParseBracketMember(left)
+		} else if self.Match("template") || self.Match("template-head") {
+			left = self.ParseTemplateLiteral(left) // A tagged template
+		} else {
+			break
+		}
+	}
+
+	return left
+}
+
+func (self *_parser) ParseLeftHandSideExpressionAllowCall() ast.Expression {
+
+	var left ast.Expression
+	if self.Match("new") {
+		left = self.ParseNewExpression()
+	} else {
+		left = self.ParsePrimaryExpression()
+	}
+
+	for {
+		if self.Match(".") {
+			left = self.ParseDotMember(left)
+		} else if self.Match("[") {
+			left = self.ParseBracketMember(left)
+		} else if self.Match("(") {
+			left = self.ParseCallExpression(left)
+		} else if self.Match("template") || self.Match("template-head") {
+			left = self.ParseTemplateLiteral(left) // A tagged template
+		} else {
+			break
+		}
+	}
+
+	return left
+}
+
+func isReference(node ast.Expression) bool {
+	switch node.(type) {
+	case *ast.Identifier, *ast.DotExpression, *ast.BracketExpression:
+		return true
+	}
+	return false
+}
+
+func (self *_parser) ParsePostfixExpression() ast.Expression {
+	left := self.ParseLeftHandSideExpressionAllowCall()
+
+	// TODO Need better syntax checking here
+	// Strictness checking, etc.
+
+	switch token := self.Peek(); token.Kind {
+	case "++", "--": // Postfix
+		if self.Match("\n") { // TODO Why?
+			break
+		}
+		if !isReference(left) {
+			panic(self.newSyntaxError(self.History(-1), "Invalid left-hand side in assignment"))
+		}
+		self.checkStrictAssignment(left)
+		node := &ast.UnaryExpression{
+			Operator: self.Consume(),
+			Operand:  left,
+			Postfix:  true,
+		}
+		self.markNode(&node.Span, left.Idx0())
+		return node
+	}
+
+	return left
+}
+
+func (self *_parser) ParseUnaryExpression() ast.Expression {
+
+	// TODO Need better syntax checking here
+	// Strictness checking, basically (trying to delete a non-reference, etc.)
+
+	switch token := self.Peek(); token.Kind {
+	case "+", "-", "!", "~", "delete", "void", "typeof":
+		idx0 := token.Idx0
+		operator := self.Consume()
+		node := &ast.UnaryExpression{
+			Operator: operator,
+			Operand:  self.ParseUnaryExpression(),
+		}
+		if _, isIdentifier := node.Operand.(*ast.Identifier); isIdentifier && operator == "delete" && self.Scope().Strict {
+			panic(self.lexer.newSyntaxError(idx0, "Delete of an unqualified identifier in strict mode."))
+		}
+		self.markNode(&node.Span, idx0)
+		return node
+	case "++", "--": // Prefix
+		idx0 := token.Idx0
+		operator := self.Consume()
+		operand := self.ParseUnaryExpression()
+		if !isReference(operand) {
+			panic(self.newSyntaxError(self.History(-1), "Invalid left-hand side in assignment"))
+		}
+		self.checkStrictAssignment(operand)
+		node := &ast.UnaryExpression{
+			Operator: operator,
+			Operand:  operand,
+		}
+		self.markNode(&node.Span, idx0)
+		return node
+	case "identifier":
+		if token.Text == "await" && self.Scope().InAsync {
+			self.Next()
+			node := &ast.AwaitExpression{
+				Argument: self.ParseUnaryExpression(),
+			}
+			self.markNode(&node.Span, token.Idx0)
+			return node
+		}
+	}
+
+	return self.ParsePostfixExpression()
+}
+
+func (self *_parser) parseBinaryExpression(left ast.Expression, next func() ast.Expression) *ast.BinaryExpression {
+	operator := self.Consume()
+	node := &ast.BinaryExpression{
+		Operator: operator,
+		Left:     left,
+		Right:    next(),
+	}
+	self.markNode(&node.Span, left.Idx0())
+	return node
+}
+
+func (self *_parser) ParseMultiplicativeExpression() ast.Expression {
+	left := self.ParseUnaryExpression()
+
+REPEAT:
+	switch self.Peek().Kind {
+	case "*", "/", "%":
+		left = self.parseBinaryExpression(left, self.ParseUnaryExpression)
+		goto REPEAT
+	}
+
+	return left
+}
+
+func (self *_parser) ParseAdditiveExpression() ast.Expression {
+	left := self.ParseMultiplicativeExpression()
+
+REPEAT:
+	switch self.Peek().Kind {
+	case "+", "-":
+		left = self.parseBinaryExpression(left, self.ParseMultiplicativeExpression)
+		goto REPEAT
+	}
+
+	return left
+}
+
+func (self *_parser) ParseShiftExpression() ast.Expression {
+	left := self.ParseAdditiveExpression()
+
+REPEAT:
+	switch self.Peek().Kind {
+	case "<<", ">>", ">>>":
+		left = self.parseBinaryExpression(left, self.ParseAdditiveExpression)
+		goto REPEAT
+	}
+	return left
+}
+
+func (self *_parser) ParseRelationalExpression() ast.Expression {
+	previousAllowIn := self.Scope().AllowIn
+	self.Scope().AllowIn = true
+	left := self.ParseShiftExpression()
+	self.Scope().AllowIn = previousAllowIn // TODO This should be deferred
+
+	switch self.Peek().Kind {
+	case "<", ">", "<=", ">=", "instanceof":
+		return self.parseBinaryExpression(left, self.ParseRelationalExpression)
+	case "in":
+		if !self.Scope().AllowIn {
+			return left
+		}
+		return self.parseBinaryExpression(left, self.ParseRelationalExpression)
+	}
+
+	return left
+}
+
+func (self *_parser) ParseEqualityExpression() ast.Expression {
+	left := self.ParseRelationalExpression()
+
+REPEAT:
+	switch self.Peek().Kind {
+	case "==", "!=", "===", "!==":
+		left = self.parseBinaryExpression(left, self.ParseRelationalExpression)
+		goto REPEAT
+	}
+
+	return left
+}
+
+func (self *_parser) ParseBitwiseANDExpression() ast.Expression {
+	left := self.ParseEqualityExpression()
+
+	for self.Match("&") {
+		left = self.parseBinaryExpression(left, self.ParseEqualityExpression)
+	}
+
+	return left
+}
+
+func (self *_parser) ParseBitwiseXORExpression() ast.Expression {
+	left := self.ParseBitwiseANDExpression()
+
+	for self.Match("^") {
+		left = self.parseBinaryExpression(left, self.ParseBitwiseANDExpression)
+	}
+
+	return left
+}
+
+func (self *_parser) ParseBitwiseORExpression() ast.Expression {
+	left := self.ParseBitwiseXORExpression()
+
+	for self.Match("|") {
+		left = self.parseBinaryExpression(left, self.ParseBitwiseXORExpression)
+	}
+
+	return left
+}
+
+func (self *_parser) ParseLogicalANDExpression() ast.Expression {
+	left := self.ParseBitwiseORExpression()
+
+	for self.Match("&&") {
+		left = self.parseBinaryExpression(left, self.ParseBitwiseORExpression)
+	}
+
+	return left
+}
+
+func (self *_parser) ParseLogicalORExpression() ast.Expression {
+	left := self.ParseLogicalANDExpression()
+
+	for self.Match("||") {
+		left = self.parseBinaryExpression(left, self.ParseLogicalANDExpression)
+	}
+
+	return left
+}
+
+func (self *_parser) ParseConditionlExpression() ast.Expression {
+	left := self.ParseLogicalORExpression()
+
+	if self.Accept("?") {
+		consequent := self.ParseAssignmentExpression()
+		self.Expect(":")
+		node := &ast.ConditionalExpression{
+			Test:       left,
+			Consequent: consequent,
+			Alternate:  self.ParseAssignmentExpression(),
+		}
+		self.markNode(&node.Span, left.Idx0())
+		return node
+	}
+
+	return left
+}
+
+func (self *_parser) ParseAssignmentExpression() ast.Expression {
+	return self.parseAssignmentExpression(false)
+}
+
+// parseAssignmentExpression is ParseAssignmentExpression, where element is true for
+// an element of an array or object literal: as that literal could yet turn out to be
+// a pattern, so could the element (if it is a literal), e.g. [ { abc = 1 } ] = def
+func (self *_parser) parseAssignmentExpression(element bool) ast.Expression {
+	if self.matchArrowFunction() {
+		return self.ParseArrowFunction()
+	}
+	if token := self.Peek(); token.Kind == "identifier" && token.Text == "yield" && self.Scope().InGenerator {
+		return self.ParseYieldExpression()
+	}
+
+	mark := len(self.patternOnly)
+	left := self.ParseConditionlExpression()
+	if self.Match("=") {
+		if pattern := self.toPattern(left); pattern != nil {
+			left = pattern
+			self.patternOnly = self.patternOnly[:mark]
+		}
+	}
+	if len(self.patternOnly) > mark {
+		// Unless (as an element) it could yet be part of a pattern
+		_, array := left.(*ast.ArrayLiteral)
+		_, object := left.(*ast.ObjectLiteral)
+		if !element || !(array || object) {
+			panic(self.patternOnly[mark])
+		}
+	}
+	if self.matchAssignment() {
+		switch left.(type) {
+		case *ast.ArrayPattern, *ast.ObjectPattern:
+		default:
+			if !isReference(left) {
+				panic(self.lexer.newError("ReferenceError", left.Idx0(), "Invalid left-hand side in assignment"))
+			}
+			self.checkStrictAssignment(left)
+		}
+		operator := self.Consume()
+		node := &ast.AssignExpression{
+			Operator: operator,
+			Left:     left,
+			Right:    self.ParseAssignmentExpression(),
+		}
+		self.markNode(&node.Span, left.Idx0())
+		return node
+	}
+	return left
+}
+
+// ParseYieldExpression parses yield (in the body of a generator function), which has an
+// argument only if it is on the same line, e.g. yield abc, or yield* abc
+func (self *_parser) ParseYieldExpression() *ast.YieldExpression {
+	idx0 := self.idx0()
+	self.Next()
+
+	node := &ast.YieldExpression{}
+
+	if !self.Match("\n") {
+		if self.Accept("*") {
+			node.Delegate = true
+			node.Argument = self.ParseAssignmentExpression()
+		} else {
+			switch self.Peek().Kind {
+			case ")", "]", "}", ",", ";", ":", "EOF":
+			default:
+				node.Argument = self.ParseAssignmentExpression()
+			}
+		}
+	}
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// matchArrowFunction returns true if the next tokens are the parameters of an
+// arrow function, e.g. abc => ... or (abc, def) => ...
+func (self *_parser) matchArrowFunction() bool {
+	lexer := self.lexer.Copy()
+	if self.matchAsync("identifier") || self.matchAsync("(") {
+		// async abc => ... or async (abc, def) => ...
+		lexer.Scan()
+	}
+	switch lexer.Scan().Kind {
+	case "identifier":
+	case "(":
+		for depth := 1; depth > 0; {
+			switch lexer.Scan().Kind {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			case "EOF", "illegal":
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	// There cannot be a line terminator before =>
+	if lexer.ScanLineSkip() {
+		return false
+	}
+	return lexer.Scan().Kind == "=>"
+}
+
+func (self *_parser) ParseArrowFunction() *ast.FunctionLiteral {
+	idx0 := self.idx0()
+
+	node := &ast.FunctionLiteral{
+		Arrow: true,
+		Async: self.matchAsync("identifier") || self.matchAsync("("),
+	}
+	if node.Async {
+		self.Next()
+	}
+
+	if self.Match("identifier") {
+		node.ParameterList = append(node.ParameterList, self.ConsumeIdentifier())
+	} else {
+		self.parseParameterList(node)
+	}
+	self.checkDuplicateParameters(node)
+	self.Expect("=>")
+
+	outer := self.Scope()
+	self.parseFunctionBodyOf(node, func() *ast.BlockStatement {
+		// An arrow function has the super of where it is defined
+		self.Scope().AllowSuper = outer.AllowSuper
+		self.Scope().AllowSuperCall = outer.AllowSuperCall
+		if self.Match("{") {
+			return self.parseFunctionBody()
+		}
+		// An expression body, e.g. abc => abc + 1, is the same as { return abc + 1 }
+		argument := self.ParseAssignmentExpression()
+		statement := &ast.ReturnStatement{
+			Argument: argument,
+		}
+		statement.Span = ast.Span{From: argument.Idx0(), To: argument.Idx1()}
+		return &ast.BlockStatement{
+			Span: statement.Span,
+			List: []ast.Statement{statement},
+		}
+	})
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// matchAsync returns true if the next token is async, followed (on the same line) by
+// a token of kind, e.g. async function abc() {}
+func (self *_parser) matchAsync(kind string) bool {
+	if token := self.Peek(); token.Kind != "identifier" || token.Text != "async" {
+		return false
+	}
+	lexer := self.lexer.Copy()
+	lexer.Scan()
+	if lexer.Copy().ScanLineSkip() {
+		return false
+	}
+	return lexer.Scan().Kind == kind
+}
+
+func (self *_parser) ParseExpression() ast.Expression {
+	left := self.ParseAssignmentExpression()
+
+	if self.Match(",") {
+		list := []ast.Expression{left}
+		for {
+			if !self.Accept(",") {
+				break
+			}
+			list = append(list, self.ParseAssignmentExpression())
+		}
+		node := &ast.SequenceExpression{
+			Sequence: list,
+		}
+		self.markNode(&node.Span, left.Idx0())
+		return node
+	}
+
+	return left
+}
+
+// checkStrictName checks that identifier, which is being declared (or bound),
+// is not eval or arguments in strict mode code (12.2.1, 12.14.1, 13.1)
+func (self *_parser) checkStrictName(identifier *ast.Identifier) {
+	if self.Scope().Strict {
+		self.checkEvalOrArguments(identifier)
+	}
+}
+
+func (self *_parser) checkEvalOrArguments(identifier *ast.Identifier) {
+	if identifier.Name == "eval" || identifier.Name == "arguments" {
+		panic(self.lexer.newSyntaxError(identifier.Idx0(), "Unexpected eval or arguments in strict mode"))
+	}
+}
+
+// checkStrictAssignment checks that the target of an assignment is not eval
+// or arguments in strict mode code (11.13.1, 11.3.1, 11.4.4)
+func (self *_parser) checkStrictAssignment(target ast.Expression) {
+	if identifier, valid := target.(*ast.Identifier); valid {
+		self.checkStrictName(identifier)
+	}
+}
@@ -1,13 +1,14 @@
-package otto
+package parser
 
 import (
 	"bytes"
-	"fmt"
 	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf16"
 	"unicode/utf8"
+
+	"github.com/robertkrimen/otto/file"
 )
 
 var keywordTable map[string]bool = boolFields(`
@@ -62,7 +63,7 @@ var punctuatorTable map[string]bool
 func init() {
 
 	punctuatorTable = boolFields(`
-		>>>= === !== >>> <<= >>=
+		>>>= === !== >>> <<= >>= =>
 	`)
 
 	// 2-character
@@ -83,9 +84,11 @@ func init() {
 }
 
 type _token struct {
-	Line, Column, Character int
-	Kind, File, Text        string
-	Error                   bool
+	Idx0  file.Idx // The index of the first character of the token
+	Idx1  file.Idx // The index of the first character immediately after the token
+	Kind  string
+	Text  string
+	Error bool
 }
 
 func (self _token) IsValid() bool {
@@ -94,12 +97,12 @@ func (self _token) IsValid() bool {
 
 type _lexer struct {
 	Source string
+	file   *file.File
 	//Tail		int
 	//Head		int
 	//Width		int
 
-	lineCount        int
-	zeroColumnOffset int
+	lineCount int
 
 	readIn       []rune
 	readInOffset int
@@ -111,10 +114,10 @@ type _lexer struct {
 	tailOffset int
 }
 
-// Only called for testing (for now)
-func newLexer(source string) _lexer {
+func newLexer(filename, source string) _lexer {
 	self := _lexer{
 		Source: source,
+		file:   file.NewFile(filename, source),
 		readIn: make([]rune, 0, len(source)), // Guestimate
 	}
 	return self
@@ -149,6 +152,7 @@ func (self *_lexer) ScanLineComment() {
 }
 
 func (self *_lexer) ScanBlockComment() int {
+	idx := file.Idx(1 + self.tailOffset - 2) // The /*
 	lineCount := 0
 	for {
 		chr := self.next()
@@ -157,14 +161,11 @@ func (self *_lexer) ScanBlockComment() int {
 			self.next() // /
 			return lineCount
 		case chr == endOfFile:
-			panic(&_syntaxError{
-				Message: "Unexpected token ILLEGAL",
-			})
+			panic(self.newSyntaxError(idx, "Unexpected token ILLEGAL"))
 		case self.scanEndOfLine(chr, false):
 			lineCount += 1
 		}
 	}
-	panic(hereBeDragons())
 }
 
 func (self *_lexer) ScanSkip() int {
@@ -190,14 +191,12 @@ func (self *_lexer) ScanSkip() int {
 				goto RETURN
 			}
 			self.ignore()
-			self.zeroColumnOffset = self.tailOffset
 		case isWhiteSpace(chr):
 			self.next()
 			self.ignore()
 		case self.scanEndOfLine(chr, true):
 			lineCount += 1
 			self.ignore()
-			self.zeroColumnOffset = self.tailOffset
 		default:
 			goto RETURN
 		}
@@ -217,7 +216,7 @@ func (self *_lexer) ScanRegularExpression() _token {
 
 	token := self.scanQuoteLiteral()
 	if token.Kind != "//" {
-		panic(token.newSyntaxError("Invalid regular expression"))
+		panic(self.newSyntaxError(token.Idx0, "Invalid regular expression"))
 	}
 	return token
 }
@@ -242,6 +241,10 @@ func (self *_lexer) Scan() (token _token) {
 		}
 	}
 
+	if chr == '`' {
+		return self.scanTemplateLiteral()
+	}
+
 	if chr == '.' || isDecimalDigit(chr) {
 		if token = self.scanNumericLiteral(); token.IsValid() {
 			return
@@ -293,40 +296,9 @@ func (self *_lexer) scanQuoteLiteral() _token {
 				text.WriteRune(value)
 				continue
 			}
-			switch value {
-			case 'n':
-				text.WriteRune('\n')
-			case 'r':
-				text.WriteRune('\r')
-			case 't':
-				text.WriteRune('\t')
-			case 'b':
-				text.WriteRune('\b')
-			case 'f':
-				text.WriteRune('\f')
-			case 'v':
-				text.WriteRune('\v')
-			case '0':
-				text.WriteRune(0)
-			case 'u':
-				result := self.scanHexadecimalRune(4)
-				if result != utf8.RuneError {
-					text.WriteRune(result)
-				} else {
-					return errorIllegal()
-				}
-
-			case 'x':
-				result := self.scanHexadecimalRune(2)
-				if result != utf8.RuneError {
-					text.WriteRune(result)
-				} else {
-					return errorIllegal()
-				}
-			default:
-				text.WriteRune(value)
+			if !self.scanEscape(value, &text) {
+				return errorIllegal()
 			}
-			// TODO Octal escaping
 		default:
 			if isLineTerminator(value) {
 				return errorIllegal()
@@ -338,6 +310,104 @@ func (self *_lexer) scanQuoteLiteral() _token {
 	return self.emit("illegal")
 }
 
+// scanEscape writes the character of an escape sequence (of a string or template),
+// which begins with value (after the \), to text, returning false if the sequence is not valid
+func (self *_lexer) scanEscape(value rune, text *bytes.Buffer) bool {
+	switch value {
+	case 'n':
+		text.WriteRune('\n')
+	case 'r':
+		text.WriteRune('\r')
+	case 't':
+		text.WriteRune('\t')
+	case 'b':
+		text.WriteRune('\b')
+	case 'f':
+		text.WriteRune('\f')
+	case 'v':
+		text.WriteRune('\v')
+	case '0':
+		text.WriteRune(0)
+	case 'u':
+		result := self.scanHexadecimalRune(4)
+		if result == utf8.RuneError {
+			return false
+		}
+		text.WriteRune(result)
+	case 'x':
+		result := self.scanHexadecimalRune(2)
+		if result == utf8.RuneError {
+			return false
+		}
+		text.WriteRune(result)
+	default:
+		text.WriteRune(value)
+	}
+	// TODO Octal escaping
+	return true
+}
+
+// scanTemplateLiteral scans (the beginning of) a template literal, from the ` up to the
+// closing ` (a "template" token), or up to the first ${ (a "template-head" token), in
+// which case the parser continues with ScanTemplateContinuation after the substitution
+//
+// The text of the token is the string with escapes, etc. interpreted
+func (self *_lexer) scanTemplateLiteral() _token {
+	self.next() // `
+	return self.scanTemplateCharacters()
+}
+
+// ScanTemplateContinuation scans the rest of a template literal after a substitution,
+// from the } up to the closing ` (a "template" token), or up to the next ${ (a
+// "template-head" token)
+func (self *_lexer) ScanTemplateContinuation() _token {
+	self.ScanSkip()
+	self.ignore()
+	if self.next() != '}' {
+		self.back()
+		return self.emit("illegal")
+	}
+	return self.scanTemplateCharacters()
+}
+
+func (self *_lexer) scanTemplateCharacters() _token {
+	var text bytes.Buffer
+	for {
+		value := self.next()
+		switch value {
+		case endOfFile:
+			return self.emit("illegal")
+		case '`':
+			return self.emitWith("template", text.String())
+		case '$':
+			if self.peek() == '{' {
+				self.next()
+				return self.emitWith("template-head", text.String())
+			}
+			text.WriteRune(value)
+		case '\\':
+			value = self.next()
+			if isLineTerminator(value) {
+				self.scanEndOfLine(value, false)
+				continue
+			}
+			if !self.scanEscape(value, &text) {
+				self.back()
+				return self.emit("illegal")
+			}
+		default:
+			if value == '\r' || value == '\n' {
+				// A line terminator is part of the string, but \r\n (or \r) is normalized to \n
+				self.scanEndOfLine(value, false)
+				value = '\n'
+			} else if isLineTerminator(value) {
+				self.lineCount += 1
+			}
+			text.WriteRune(value)
+		}
+	}
+}
+
 func convertHexadecimalRune(word string) rune {
 	value, err := strconv.ParseUint(word, 16, len(word)*4)
 	if err != nil {
@@ -374,7 +444,11 @@ func (self *_lexer) scanPunctuator() (token _token) {
 	read, word, _, _ := self.read(4)
 
 	if read[0] == '.' && !isDecimalDigit(read[1]) {
-		accept(1)
+		if read[1] == '.' && read[2] == '.' {
+			accept(3) // ...
+		} else {
+			accept(1)
+		}
 		return self.emit("punctuator")
 	}
 
@@ -497,9 +571,8 @@ func (self *_lexer) scanIllegal() _token {
 
 func (self *_lexer) emitWith(kind string, text string) _token {
 	token := _token{
-		Character: 1 + self.tailOffset,
-		Line:      1 + self.lineCount,
-		Column:    1 + self.tailOffset - self.zeroColumnOffset,
+		Idx0: file.Idx(1 + self.headOffset),
+		Idx1: file.Idx(1 + self.tailOffset),
 
 		Kind:  kind,
 		Text:  text,
@@ -512,9 +585,6 @@ func (self *_lexer) emitWith(kind string, text string) _token {
 	self.headOffset = self.tailOffset
 	self.head = self.tail
 
-	if ottoDebug {
-		fmt.Printf("emit: %s %s\n", token.Kind, token.Text)
-	}
 	if kind == "illegal" {
 		token.Error = true
 	}
@@ -1,15 +1,14 @@
-package otto
+package parser
 
 import (
-	. "./terst"
+	. "../terst"
 	"fmt"
 	"strings"
 	"testing"
 )
 
 func lexerCollect(source string) (result []_token) {
-	parser := newParser()
-	parser.lexer.Source = source
+	parser := newParser("", source)
 	for {
 		token := parser.Next()
 		result = append(result, token)
@@ -44,11 +43,11 @@ func TestLexer(t *testing.T) {
 	Terst(t)
 
 	{
-		lexer := newLexer("")
+		lexer := newLexer("", "")
 		token := lexer.Scan()
 		Is(token.Kind, "EOF")
 
-		lexer = newLexer("1")
+		lexer = newLexer("", "1")
 		token = lexer.Scan()
 		Is(token.Kind, "number")
 	}
@@ -56,19 +55,19 @@ func TestLexer(t *testing.T) {
 	{
 		test := testLexerRead
 
-		lexer := newLexer("")
+		lexer := newLexer("", "")
 		test(&lexer, 1, []rune{-1}, "", 0, 0)
 		lexer.next()
 		test(&lexer, 1, []rune{-1}, "", 0, 0)
 
-		lexer = newLexer("1")
+		lexer = newLexer("", "1")
 		test(&lexer, 1, []rune{49}, "1", 1, 1)
 		lexer.next()
 		test(&lexer, 1, []rune{-1}, "", 0, 0)
 		lexer.next()
 		test(&lexer, 1, []rune{-1}, "", 0, 0)
 
-		lexer = newLexer("abc")
+		lexer = newLexer("", "abc")
 		test(&lexer, 2, []rune{97, 98}, "ab", 2, 2)
 		lexer.next()
 		test(&lexer, 2, []rune{98, 99}, "bc", 2, 2)
@@ -77,7 +76,7 @@ func TestLexer(t *testing.T) {
 		lexer.next()
 		test(&lexer, 2, []rune{-1, -1}, "", 0, 0)
 
-		lexer = newLexer("abcdef")
+		lexer = newLexer("", "abcdef")
 		lexer.next()
 		lexer.next()
 		test(&lexer, 8, []rune{99, 100, 101, 102, -1, -1, -1, -1}, "cdef", 4, 4)
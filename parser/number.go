@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var stringToNumberParseInteger = regexp.MustCompile(`^(?:0[xX])`)
+
+// stringToFloat is the value of a numeric literal
+func stringToFloat(value string) float64 {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0
+	}
+
+	parseFloat := false
+	if strings.IndexRune(value, '.') != -1 {
+		parseFloat = true
+	} else if stringToNumberParseInteger.MatchString(value) {
+		parseFloat = false
+	} else {
+		parseFloat = true
+	}
+
+	if parseFloat {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil && err.(*strconv.NumError).Err != strconv.ErrRange {
+			return math.NaN()
+		}
+		return number
+	}
+
+	number, err := strconv.ParseInt(value, 0, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return float64(number)
+}
+
+var matchLeading0Exponent = regexp.MustCompile(`([eE][\+\-])0+([1-9])`) // 1e-07 => 1e-7
+
+// floatToString is the property name of a numeric key in an object literal, e.g. { 1.0: ... }
+func floatToString(value float64) string {
+	if math.IsNaN(value) {
+		return "NaN"
+	} else if math.IsInf(value, 0) {
+		if math.Signbit(value) {
+			return "-Infinity"
+		}
+		return "Infinity"
+	}
+	exponent := math.Log10(math.Abs(value))
+	if exponent >= 21 || exponent < -6 {
+		return matchLeading0Exponent.ReplaceAllString(strconv.FormatFloat(value, 'g', -1, 64), "$1$2")
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
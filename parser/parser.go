@@ -0,0 +1,481 @@
+/*
+Package parser implements a parser for JavaScript.
+
+    import (
+        "github.com/robertkrimen/otto/parser"
+    )
+
+Parse and return an AST
+
+    filename := "" // A filename is optional
+    src := `
+        // Sample xyzzy example
+        (function(){
+            if (3.14159 > 0) {
+                console.log("Hello, World.");
+                return;
+            }
+
+            var xyzzy = NaN;
+            console.log("Nothing happens.");
+            return xyzzy;
+        })();
+    `
+
+    // Parse some JavaScript, yielding a *ast.Program and/or an error
+    program, err := parser.ParseFile(filename, src)
+
+Warning
+
+The parser and AST interfaces are still evolving and may change in the future.
+
+*/
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+)
+
+const endOfFile = -1
+const startOfFile = -2
+
+type _parser struct {
+	lexer   _lexer
+	Stack   [](*_sourceScope)
+	history []_token
+
+	// patternOnly is an error for each part of an array or object literal that is
+	// only valid if the literal turns out to be a pattern, e.g. { abc = 1 } = def
+	// (see parseAssignmentExpression)
+	patternOnly []*Error
+}
+
+func newParser(filename, source string) *_parser {
+	return &_parser{
+		lexer:   newLexer(filename, source),
+		history: make([]_token, 0, 4),
+	}
+}
+
+// newParserAt is newParser for source that begins at the given line of filename
+func newParserAt(filename, source string, line int) *_parser {
+	self := newParser(filename, source)
+	self.lexer.file = file.NewFileAt(filename, source, line)
+	return self
+}
+
+// ReadSource reads the source of a program from src, which can be
+// a string, []byte, *bytes.Buffer, or io.Reader.
+func ReadSource(filename string, src interface{}) (string, error) {
+	if src != nil {
+		switch src := src.(type) {
+		case string:
+			return src, nil
+		case []byte:
+			return string(src), nil
+		case *bytes.Buffer:
+			if src != nil {
+				return src.String(), nil
+			}
+		case io.Reader:
+			var bfr bytes.Buffer
+			if _, err := io.Copy(&bfr, src); err != nil {
+				return "", err
+			}
+			return bfr.String(), nil
+		}
+		return "", errors.New("invalid source")
+	}
+	source, err := ioutil.ReadFile(filename)
+	return string(source), err
+}
+
+// ParseFile parses the source code of a single JavaScript program and
+// returns the corresponding ast.Program node.
+//
+// If src != nil, ParseFile parses the source from src and the filename is
+// only used when recording position information.
+// The type of the argument for the src parameter must be string, []byte,
+// *bytes.Buffer, or io.Reader.
+//
+// If src == nil, ParseFile parses the file specified by filename.
+//
+// If the source is not valid JavaScript, the returned error is a *parser.Error.
+func ParseFile(filename string, src interface{}) (*ast.Program, error) {
+	return ParseFileAt(filename, 1, src)
+}
+
+// ParseFileAt is ParseFile for source that begins at the given line of filename
+// (rather than line 1), as the positions of the program and of any error are.
+func ParseFileAt(filename string, line int, src interface{}) (*ast.Program, error) {
+	source, err := ReadSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var program *ast.Program
+	err = catchError(func() {
+		parser := newParserAt(filename, source, line)
+		program = parser.Parse()
+		program.File = parser.lexer.file
+	})
+	if err != nil {
+		return nil, err
+	}
+	return program, nil
+}
+
+// ParseFunction parses the body of a function with the given parameter names,
+// as the Function constructor does (e.g. new Function("a", "b", "return a + b")),
+// and returns the corresponding ast.FunctionLiteral node.
+//
+// Each parameter must be a single identifier, otherwise the result is a
+// SyntaxError whose message is the offending parameter.
+func ParseFunction(parameterList []string, body string) (*ast.FunctionLiteral, error) {
+	var function *ast.FunctionLiteral
+	err := catchError(func() {
+		function = &ast.FunctionLiteral{}
+		for _, name := range parameterList {
+			lexer := newLexer("", name)
+			token := lexer.Scan()
+			if token.Kind != "identifier" || lexer.Scan().Kind != "EOF" {
+				panic(&Error{
+					Name:    "SyntaxError",
+					Message: name,
+				})
+			}
+			function.ParameterList = append(function.ParameterList, &ast.Identifier{Name: token.Text})
+		}
+
+		parser := newParser("", body)
+		program := parser.ParseAsFunction()
+		parser.checkLexicalDeclarations(program.Body, function.ParameterList)
+		function.Body = &ast.BlockStatement{List: program.Body}
+		function.DeclarationList = program.DeclarationList
+		function.Strict = program.Strict
+		if function.Strict {
+			parser.checkStrictFunction(function)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return function, nil
+}
+
+func catchError(parse func()) (err error) {
+	defer func() {
+		if caught := recover(); caught != nil {
+			if caught, ok := caught.(*Error); ok {
+				err = caught
+				return
+			}
+			panic(caught)
+		}
+	}()
+	parse()
+	return nil
+}
+
+func (self *_parser) Consume() string {
+	return self.Next().Text
+}
+
+type _sourceScope struct {
+	DeclarationList []ast.Declaration
+	labelSet        map[string]bool
+	AllowIn         bool
+	InFunction      bool
+	InSwitch        bool
+	InIteration     bool
+	Strict          bool // In strict mode code (10.1.1)
+	AllowSuper      bool // In a method (of a class), where super.abc can be used
+	AllowSuperCall  bool // In the constructor of a class that extends another, where super() can be used
+	InGenerator     bool // In the body of a generator function, where yield can be used
+	InAsync         bool // In the body of an async function, where await can be used
+}
+
+func (self *_sourceScope) Declare(declaration ast.Declaration) {
+	self.DeclarationList = append(self.DeclarationList, declaration)
+}
+
+func newSourceScope() *_sourceScope {
+	self := &_sourceScope{
+		labelSet: map[string]bool{},
+		AllowIn:  true,
+	}
+	return self
+}
+
+func (self *_sourceScope) HasLabel(name string) bool {
+	_, exists := self.labelSet[name]
+	return exists
+}
+
+func (self *_parser) EnterScope() {
+	scope := newSourceScope()
+	if len(self.Stack) > 0 {
+		// A function nested in strict mode code is strict mode code
+		scope.Strict = self.Scope().Strict
+	}
+	self.Stack = append(self.Stack, scope)
+}
+
+func (self *_parser) LeaveScope() {
+	self.Stack = self.Stack[:len(self.Stack)-1]
+}
+
+func (self *_parser) Scope() *_sourceScope {
+	return self.Stack[len(self.Stack)-1]
+}
+
+func (self *_parser) Accept(kind string) bool {
+	if kind == "\n" {
+		// This is a PeekLineSkip, except we
+		// retain the adjusted lexer if we really
+		// skipped a line
+		lexerCopy := self.lexer.Copy()
+		didSkip := lexerCopy.ScanLineSkip()
+		if didSkip {
+			self.lexer = *lexerCopy
+		}
+		return didSkip
+	}
+	if self.Match(kind) {
+		self.Next()
+		return true
+	}
+	return false
+}
+
+func (self *_parser) Match(kind string) bool {
+	if kind == "\n" {
+		return self.PeekLineSkip()
+	}
+	return self.Peek().Kind == kind
+}
+
+func (self *_parser) Expect(kind string) {
+	token := self.Next()
+	if token.Kind != kind {
+		panic(self.Unexpected(token))
+	}
+}
+
+var assignmentTable map[string]bool = boolFields(`
+	= *= /= %= += -= <<= >>= >>>= &= ^= |=
+`)
+
+func (self *_parser) matchAssignment() bool {
+	return assignmentTable[self.Peek().Kind]
+}
+
+func (self *_parser) throwUnexpectedError(token _token) {
+	if futureKeywordTable[token.Kind] {
+		panic(self.newSyntaxError(token, "Unexpected reserved word"))
+	}
+	panic(self.newSyntaxError(token, "Unexpected token %s", token.Kind))
+}
+
+func (self *_parser) ConsumeNull() *ast.NullLiteral {
+	token := self.Next()
+	node := &ast.NullLiteral{Literal: token.Text}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+func (self *_parser) ConsumeIdentifier() *ast.Identifier {
+	token := self.Next()
+	if token.Kind != "identifier" {
+		self.throwUnexpectedError(token) // panic
+	}
+	node := &ast.Identifier{Name: token.Text}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+func (self *_parser) ConsumeString() *ast.StringLiteral {
+	token := self.Next()
+	node := &ast.StringLiteral{Value: token.Text}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+func (self *_parser) ConsumeBoolean() *ast.BooleanLiteral {
+	token := self.Next()
+	node := &ast.BooleanLiteral{Literal: token.Text, Value: token.Text == "true"}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+func (self *_parser) ConsumeNumber() *ast.NumberLiteral {
+	token := self.Next()
+	// An octal literal is not part of the grammar of strict mode code (B.1.1)
+	if len(token.Text) > 1 && token.Text[0] == '0' && '0' <= token.Text[1] && token.Text[1] <= '7' && self.Scope().Strict {
+		panic(self.lexer.newSyntaxError(token.Idx0, "Octal literals are not allowed in strict mode"))
+	}
+	node := &ast.NumberLiteral{Literal: token.Text, Value: stringToFloat(token.Text)}
+	self.markToken(&node.Span, token)
+	return node
+}
+
+func (self *_parser) ConsumeSemicolon() {
+
+	if self.Accept(";") {
+		return
+	}
+
+	if self.Accept("\n") {
+		return
+	}
+
+	if self.Accept(";") {
+		return
+	}
+
+	if !self.Match("EOF") && !self.Match("}") {
+		panic(self.Unexpected(self.Peek()))
+	}
+
+	return
+}
+
+func (self *_parser) ScanRegularExpression() _token {
+	token := self.lexer.ScanRegularExpression()
+	self.history = append(self.history, token)
+	if len(self.history) > 4 {
+		self.history = self.history[len(self.history)-4:]
+	}
+	return token
+}
+
+func (self *_parser) ScanTemplateContinuation() _token {
+	token := self.lexer.ScanTemplateContinuation()
+	self.history = append(self.history, token)
+	if len(self.history) > 4 {
+		self.history = self.history[len(self.history)-4:]
+	}
+	return token
+}
+
+func (self *_parser) Next() _token {
+	token := self.lexer.Scan()
+	self.history = append(self.history, token)
+	if len(self.history) > 4 {
+		self.history = self.history[len(self.history)-4:]
+	}
+	return token
+}
+
+func (self *_parser) History(index int) _token {
+	if 0 > index {
+		index = len(self.history) + index
+	}
+	if index >= len(self.history) {
+		panic(fmt.Errorf("Index %d is out of range for history (%d)", index, len(self.history)))
+	}
+	return self.history[index]
+}
+
+func (self *_parser) PeekLineSkip() bool {
+	return self.lexer.Copy().ScanLineSkip()
+}
+
+func (self *_parser) Peek() _token {
+	return self.lexer.Copy().Scan()
+}
+
+// peekAfter returns the token after the next token
+func (self *_parser) peekAfter() _token {
+	lexer := self.lexer.Copy()
+	lexer.Scan()
+	return lexer.Scan()
+}
+
+func (self *_parser) Parse() *ast.Program {
+	self.EnterScope()
+	defer self.LeaveScope()
+
+	node := &ast.Program{}
+	node.Body = self.parseSourceElementsUntil(func() bool {
+		return self.Match("EOF")
+	})
+	self.checkLexicalDeclarations(node.Body, nil)
+	node.DeclarationList = self.Scope().DeclarationList
+	node.Strict = self.Scope().Strict
+	node.From, node.To = 1, file.Idx(1+len(self.lexer.Source))
+
+	return node
+}
+
+func (self *_parser) ParseAsFunction() *ast.Program {
+	self.EnterScope()
+	defer self.LeaveScope()
+	self.Scope().InFunction = true
+
+	node := &ast.Program{}
+	node.Body = self.parseSourceElementsUntil(func() bool {
+		return self.Match("EOF")
+	})
+	self.checkLexicalDeclarations(node.Body, nil)
+	node.DeclarationList = self.Scope().DeclarationList
+	node.Strict = self.Scope().Strict
+	node.From, node.To = 1, file.Idx(1+len(self.lexer.Source))
+
+	return node
+}
+
+func (self *_parser) Unexpected(token _token) *Error {
+	switch token.Kind {
+	case "EOF":
+		return self.newSyntaxError(token, "Unexpected end of input")
+	case "illegal":
+		return self.newSyntaxError(token, "Unexpected token ILLEGAL (%s)", token.Text)
+	}
+	return self.newSyntaxError(token, "Unexpected token %s", token.Text)
+}
+
+// idx0 returns the start of the next token, the start of a node about to be parsed
+func (self *_parser) idx0() file.Idx {
+	return self.Peek().Idx0
+}
+
+// markNode records the span of a node, from idx0 to the end of the last token consumed
+func (self *_parser) markNode(span *ast.Span, idx0 file.Idx) {
+	span.From = idx0
+	if len(self.history) > 0 {
+		span.To = self.history[len(self.history)-1].Idx1
+	} else {
+		span.To = idx0
+	}
+}
+
+// markToken records the span of a node consisting of a single token
+func (self *_parser) markToken(span *ast.Span, token _token) {
+	span.From = token.Idx0
+	span.To = token.Idx1
+}
+
+func isIdentifierName(token _token) bool {
+	switch token.Kind {
+	case "identifier", "boolean":
+		return true
+	}
+	return keywordTable[token.Kind]
+}
+
+func boolFields(input string) (result map[string]bool) {
+	result = map[string]bool{}
+	for _, word := range strings.Fields(input) {
+		result[word] = true
+	}
+	return result
+}
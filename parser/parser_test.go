@@ -0,0 +1,500 @@
+package parser
+
+import (
+	. "../terst"
+	"testing"
+
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+)
+
+func TestParseFile(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("xyzzy.js", `
+        var abc = 1;
+        function def(ghi) {
+            return abc + ghi;
+        }
+        def(2);
+    `)
+	Is(err, nil)
+	Is(program.File.Name(), "xyzzy.js")
+	Is(len(program.Body), 3)
+	Is(len(program.DeclarationList), 2)
+
+	statement := program.Body[0].(*ast.VariableStatement)
+	Is(statement.List[0].Name, "abc")
+	Is(statement.List[0].Initializer.(*ast.NumberLiteral).Value, 1)
+	Is(program.File.Position(statement.Idx0()).String(), "xyzzy.js:2:9")
+	Is(program.File.Position(statement.Idx1()).String(), "xyzzy.js:2:21")
+
+	function := program.Body[1].(*ast.FunctionStatement).Function
+	Is(function.Name.Name, "def")
+	Is(function.ParameterList[0].Name, "ghi")
+	Is(program.File.Position(function.Idx0()).String(), "xyzzy.js:3:9")
+	Is(program.File.Position(function.Idx1()).String(), "xyzzy.js:5:10")
+	Is(program.DeclarationList[1].(*ast.FunctionDeclaration).Function == function, true)
+
+	call := program.Body[2].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	Is(call.Callee.(*ast.Identifier).Name, "def")
+	Is(program.File.Position(call.Idx0()).String(), "xyzzy.js:6:9")
+	Is(program.File.Position(call.Idx1()).String(), "xyzzy.js:6:15")
+	Is(program.File.Position(call.ArgumentList[0].Idx0()).String(), "xyzzy.js:6:13")
+
+	_, err = ParseFile("", []byte("abc = {"))
+	Is(err, "SyntaxError: Unexpected end of input (line 1)")
+	Is(err.(*Error).Position.String(), "1:8")
+
+	_, err = ParseFile("xyzzy.js", "\n\tabc = 1 = 2")
+	Is(err.(*Error).Name, "ReferenceError")
+	Is(err.(*Error).Message, "Invalid left-hand side in assignment")
+	Is(err.(*Error).Position, file.Position{Filename: "xyzzy.js", Offset: 8, Line: 2, Column: 8})
+}
+
+func TestParseFunction(t *testing.T) {
+	Terst(t)
+
+	function, err := ParseFunction([]string{"abc", "def"}, "return abc + def")
+	Is(err, nil)
+	Is(len(function.ParameterList), 2)
+	Is(len(function.Body.List), 1)
+
+	_, err = ParseFunction([]string{"abc;def"}, "")
+	Is(err.(*Error).Message, "abc;def")
+
+	_, err = ParseFunction([]string{"null"}, "")
+	Is(err.(*Error).Message, "null")
+}
+
+func TestParseStrict(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        "use strict";
+        function abc() {
+            return function() {};
+        }
+    `)
+	Is(err, nil)
+	Is(program.Strict, true)
+	function := program.Body[1].(*ast.FunctionStatement).Function
+	Is(function.Strict, true)
+	Is(function.Body.List[0].(*ast.ReturnStatement).Argument.(*ast.FunctionLiteral).Strict, true)
+
+	program, err = ParseFile("", `
+        function abc() {
+            'use strict';
+        }
+        function def() {
+            abc();
+            'use strict';
+        }
+    `)
+	Is(err, nil)
+	Is(program.Strict, false)
+	Is(program.Body[0].(*ast.FunctionStatement).Function.Strict, true)
+	Is(program.Body[1].(*ast.FunctionStatement).Function.Strict, false)
+
+	_, err = ParseFile("", `
+        function abc() {
+            "use strict";
+            with (def) {}
+        }
+    `)
+	Is(err, "SyntaxError: Strict mode code may not include a with statement (line 4)")
+	Is(err.(*Error).Position.String(), "4:13")
+
+	function, err = ParseFunction(nil, "'use strict'; return this")
+	Is(err, nil)
+	Is(function.Strict, true)
+}
+
+func TestInspect(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        if (abc) {
+            eval("def");
+        } else {
+            [ ghi, , eval ];
+        }
+    `)
+	Is(err, nil)
+
+	identifierList := []string{}
+	ast.Inspect(program, func(node ast.Node) bool {
+		if identifier, ok := node.(*ast.Identifier); ok {
+			identifierList = append(identifierList, identifier.Name)
+		}
+		return true
+	})
+	Is(identifierList, []string{"abc", "eval", "ghi", "eval"})
+
+	// Returning false skips the children of a node
+	count := 0
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node != nil {
+			count++
+		}
+		_, ok := node.(*ast.IfStatement)
+		return !ok
+	})
+	Is(count, 2) // The program and the if statement
+}
+
+func TestParseLexical(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        let abc = 1, def;
+        const ghi = 2;
+        for (let jkl = 0; jkl < 1; jkl++) {}
+        for (const jkl in abc) {}
+        var let = 3;
+        let = let + 1;
+    `)
+	Is(err, nil)
+	declaration := program.Body[0].(*ast.LexicalDeclaration)
+	Is(declaration.Token, "let")
+	Is(len(declaration.List), 2)
+	Is(program.Body[1].(*ast.LexicalDeclaration).Token, "const")
+	Is(program.Body[2].(*ast.ForStatement).Initializer.(*ast.LexicalDeclaration).Token, "let")
+	Is(program.Body[3].(*ast.ForInStatement).Declaration, "const")
+	Is(len(program.DeclarationList), 1) // var let
+
+	test := func(source string, message string) {
+		_, err := ParseFile("", source)
+		Is(err, message)
+	}
+
+	test("let abc; let abc;", "SyntaxError: Identifier 'abc' has already been declared (line 1)")
+	test("{ var abc; const abc = 1; }", "SyntaxError: Identifier 'abc' has already been declared (line 1)")
+	test("function abc(def) { let def; }", "SyntaxError: Identifier 'def' has already been declared (line 1)")
+	test("switch (abc) { case 1: let def; case 2: let def; }", "SyntaxError: Identifier 'def' has already been declared (line 1)")
+	test("const abc;", "SyntaxError: Missing initializer in const declaration (line 1)")
+	test("if (abc) let def = 1;", "SyntaxError: Lexical declaration cannot appear in a single-statement context (line 1)")
+	test("while (abc) const def = 1;", "SyntaxError: Lexical declaration cannot appear in a single-statement context (line 1)")
+	test("let let = 1;", "SyntaxError: let is disallowed as a lexically bound name (line 1)")
+	test("for (let abc = 1 in def) {}", "SyntaxError: for-in loop variable declaration may not have an initializer (line 1)")
+
+	// Not the same block
+	test("let abc; { let abc; }", "<nil>")
+}
+
+func TestParseArrowFunction(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        abc => abc + 1;
+        (abc, def) => { return abc; };
+        () => {};
+        (abc) + 1;
+    `)
+	Is(err, nil)
+	function := program.Body[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	Is(function.Arrow, true)
+	Is(len(function.ParameterList), 1)
+	Is(function.Body.List[0].(*ast.ReturnStatement).Argument.(*ast.BinaryExpression).Operator, "+")
+	function = program.Body[1].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	Is(len(function.ParameterList), 2)
+	Is(len(program.Body[2].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral).ParameterList), 0)
+	_, isBinary := program.Body[3].(*ast.ExpressionStatement).Expression.(*ast.BinaryExpression)
+	Is(isBinary, true)
+
+	_, err = ParseFile("", `"use strict"; var abc = (eval) => 1;`)
+	Is(err, "SyntaxError: Unexpected eval or arguments in strict mode (line 1)")
+}
+
+func TestParseTemplateLiteral(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", "`abc\\n${ def }ghi\r\n${ jkl + 1 }`;\nmno.pqr`\\u0041`;")
+	Is(err, nil)
+	template := program.Body[0].(*ast.ExpressionStatement).Expression.(*ast.TemplateLiteral)
+	Is(template.Tag, nil)
+	Is(len(template.Elements), 3)
+	Is(len(template.Expressions), 2)
+	Is(template.Elements[0].Value, "abc\n")
+	Is(template.Elements[0].Raw, "abc\\n")
+	Is(template.Elements[1].Value, "ghi\n")
+	Is(template.Elements[1].Raw, "ghi\n")
+	Is(template.Elements[2].Value, "")
+	Is(template.Expressions[0].(*ast.Identifier).Name, "def")
+	Is(template.Expressions[1].(*ast.BinaryExpression).Operator, "+")
+	Is(template.Idx0(), 1)
+	Is(template.Idx1(), 33)
+
+	template = program.Body[1].(*ast.ExpressionStatement).Expression.(*ast.TemplateLiteral)
+	Is(template.Tag.(*ast.DotExpression).Identifier, "pqr")
+	Is(template.Elements[0].Value, "A")
+	Is(template.Elements[0].Raw, "\\u0041")
+
+	_, err = ParseFile("", "`abc${ def `")
+	Is(err, "SyntaxError: Unexpected token ILLEGAL (`) (line 1)")
+
+	_, err = ParseFile("", "`abc${ def ghi }`")
+	Is(err, "SyntaxError: Unexpected token ghi (line 1)")
+}
+
+func TestParseClass(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        class Abc extends Def.Ghi {
+            constructor(jkl) { super(jkl); }
+            mno() { return super.mno(); }
+            static pqr() {}
+            get stu() {}
+            set stu(value) {}
+            static() {}
+        }
+        var vwx = class {};
+    `)
+	Is(err, nil)
+	class := program.Body[0].(*ast.ClassStatement).Class
+	Is(class.Name.Name, "Abc")
+	Is(class.SuperClass.(*ast.DotExpression).Identifier, "Ghi")
+	Is(len(class.Constructor.ParameterList), 1)
+	Is(class.Constructor.Strict, true)
+	_, isSuper := class.Constructor.Body.List[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression).Callee.(*ast.SuperExpression)
+	Is(isSuper, true)
+	Is(len(class.Body), 5)
+	Is(class.Body[0].Key, "mno")
+	Is(class.Body[0].Kind, "method")
+	Is(class.Body[1].Static, true)
+	Is(class.Body[2].Kind, "get")
+	Is(class.Body[3].Kind, "set")
+	Is(class.Body[4].Key, "static")
+	Is(class.Body[4].Static, false)
+	class = program.Body[1].(*ast.VariableStatement).List[0].Initializer.(*ast.ClassLiteral)
+	Is(class.Name == nil, true)
+	Is(class.Constructor == nil, true)
+
+	_, err = ParseFile("", `class Abc { constructor() { super(); } }`)
+	Is(err, "SyntaxError: 'super' keyword unexpected here (line 1)")
+
+	_, err = ParseFile("", `class Abc { get constructor() {} }`)
+	Is(err, "SyntaxError: Class constructor may not be an accessor (line 1)")
+
+	_, err = ParseFile("", `class Abc {}; let Abc;`)
+	Is(err, "SyntaxError: Identifier 'Abc' has already been declared (line 1)")
+}
+
+func TestParseDestructuring(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        var [ abc, , def = 1, ...ghi ] = jkl;
+        let { mno, pqr: { stu } = {}, ...vwx } = jkl;
+        [ abc, xyz.abc ] = [ def, { mno = 2 } ] = jkl;
+        function f({ abc }, def, [ ghi ]) {}
+    `)
+	Is(err, nil)
+	array := program.Body[0].(*ast.VariableStatement).List[0].Pattern.(*ast.ArrayPattern)
+	Is(len(array.Elements), 3)
+	Is(array.Elements[1] == nil, true)
+	Is(array.Elements[2].(*ast.AssignmentPattern).Target.(*ast.Identifier).Name, "def")
+	Is(array.Rest.(*ast.Identifier).Name, "ghi")
+	object := program.Body[1].(*ast.LexicalDeclaration).List[0].Pattern.(*ast.ObjectPattern)
+	Is(len(object.Properties), 2)
+	Is(object.Properties[0].Key, "mno")
+	Is(object.Properties[1].Value.(*ast.AssignmentPattern).Target.(*ast.ObjectPattern).Properties[0].Key, "stu")
+	Is(object.Rest.(*ast.Identifier).Name, "vwx")
+	assign := program.Body[2].(*ast.ExpressionStatement).Expression.(*ast.AssignExpression)
+	Is(assign.Left.(*ast.ArrayPattern).Elements[1].(*ast.DotExpression).Identifier, "abc")
+	inner := assign.Right.(*ast.AssignExpression).Left.(*ast.ArrayPattern)
+	Is(inner.Elements[1].(*ast.ObjectPattern).Properties[0].Value.(*ast.AssignmentPattern).Target.(*ast.Identifier).Name, "mno")
+	function := program.Body[3].(*ast.FunctionStatement).Function
+	Is(len(function.ParameterList), 3)
+	Is(function.ParameterList[0].Name, "")
+	Is(function.ParameterList[1].Name, "def")
+	Is(function.ParameterPatternList[1] == nil, true)
+	Is(function.ParameterPatternList[2].(*ast.ArrayPattern).Elements[0].(*ast.Identifier).Name, "ghi")
+
+	_, err = ParseFile("", `var [ abc ];`)
+	Is(err, "SyntaxError: Missing initializer in destructuring declaration (line 1)")
+
+	_, err = ParseFile("", `abc({ def = 1 });`)
+	Is(err, "SyntaxError: Invalid shorthand property initializer (line 1)")
+
+	_, err = ParseFile("", `[ ...abc, def ] = ghi;`)
+	Is(err, "SyntaxError: Rest element must be last element (line 1)")
+
+	_, err = ParseFile("", `[ abc + 1 ] = def;`)
+	Is(err, "SyntaxError: Invalid destructuring assignment target (line 1)")
+
+	_, err = ParseFile("", `function abc([ def ], def) {}`)
+	Is(err, "SyntaxError: Duplicate parameter name not allowed in this context (line 1)")
+
+	_, err = ParseFile("", `let [ abc ] = [], { abc } = {};`)
+	Is(err, "SyntaxError: Identifier 'abc' has already been declared (line 1)")
+}
+
+func TestParseSpreadAndRest(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        abc(def, ...ghi);
+        [ 1, ...jkl ];
+        function mno(pqr, stu = 1, ...vwx) {}
+    `)
+	Is(err, nil)
+	call := program.Body[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	Is(call.ArgumentList[1].(*ast.SpreadElement).Argument.(*ast.Identifier).Name, "ghi")
+	array := program.Body[1].(*ast.ExpressionStatement).Expression.(*ast.ArrayLiteral)
+	Is(array.Value[1].(*ast.SpreadElement).Argument.(*ast.Identifier).Name, "jkl")
+	function := program.Body[2].(*ast.FunctionStatement).Function
+	Is(len(function.ParameterList), 2)
+	Is(function.ParameterList[1].Name, "")
+	Is(function.ParameterPatternList[0] == nil, true)
+	Is(function.ParameterPatternList[1].(*ast.AssignmentPattern).Target.(*ast.Identifier).Name, "stu")
+	Is(function.Rest.(*ast.Identifier).Name, "vwx")
+
+	_, err = ParseFile("", `function abc(...def = []) {}`)
+	Is(err, "SyntaxError: Rest parameter must be last formal parameter (line 1)")
+}
+
+func TestParseForOf(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        for (var abc of def) {}
+        for (const [ abc, def ] of ghi) {}
+        for (abc.def of ghi) {}
+        var of = 1;
+        for (of of of) {}
+    `)
+	Is(err, nil)
+	statement := program.Body[0].(*ast.ForOfStatement)
+	Is(statement.Source.(*ast.Identifier).Name, "def")
+	Is(program.Body[1].(*ast.ForOfStatement).Declaration, "const")
+	_, valid := program.Body[2].(*ast.ForOfStatement).Into.(*ast.DotExpression)
+	Is(valid, true)
+	Is(program.Body[4].(*ast.ForOfStatement).Into.(*ast.Identifier).Name, "of")
+
+	_, err = ParseFile("", `for (var abc = 1 of def) {}`)
+	Is(err, "SyntaxError: for-of loop variable declaration may not have an initializer (line 1)")
+
+	_, err = ParseFile("", `for (abc + 1 of def) {}`)
+	Is(err, "SyntaxError: Invalid left-hand side in for-of (line 1)")
+}
+
+func TestParseGenerator(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        function* abc() {
+            yield;
+            yield 1, yield* def;
+            var ghi = [ yield ];
+            yield
+            2;
+        }
+        var yield = 1;
+        class Jkl { *mno() { yield 1 } }
+    `)
+	Is(err, nil)
+	function := program.Body[0].(*ast.FunctionStatement).Function
+	Is(function.Generator, true)
+	body := function.Body.List
+	Is(body[0].(*ast.ExpressionStatement).Expression.(*ast.YieldExpression).Argument, nil)
+	sequence := body[1].(*ast.ExpressionStatement).Expression.(*ast.SequenceExpression).Sequence
+	Is(sequence[0].(*ast.YieldExpression).Delegate, false)
+	Is(sequence[1].(*ast.YieldExpression).Delegate, true)
+	Is(body[3].(*ast.ExpressionStatement).Expression.(*ast.YieldExpression).Argument, nil)
+	Is(body[4].(*ast.ExpressionStatement).Expression.(*ast.NumberLiteral).Value, 2)
+
+	_, err = ParseFile("", `class Abc { *constructor() {} }`)
+	Is(err, "SyntaxError: Class constructor may not be a generator (line 1)")
+}
+
+func TestParseAsync(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        async function abc() {
+            await def;
+            var ghi = await jkl + 1;
+        }
+        var mno = async function() {};
+        var pqr = async stu => await stu;
+        var vwx = async () => {};
+        class Xyz { async abc() { await 1 } }
+        var await = async;
+        async
+        function def() {}
+    `)
+	Is(err, nil)
+	function := program.Body[0].(*ast.FunctionStatement).Function
+	Is(function.Async, true)
+	body := function.Body.List
+	Is(body[0].(*ast.ExpressionStatement).Expression.(*ast.AwaitExpression).Argument.(*ast.Identifier).Name, "def")
+	binary := body[1].(*ast.VariableStatement).List[0].Initializer.(*ast.BinaryExpression)
+	Is(binary.Operator, "+")
+	Is(binary.Left.(*ast.AwaitExpression).Argument.(*ast.Identifier).Name, "jkl")
+	Is(program.Body[1].(*ast.VariableStatement).List[0].Initializer.(*ast.FunctionLiteral).Async, true)
+	function = program.Body[2].(*ast.VariableStatement).List[0].Initializer.(*ast.FunctionLiteral)
+	Is(function.Arrow, true)
+	Is(function.Async, true)
+	Is(len(function.ParameterList), 1)
+	Is(program.Body[3].(*ast.VariableStatement).List[0].Initializer.(*ast.FunctionLiteral).Async, true)
+	Is(program.Body[4].(*ast.ClassStatement).Class.Body[0].Value.Async, true)
+	Is(program.Body[5].(*ast.VariableStatement).List[0].Initializer.(*ast.Identifier).Name, "async")
+	// A line terminator after async is the end of an expression statement
+	Is(program.Body[6].(*ast.ExpressionStatement).Expression.(*ast.Identifier).Name, "async")
+	Is(program.Body[7].(*ast.FunctionStatement).Function.Async, false)
+
+	_, err = ParseFile("", `async function* abc() {}`)
+	Is(err, "SyntaxError: Async generator functions are not supported (line 1)")
+
+	_, err = ParseFile("", `class Abc { async constructor() {} }`)
+	Is(err, "SyntaxError: Class constructor may not be an async method (line 1)")
+}
+
+func TestParseComputedKeyAndMethod(t *testing.T) {
+	Terst(t)
+
+	program, err := ParseFile("", `
+        ({
+            [abc]: 1,
+            def() {},
+            *[Symbol.iterator]() {},
+            get [ghi]() {},
+            get: 2,
+            set() {},
+        });
+        class Jkl {
+            ["constructor"]() {}
+            static *[Symbol.iterator]() {}
+        }
+    `)
+	Is(err, nil)
+	literal := program.Body[0].(*ast.ExpressionStatement).Expression.(*ast.ObjectLiteral)
+	Is(literal.Value[0].Key, "")
+	Is(literal.Value[0].Computed.(*ast.Identifier).Name, "abc")
+	Is(literal.Value[1].Kind, "method")
+	Is(literal.Value[1].Key, "def")
+	Is(literal.Value[2].Kind, "method")
+	Is(literal.Value[2].Value.(*ast.FunctionLiteral).Generator, true)
+	Is(literal.Value[2].Computed.(*ast.DotExpression).Identifier, "iterator")
+	Is(literal.Value[3].Kind, "get")
+	Is(literal.Value[4].Kind, "value")
+	Is(literal.Value[4].Key, "get")
+	Is(literal.Value[5].Kind, "method")
+	Is(literal.Value[5].Key, "set")
+	class := program.Body[1].(*ast.ClassStatement).Class
+	Is(class.Constructor == nil, true)
+	Is(len(class.Body), 2)
+	Is(class.Body[1].Static, true)
+	Is(class.Body[1].Value.Generator, true)
+
+	_, err = ParseFile("", `({ [abc]: 1 } = {})`)
+	Is(err, "SyntaxError: Invalid destructuring assignment target (line 1)")
+
+	_, err = ParseFile("", `({ abc() {} } = {})`)
+	Is(err, "SyntaxError: Invalid destructuring assignment target (line 1)")
+
+	_, err = ParseFile("", `({ get abc(def) {} })`)
+	Is(err, "SyntaxError: Getter must not have any formal parameters. (line 1)")
+}
@@ -0,0 +1,255 @@
+package parser
+
+import (
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+)
+
+// parseBindingTarget parses the target of a binding (in a declaration, or a parameter):
+// an identifier, or an array or object pattern
+func (self *_parser) parseBindingTarget() ast.Expression {
+	switch self.Peek().Kind {
+	case "[":
+		return self.parseArrayBindingPattern()
+	case "{":
+		return self.parseObjectBindingPattern()
+	}
+	identifier := self.ConsumeIdentifier()
+	self.checkStrictName(identifier)
+	return identifier
+}
+
+// parseBindingElement parses a binding target, along with its default (if any), e.g. abc = 1
+func (self *_parser) parseBindingElement() ast.Expression {
+	target := self.parseBindingTarget()
+	if self.Accept("=") {
+		node := &ast.AssignmentPattern{
+			Target:      target,
+			Initializer: self.ParseAssignmentExpression(),
+		}
+		self.markNode(&node.Span, target.Idx0())
+		return node
+	}
+	return target
+}
+
+func (self *_parser) parseArrayBindingPattern() *ast.ArrayPattern {
+	idx0 := self.idx0()
+
+	node := &ast.ArrayPattern{}
+
+	self.Expect("[")
+	for !self.Match("]") {
+		if self.Accept(",") {
+			node.Elements = append(node.Elements, nil)
+			continue
+		}
+		if self.Accept("...") {
+			node.Rest = self.parseBindingTarget()
+			self.checkRestLast()
+			break
+		}
+		node.Elements = append(node.Elements, self.parseBindingElement())
+		if !self.Match("]") {
+			self.Expect(",")
+		}
+	}
+	self.Expect("]")
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) parseObjectBindingPattern() *ast.ObjectPattern {
+	idx0 := self.idx0()
+
+	node := &ast.ObjectPattern{}
+
+	self.Expect("{")
+	for !self.Match("}") {
+		if self.Accept("...") {
+			identifier := self.ConsumeIdentifier()
+			self.checkStrictName(identifier)
+			node.Rest = identifier
+			self.checkRestLast()
+			break
+		}
+
+		propertyIdx0 := self.idx0()
+		property := &ast.Property{
+			Kind: "value",
+		}
+		if self.Match("identifier") && self.peekAfter().Kind != ":" {
+			// { abc } or { abc = 1 }
+			identifier := self.ConsumeIdentifier()
+			self.checkStrictName(identifier)
+			property.Key = identifier.Name
+			property.Value = identifier
+			if self.Accept("=") {
+				pattern := &ast.AssignmentPattern{
+					Target:      identifier,
+					Initializer: self.ParseAssignmentExpression(),
+				}
+				self.markNode(&pattern.Span, identifier.Idx0())
+				property.Value = pattern
+			}
+		} else {
+			property.Key, property.Computed = self.parsePropertyKey()
+			self.Expect(":")
+			property.Value = self.parseBindingElement()
+		}
+		self.markNode(&property.Span, propertyIdx0)
+		node.Properties = append(node.Properties, property)
+
+		if !self.Match("}") {
+			self.Expect(",")
+		}
+	}
+	self.Expect("}")
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// checkRestLast checks that a rest element (which has just been parsed) is the last
+// element of its pattern
+func (self *_parser) checkRestLast() {
+	if token := self.Peek(); token.Kind == "," {
+		panic(self.newSyntaxError(token, "Rest element must be last element"))
+	}
+}
+
+// toPattern converts node, if it is an array or object literal, to a pattern, as the
+// target of an assignment (or a for-in loop), e.g. [ abc, def ] = ghi, returning
+// nil if node is not a literal
+func (self *_parser) toPattern(node ast.Expression) ast.Expression {
+	switch node := node.(type) {
+	case *ast.ArrayLiteral:
+		pattern := &ast.ArrayPattern{
+			Span:     node.Span,
+			Elements: []ast.Expression{},
+		}
+		for index, element := range node.Value {
+			if spread, valid := element.(*ast.SpreadElement); valid {
+				if index != len(node.Value)-1 {
+					panic(self.lexer.newSyntaxError(spread.Idx0(), "Rest element must be last element"))
+				}
+				pattern.Rest = self.toTarget(spread.Argument, false)
+				break
+			}
+			if element == nil {
+				pattern.Elements = append(pattern.Elements, nil)
+				continue
+			}
+			pattern.Elements = append(pattern.Elements, self.toTarget(element, true))
+		}
+		return pattern
+
+	case *ast.ObjectLiteral:
+		pattern := &ast.ObjectPattern{
+			Span:       node.Span,
+			Properties: []*ast.Property{},
+		}
+		for index, property := range node.Value {
+			switch property.Kind {
+			case "value":
+				pattern.Properties = append(pattern.Properties, &ast.Property{
+					Span:     property.Span,
+					Key:      property.Key,
+					Computed: property.Computed,
+					Kind:     "value",
+					Value:    self.toTarget(property.Value, true),
+				})
+			case "rest":
+				if index != len(node.Value)-1 {
+					panic(self.lexer.newSyntaxError(property.Idx0(), "Rest element must be last element"))
+				}
+				pattern.Rest = self.toTarget(property.Value, false)
+			default:
+				panic(self.lexer.newSyntaxError(property.Idx0(), "Invalid destructuring assignment target"))
+			}
+		}
+		return pattern
+	}
+
+	return nil
+}
+
+// toTarget converts node, an element of a literal that is being converted to a
+// pattern, to the target of an assignment, along with its default (if allowed)
+func (self *_parser) toTarget(node ast.Expression, allowDefault bool) ast.Expression {
+	switch target := node.(type) {
+	case *ast.Identifier:
+		self.checkStrictAssignment(target)
+		return target
+	case *ast.DotExpression, *ast.BracketExpression:
+		return target
+	case *ast.ArrayLiteral, *ast.ObjectLiteral:
+		return self.toPattern(target)
+	case *ast.AssignExpression:
+		// The Left of [ abc = 1 ] is already a reference (or a pattern)
+		if allowDefault && target.Operator == "=" {
+			return &ast.AssignmentPattern{
+				Span:        target.Span,
+				Target:      target.Left,
+				Initializer: target.Right,
+			}
+		}
+	case *ast.AssignmentPattern:
+		// { abc = 1 }
+		if allowDefault {
+			self.checkStrictAssignment(target.Target)
+			return target
+		}
+	}
+	panic(self.lexer.newSyntaxError(node.Idx0(), "Invalid destructuring assignment target"))
+}
+
+// boundNames returns the identifiers bound by target, an identifier or a pattern
+func boundNames(target ast.Expression) []*ast.Identifier {
+	switch target := target.(type) {
+	case *ast.Identifier:
+		return []*ast.Identifier{target}
+	case *ast.AssignmentPattern:
+		return boundNames(target.Target)
+	case *ast.ArrayPattern:
+		list := []*ast.Identifier{}
+		for _, element := range target.Elements {
+			list = append(list, boundNames(element)...)
+		}
+		return append(list, boundNames(target.Rest)...)
+	case *ast.ObjectPattern:
+		list := []*ast.Identifier{}
+		for _, property := range target.Properties {
+			list = append(list, boundNames(property.Value)...)
+		}
+		return append(list, boundNames(target.Rest)...)
+	}
+	return nil
+}
+
+// declaredNames returns the identifiers declared by variable (more than one, if it
+// is a destructuring declaration)
+func declaredNames(variable *ast.VariableExpression) []*ast.Identifier {
+	if variable.Pattern != nil {
+		return boundNames(variable.Pattern)
+	}
+	identifier := &ast.Identifier{
+		Name: variable.Name,
+	}
+	identifier.Span = ast.Span{From: variable.Idx0(), To: variable.Idx0() + file.Idx(len(variable.Name))}
+	return []*ast.Identifier{identifier}
+}
+
+// parameterNames returns the identifiers bound by the parameters of the function node
+func parameterNames(node *ast.FunctionLiteral) []*ast.Identifier {
+	list := []*ast.Identifier{}
+	for index, identifier := range node.ParameterList {
+		if node.ParameterPatternList != nil && node.ParameterPatternList[index] != nil {
+			list = append(list, boundNames(node.ParameterPatternList[index])...)
+			continue
+		}
+		list = append(list, identifier)
+	}
+	return append(list, boundNames(node.Rest)...)
+}
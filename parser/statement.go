@@ -0,0 +1,1075 @@
+package parser
+
+import (
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+)
+
+func (self *_parser) ParseStatement() ast.Statement {
+
+	if self.matchLexical() || self.Match("class") {
+		panic(self.newSyntaxError(self.Peek(), "Lexical declaration cannot appear in a single-statement context"))
+	}
+
+	switch self.Peek().Kind {
+	case ";":
+		node := &ast.EmptyStatement{}
+		self.markToken(&node.Span, self.Next())
+		return node
+	case "if":
+		return self.ParseIf()
+	case "do":
+		return self.ParseDoWhile()
+	case "while":
+		return self.ParseWhile()
+	case "for":
+		return self.ParseForOrForIn()
+	case "continue":
+		return self.ParseContinue()
+	case "with":
+		return self.ParseWith()
+	case "break":
+		return self.ParseBreak()
+	case "{":
+		return self.ParseBlock()
+	case "var":
+		return self.ParseVariableStatement()
+	case "function":
+		function := self.ParseFunction(true)
+		node := &ast.FunctionStatement{
+			Span:     function.Span,
+			Function: function,
+		}
+		return node
+	case "switch":
+		return self.ParseSwitch()
+	case "return":
+		return self.ParseReturnStatement()
+	case "throw":
+		return self.ParseThrow()
+	case "try":
+		return self.ParseTryCatch()
+	}
+
+	if self.matchAsync("function") {
+		function := self.ParseFunction(true)
+		return &ast.FunctionStatement{
+			Span:     function.Span,
+			Function: function,
+		}
+	}
+
+	expression := self.ParseExpression()
+
+	if identifier, yes := expression.(*ast.Identifier); yes && self.Accept(":") {
+		labelSet := self.Scope().labelSet
+		label := identifier.Name
+		if labelSet[label] {
+			panic(self.newSyntaxError(self.History(-2), "Label '%s' has already been declared", label))
+		}
+		labelSet[label] = true
+		statement := self.ParseStatement()
+		delete(labelSet, label)
+		return &ast.LabelledStatement{
+			Span:      ast.Span{From: identifier.Idx0(), To: statement.Idx1()},
+			Label:     identifier,
+			Statement: statement,
+		}
+	}
+
+	self.ConsumeSemicolon()
+
+	node := &ast.ExpressionStatement{
+		Expression: expression,
+	}
+	self.markNode(&node.Span, expression.Idx0())
+	return node
+}
+
+func (self *_parser) ParseTryCatch() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("try")
+
+	node := &ast.TryStatement{
+		Body: self.ParseBlock(),
+	}
+
+	found := false
+	if self.Match("catch") {
+		catchIdx0 := self.idx0()
+		self.Expect("catch")
+		self.Expect("(")
+		identifier, pattern := self.parseParameter()
+		self.checkStrictName(identifier)
+		self.Expect(")")
+		node.Catch = &ast.CatchStatement{
+			Parameter: identifier,
+			Pattern:   pattern,
+			Body:      self.ParseBlock(),
+		}
+		self.markNode(&node.Catch.Span, catchIdx0)
+		found = true
+	}
+
+	if self.Accept("finally") {
+		node.Finally = self.ParseBlock()
+		found = true
+	}
+
+	if !found {
+		panic(self.newSyntaxError(self.Peek(), "Missing catch or finally after try"))
+	}
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseWith() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("with")
+
+	if self.Scope().Strict {
+		panic(self.newSyntaxError(self.History(-1), "Strict mode code may not include a with statement"))
+	}
+
+	node := &ast.WithStatement{
+		Object: self.ParseExpression(),
+		Body:   self.ParseStatement(),
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseContinue() ast.Statement {
+	idx0 := self.idx0()
+	label := self.ParseContinueBreak("continue")
+	if self.Scope().InIteration {
+		node := &ast.ContinueStatement{
+			Label: label,
+		}
+		self.markNode(&node.Span, idx0)
+		return node
+	}
+	panic(self.newSyntaxError(self.Peek(), "Illegal continue statement"))
+}
+
+func (self *_parser) ParseBreak() ast.Statement {
+	idx0 := self.idx0()
+	label := self.ParseContinueBreak("break")
+	scope := self.Scope()
+	if scope.InIteration || scope.InSwitch {
+		node := &ast.BreakStatement{
+			Label: label,
+		}
+		self.markNode(&node.Span, idx0)
+		return node
+	}
+	panic(self.newSyntaxError(self.Peek(), "Illegal break statement"))
+}
+
+func (self *_parser) ParseContinueBreak(kind string) *ast.Identifier {
+	self.Expect(kind)
+
+	if self.Accept(";") || self.Accept("\n") {
+		return nil
+	}
+
+	var label *ast.Identifier
+	if self.Match("identifier") {
+		label = self.ConsumeIdentifier()
+		if !self.Scope().HasLabel(label.Name) {
+			panic(self.newSyntaxError(self.History(-1), "Undefined label '%s'", label.Name))
+		}
+	}
+
+	self.ConsumeSemicolon()
+
+	return label
+}
+
+func (self *_parser) parseInFunction(parse func()) {
+	in := self.Scope().InFunction
+	self.Scope().InFunction = true
+	defer func() {
+		self.Scope().InFunction = in
+	}()
+	parse()
+}
+
+func (self *_parser) parseInSwitch(parse func()) {
+	in := self.Scope().InSwitch
+	self.Scope().InSwitch = true
+	defer func() {
+		self.Scope().InSwitch = in
+	}()
+	parse()
+}
+
+func (self *_parser) parseInIteration() ast.Statement {
+	in := self.Scope().InIteration
+	self.Scope().InIteration = true
+	defer func() {
+		self.Scope().InIteration = in
+	}()
+	return self.ParseStatement()
+}
+
+func (self *_parser) ParseDoWhile() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("do")
+	body := self.parseInIteration()
+	self.Expect("while")
+	self.Expect("(")
+	test := self.ParseExpression()
+	self.Expect(")")
+
+	node := &ast.DoWhileStatement{
+		Test: test,
+		Body: body,
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseWhile() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("while")
+	self.Expect("(")
+	test := self.ParseExpression()
+	self.Expect(")")
+	body := self.parseInIteration()
+
+	node := &ast.WhileStatement{
+		Test: test,
+		Body: body,
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseIf() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("if")
+	self.Expect("(")
+	node := &ast.IfStatement{
+		Test: self.ParseExpression(),
+	}
+	self.Expect(")")
+	node.Consequent = self.ParseStatement()
+	if self.Accept("else") {
+		node.Alternate = self.ParseStatement()
+	}
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// parseStatementListItem is ParseStatement for a statement in a block (or the body
+// of a program, function, or switch), where a let or const declaration can also appear
+func (self *_parser) parseStatementListItem() ast.Statement {
+	if self.matchLexical() {
+		return self.ParseLexicalStatement()
+	}
+	if self.Match("class") {
+		class := self.ParseClass(true)
+		return &ast.ClassStatement{
+			Span:  class.Span,
+			Class: class,
+		}
+	}
+	return self.ParseStatement()
+}
+
+func (self *_parser) parseStatementUntil(stop func() bool) []ast.Statement {
+	list := []ast.Statement{}
+	for {
+		if stop() {
+			break
+		}
+		list = append(list, self.parseStatementListItem())
+	}
+	return list
+}
+
+// parseSourceElementsUntil is parseStatementUntil for the body of a
+// program or function, where a "use strict" directive in the directive
+// prologue (14.1) makes the rest of the body strict mode code
+func (self *_parser) parseSourceElementsUntil(stop func() bool) []ast.Statement {
+	list := []ast.Statement{}
+	prologue := true
+	for {
+		if stop() {
+			break
+		}
+		statement := self.parseStatementListItem()
+		if prologue {
+			directive, valid := self.directive(statement)
+			if !valid {
+				prologue = false
+			} else if directive == "use strict" {
+				self.Scope().Strict = true
+			}
+		}
+		list = append(list, statement)
+	}
+	return list
+}
+
+// directive returns the source (without quotes) of statement, if
+// statement is a string literal (a possible directive)
+func (self *_parser) directive(statement ast.Statement) (string, bool) {
+	if statement, valid := statement.(*ast.ExpressionStatement); valid {
+		if literal, valid := statement.Expression.(*ast.StringLiteral); valid {
+			// The directive must be exactly "use strict" or 'use strict',
+			// without any escapes, etc., so check the source and not the value
+			source := self.lexer.Source[literal.Idx0()-1 : literal.Idx1()-1]
+			return source[1 : len(source)-1], true
+		}
+	}
+	return "", false
+}
+
+func (self *_parser) ParseBlock() *ast.BlockStatement {
+	idx0 := self.idx0()
+	node := &ast.BlockStatement{}
+
+	self.Expect("{")
+	node.List = self.parseStatementUntil(func() bool {
+		return self.Accept("}")
+	})
+	self.checkLexicalDeclarations(node.List, nil)
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseReturnStatement() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("return")
+
+	if !self.Scope().InFunction {
+		panic(self.newSyntaxError(self.History(-1), "Illegal return statement"))
+	}
+
+	node := &ast.ReturnStatement{}
+
+	if self.Match("\n") {
+		self.markNode(&node.Span, idx0)
+		return node
+	}
+
+	if !self.Match(";") {
+		if !self.Match("}") && !self.Match("EOF") {
+			node.Argument = self.ParseExpression()
+		}
+	}
+
+	self.ConsumeSemicolon()
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseThrow() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("throw")
+
+	if self.Match("\n") {
+		// TODO Better error message
+		panic(self.newSyntaxError(self.Peek(), "Illegal newline after throw"))
+	}
+
+	node := &ast.ThrowStatement{
+		Argument: self.ParseExpression(),
+	}
+
+	self.ConsumeSemicolon()
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseSwitch() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("switch")
+
+	self.Expect("(")
+	node := &ast.SwitchStatement{
+		Discriminant: self.ParseExpression(),
+		Default:      -1,
+	}
+	self.Expect(")")
+
+	self.Expect("{")
+
+	self.parseInSwitch(func() {
+		for index := 0; true; index++ {
+			if self.Accept("}") {
+				break
+			}
+
+			clause := self.ParseCase()
+			if clause.Test == nil {
+				if node.Default != -1 {
+					panic(self.newSyntaxError(self.History(-2), "More than one default clause in switch statement"))
+				}
+				node.Default = index
+			}
+			node.Body = append(node.Body, clause)
+		}
+	})
+
+	// The clauses of a switch are a single block
+	list := []ast.Statement{}
+	for _, clause := range node.Body {
+		list = append(list, clause.Consequent...)
+	}
+	self.checkLexicalDeclarations(list, nil)
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseCase() *ast.CaseStatement {
+	idx0 := self.idx0()
+
+	node := &ast.CaseStatement{}
+	if !self.Accept("default") {
+		self.Expect("case")
+		node.Test = self.ParseExpression()
+	}
+	self.Expect(":")
+
+	node.Consequent = self.parseStatementUntil(func() bool {
+		return false ||
+			self.Match("EOF") ||
+			self.Match("}") ||
+			self.Match("default") ||
+			self.Match("case")
+	})
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseVariable() *ast.VariableExpression {
+	idx0 := self.idx0()
+	node := &ast.VariableExpression{}
+	if self.Match("[") || self.Match("{") {
+		node.Pattern = self.parseBindingTarget()
+	} else {
+		identifier := self.ConsumeIdentifier()
+		self.checkStrictName(identifier)
+		node.Name = identifier.Name
+	}
+
+	if self.Accept("=") {
+		node.Initializer = self.ParseAssignmentExpression()
+	}
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseVariableDeclaration() *ast.VariableStatement {
+	idx0 := self.idx0()
+	self.Expect("var")
+
+	node := &ast.VariableStatement{}
+
+	for {
+		node.List = append(node.List, self.ParseVariable())
+
+		if !self.Accept(",") {
+			break
+		}
+	}
+	self.markNode(&node.Span, idx0)
+
+	self.Scope().Declare(&ast.VariableDeclaration{
+		List: node.List,
+	})
+
+	return node
+}
+
+func (self *_parser) ParseVariableStatement() *ast.VariableStatement {
+
+	node := self.ParseVariableDeclaration()
+	self.checkInitializer("var", node.List)
+
+	self.ConsumeSemicolon()
+
+	self.markNode(&node.Span, node.Idx0())
+	return node
+}
+
+// matchLexical returns true if the next token begins a let or const declaration
+//
+// let is not a keyword (it can be the name of a variable in ES5 code), so it only
+// begins a declaration when it is followed by the name (or pattern) being declared
+func (self *_parser) matchLexical() bool {
+	token := self.Peek()
+	if token.Kind == "const" {
+		return true
+	}
+	if token.Kind != "identifier" || token.Text != "let" {
+		return false
+	}
+	switch self.peekAfter().Kind {
+	case "identifier", "[", "{":
+		return true
+	}
+	return false
+}
+
+func (self *_parser) ParseLexicalDeclaration() *ast.LexicalDeclaration {
+	idx0 := self.idx0()
+
+	node := &ast.LexicalDeclaration{
+		Token: self.Next().Text,
+	}
+
+	for {
+		variable := self.ParseVariable()
+		for _, identifier := range declaredNames(variable) {
+			if identifier.Name == "let" {
+				panic(self.lexer.newSyntaxError(identifier.Idx0(), "let is disallowed as a lexically bound name"))
+			}
+		}
+		node.List = append(node.List, variable)
+
+		if !self.Accept(",") {
+			break
+		}
+	}
+	self.markNode(&node.Span, idx0)
+
+	return node
+}
+
+func (self *_parser) ParseLexicalStatement() *ast.LexicalDeclaration {
+
+	node := self.ParseLexicalDeclaration()
+	self.checkInitializer(node.Token, node.List)
+
+	self.ConsumeSemicolon()
+
+	self.markNode(&node.Span, node.Idx0())
+	return node
+}
+
+// checkInitializer checks that every binding of a const declaration, and every
+// destructuring declaration, has an initializer (except in the head of a for-in loop)
+func (self *_parser) checkInitializer(token string, list []*ast.VariableExpression) {
+	for _, variable := range list {
+		if variable.Initializer != nil {
+			continue
+		}
+		if variable.Pattern != nil {
+			panic(self.lexer.newSyntaxError(variable.Idx1(), "Missing initializer in destructuring declaration"))
+		}
+		if token == "const" {
+			panic(self.lexer.newSyntaxError(variable.Idx1(), "Missing initializer in const declaration"))
+		}
+	}
+}
+
+// checkLexicalDeclarations checks that the let, const, and class declarations of a block
+// (list) do not declare a name that is declared again in the same block, by another
+// let or const, a var, a function, or a parameter (of the function whose body it is)
+func (self *_parser) checkLexicalDeclarations(list []ast.Statement, parameterList []*ast.Identifier) {
+	lexical := map[string]bool{}
+	declare := func(name string, idx file.Idx) {
+		if lexical[name] {
+			panic(self.lexer.newSyntaxError(idx, "Identifier '%s' has already been declared", name))
+		}
+		lexical[name] = true
+	}
+	for _, statement := range list {
+		switch statement := statement.(type) {
+		case *ast.LexicalDeclaration:
+			for _, variable := range statement.List {
+				for _, identifier := range declaredNames(variable) {
+					declare(identifier.Name, identifier.Idx0())
+				}
+			}
+		case *ast.ClassStatement:
+			declare(statement.Class.Name.Name, statement.Class.Name.Idx0())
+		}
+	}
+	if len(lexical) == 0 {
+		return
+	}
+
+	check := func(name string, idx file.Idx) {
+		if lexical[name] {
+			panic(self.lexer.newSyntaxError(idx, "Identifier '%s' has already been declared", name))
+		}
+	}
+	for _, identifier := range parameterList {
+		check(identifier.Name, identifier.Idx0())
+	}
+	for _, statement := range list {
+		switch statement := statement.(type) {
+		case *ast.VariableStatement:
+			for _, variable := range statement.List {
+				for _, identifier := range declaredNames(variable) {
+					check(identifier.Name, identifier.Idx0())
+				}
+			}
+		case *ast.FunctionStatement:
+			check(statement.Function.Name.Name, statement.Function.Name.Idx0())
+		}
+	}
+}
+
+func (self *_parser) ParseFunction(declare bool) *ast.FunctionLiteral {
+	idx0 := self.idx0()
+
+	async := self.matchAsync("function")
+	if async {
+		self.Next()
+	}
+	self.Expect("function")
+
+	node := &ast.FunctionLiteral{
+		Generator: self.Accept("*"),
+		Async:     async,
+	}
+	if node.Async && node.Generator {
+		panic(self.lexer.newSyntaxError(idx0, "Async generator functions are not supported"))
+	}
+
+	if self.Match("identifier") {
+		node.Name = self.ConsumeIdentifier()
+		if declare {
+			self.Scope().Declare(&ast.FunctionDeclaration{
+				Function: node,
+			})
+		}
+	} else if declare {
+		// Trigger a panic, because we really should see
+		// an identifier here
+		self.Expect("identifier")
+	}
+
+	self.parseFunctionRest(node)
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// parseFunctionRest parses the parameters and body of a function, starting at (
+func (self *_parser) parseFunctionRest(node *ast.FunctionLiteral) {
+	token := self.Peek()
+	if token.Kind != "(" {
+		panic(self.Unexpected(token))
+	}
+
+	self.parseParameterList(node)
+	self.parseFunctionBodyOf(node, self.parseFunctionBody)
+}
+
+// parseParameterList parses the parameters of the function node (the ParameterList,
+// along with the ParameterPatternList and Rest, if the parameters are not just names)
+func (self *_parser) parseParameterList(node *ast.FunctionLiteral) {
+	node.ParameterList = []*ast.Identifier{}
+	patternList := []ast.Expression{}
+	patterned := false
+	self.Expect("(")
+	for !self.Accept(")") {
+		if self.Accept("...") {
+			node.Rest = self.parseBindingTarget()
+			if token := self.Peek(); token.Kind != ")" {
+				panic(self.newSyntaxError(token, "Rest parameter must be last formal parameter"))
+			}
+			continue
+		}
+		identifier, pattern := self.parseParameter()
+		if self.Accept("=") {
+			// A default, e.g. function(abc = 1) {}, makes a pattern of the parameter
+			target := pattern
+			if target == nil {
+				target = identifier
+			}
+			pattern = &ast.AssignmentPattern{
+				Target:      target,
+				Initializer: self.ParseAssignmentExpression(),
+			}
+			self.markNode(&pattern.(*ast.AssignmentPattern).Span, target.Idx0())
+			identifier = newParameterPlaceholder(pattern)
+		}
+		node.ParameterList = append(node.ParameterList, identifier)
+		patternList = append(patternList, pattern)
+		if pattern != nil {
+			patterned = true
+		}
+		if !self.Match(")") {
+			self.Expect(",")
+		}
+	}
+	if patterned {
+		node.ParameterPatternList = patternList
+	}
+	if patterned || node.Rest != nil {
+		// Like an arrow function, a function with parameters that are not
+		// just names may never have duplicate parameter names
+		self.checkDuplicateParameters(node)
+	}
+}
+
+// parseParameter parses a parameter (of a function, or a catch clause), returning
+// the parameter, along with its pattern if it is destructured (in which case the
+// parameter is a placeholder with an empty Name)
+func (self *_parser) parseParameter() (*ast.Identifier, ast.Expression) {
+	if self.Match("[") || self.Match("{") {
+		pattern := self.parseBindingTarget()
+		return newParameterPlaceholder(pattern), pattern
+	}
+	return self.ConsumeIdentifier(), nil
+}
+
+// newParameterPlaceholder returns the parameter (with an empty Name) in place of pattern
+func newParameterPlaceholder(pattern ast.Expression) *ast.Identifier {
+	identifier := &ast.Identifier{}
+	identifier.Span = ast.Span{From: pattern.Idx0(), To: pattern.Idx1()}
+	return identifier
+}
+
+// checkDuplicateParameters checks that no two parameters of the function node have
+// the same name
+func (self *_parser) checkDuplicateParameters(node *ast.FunctionLiteral) {
+	seen := map[string]bool{}
+	for _, identifier := range parameterNames(node) {
+		if seen[identifier.Name] {
+			panic(self.lexer.newSyntaxError(identifier.Idx0(), "Duplicate parameter name not allowed in this context"))
+		}
+		seen[identifier.Name] = true
+	}
+}
+
+// parseFunctionBodyOf parses (using parse) the body of the function node, in a scope of its own
+func (self *_parser) parseFunctionBodyOf(node *ast.FunctionLiteral, parse func() *ast.BlockStatement) {
+	{
+		self.EnterScope()
+		defer self.LeaveScope()
+		self.Scope().InGenerator = node.Generator
+		self.Scope().InAsync = node.Async
+		self.parseInFunction(func() {
+			node.Body = parse()
+		})
+		self.checkLexicalDeclarations(node.Body.List, parameterNames(node))
+		node.DeclarationList = self.Scope().DeclarationList
+		node.Strict = self.Scope().Strict
+	}
+
+	if node.Strict {
+		self.checkStrictFunction(node)
+	}
+}
+
+// parseFunctionBody is ParseBlock for the body of a function (see parseSourceElementsUntil)
+func (self *_parser) parseFunctionBody() *ast.BlockStatement {
+	idx0 := self.idx0()
+	node := &ast.BlockStatement{}
+
+	self.Expect("{")
+	node.List = self.parseSourceElementsUntil(func() bool {
+		return self.Accept("}")
+	})
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// ParseClass parses a class declaration (if declare), or a class expression
+func (self *_parser) ParseClass(declare bool) *ast.ClassLiteral {
+	idx0 := self.idx0()
+
+	self.Expect("class")
+
+	// The whole of a class is strict mode code
+	strict := self.Scope().Strict
+	self.Scope().Strict = true
+	defer func() {
+		self.Scope().Strict = strict
+	}()
+
+	node := &ast.ClassLiteral{}
+
+	if self.Match("identifier") {
+		node.Name = self.ConsumeIdentifier()
+		self.checkEvalOrArguments(node.Name)
+	} else if declare {
+		// Trigger a panic, because we really should see
+		// an identifier here
+		self.Expect("identifier")
+	}
+
+	if self.Accept("extends") {
+		node.SuperClass = self.ParseLeftHandSideExpressionAllowCall()
+	}
+
+	self.Expect("{")
+	for !self.Accept("}") {
+		if self.Accept(";") {
+			continue
+		}
+		element := self.parseClassElement(node.SuperClass != nil)
+		if element.Key == "constructor" && element.Computed == nil && !element.Static {
+			if element.Kind != "method" {
+				panic(self.lexer.newSyntaxError(element.Idx0(), "Class constructor may not be an accessor"))
+			}
+			if node.Constructor != nil {
+				panic(self.lexer.newSyntaxError(element.Idx0(), "A class may only have one constructor"))
+			}
+			node.Constructor = element.Value
+			continue
+		}
+		node.Body = append(node.Body, element)
+	}
+
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// parseClassElement parses a method, getter, or setter (static or not) of a class,
+// which extends another if derived (so that the constructor can call super())
+func (self *_parser) parseClassElement(derived bool) *ast.ClassElement {
+	idx0 := self.idx0()
+
+	node := &ast.ClassElement{
+		Kind: "method",
+	}
+
+	// static, get, and set can also be the name of a method, e.g. static() {}
+	node.Static = self.acceptModifier("static")
+	generator, async := false, false
+	if self.acceptModifier("get") {
+		node.Kind = "get"
+	} else if self.acceptModifier("set") {
+		node.Kind = "set"
+	} else {
+		async = self.acceptModifier("async") // async abc() {}
+		generator = self.Accept("*")         // *abc() {}
+	}
+	if async && generator {
+		panic(self.lexer.newSyntaxError(idx0, "Async generator functions are not supported"))
+	}
+	node.Key, node.Computed = self.parsePropertyKey()
+	if node.Static && node.Key == "prototype" {
+		panic(self.lexer.newSyntaxError(idx0, "Classes may not have a static property named 'prototype'"))
+	}
+
+	function := &ast.FunctionLiteral{
+		Generator: generator,
+		Async:     async,
+	}
+	functionIdx0 := self.idx0()
+	constructor := node.Key == "constructor" && node.Computed == nil && !node.Static
+	if constructor && generator {
+		panic(self.lexer.newSyntaxError(idx0, "Class constructor may not be a generator"))
+	}
+	if constructor && async {
+		panic(self.lexer.newSyntaxError(idx0, "Class constructor may not be an async method"))
+	}
+	self.parseMethodRest(function, constructor && derived)
+	self.markNode(&function.Span, functionIdx0)
+
+	switch {
+	case node.Kind == "get" && len(function.ParameterList) != 0:
+		panic(self.lexer.newSyntaxError(functionIdx0, "Getter must not have any formal parameters."))
+	case node.Kind == "set" && len(function.ParameterList) != 1:
+		panic(self.lexer.newSyntaxError(functionIdx0, "Setter must have exactly one formal parameter."))
+	}
+
+	node.Value = function
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// parseMethodRest is parseFunctionRest for a method (of a class or an object literal), where super.abc
+// can be used, along with super() (if superCall)
+func (self *_parser) parseMethodRest(node *ast.FunctionLiteral, superCall bool) {
+	token := self.Peek()
+	if token.Kind != "(" {
+		panic(self.Unexpected(token))
+	}
+
+	self.parseParameterList(node)
+	self.parseFunctionBodyOf(node, func() *ast.BlockStatement {
+		self.Scope().AllowSuper = true
+		self.Scope().AllowSuperCall = superCall
+		return self.parseFunctionBody()
+	})
+}
+
+// checkStrictFunction checks the name and parameters of a strict mode function (13.1),
+// which are parsed before it is known whether the function is strict or not
+func (self *_parser) checkStrictFunction(node *ast.FunctionLiteral) {
+	if node.Name != nil {
+		self.checkEvalOrArguments(node.Name)
+	}
+	seen := map[string]bool{}
+	for _, identifier := range parameterNames(node) {
+		self.checkEvalOrArguments(identifier)
+		if seen[identifier.Name] {
+			panic(self.lexer.newSyntaxError(identifier.Idx0(), "Strict mode function may not have duplicate parameter names"))
+		}
+		seen[identifier.Name] = true
+	}
+}
+
+func (self *_parser) parseForIn(idx0 file.Idx, into ast.Expression) *ast.ForInStatement {
+
+	// Already have consumed "<into> in"
+
+	source := self.ParseExpression()
+	self.Expect(")")
+
+	node := &ast.ForInStatement{
+		Into:   into,
+		Source: source,
+		Body:   self.parseInIteration(),
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) parseForOf(idx0 file.Idx, into ast.Expression) *ast.ForOfStatement {
+
+	// Already have consumed "<into> of"
+
+	source := self.ParseAssignmentExpression()
+	self.Expect(")")
+
+	node := &ast.ForOfStatement{
+		Into:   into,
+		Source: source,
+		Body:   self.parseInIteration(),
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+// acceptInOrOf accepts the "in" of a for-in loop, or the "of" of a for-of loop
+// (which is not a keyword), returning which (or "" for neither)
+func (self *_parser) acceptInOrOf() string {
+	if self.Accept("in") {
+		return "in"
+	}
+	if token := self.Peek(); token.Kind == "identifier" && token.Text == "of" {
+		self.Next()
+		return "of"
+	}
+	return ""
+}
+
+func (self *_parser) parseFor(idx0 file.Idx, initializer ast.Node) *ast.ForStatement {
+
+	// Already have consumed "<initializer> ;"
+
+	var test, update ast.Expression
+
+	if !self.Match(";") {
+		test = self.ParseExpression()
+	}
+	self.Expect(";")
+
+	if !self.Match(")") {
+		update = self.ParseExpression()
+	}
+	self.Expect(")")
+
+	node := &ast.ForStatement{
+		Initializer: initializer,
+		Test:        test,
+		Update:      update,
+		Body:        self.parseInIteration(),
+	}
+	self.markNode(&node.Span, idx0)
+	return node
+}
+
+func (self *_parser) ParseForOrForIn() ast.Statement {
+	idx0 := self.idx0()
+	self.Expect("for")
+	self.Expect("(")
+
+	var left ast.Node
+	var into ast.Expression
+
+	kind := "" // "in" or "of", for a for-in or for-of loop
+	declaration := ""
+	if !self.Match(";") {
+		previousAllowIn := self.Scope().AllowIn
+		self.Scope().AllowIn = false
+		if self.Match("var") {
+			statement := self.ParseVariableDeclaration()
+			if len(statement.List) == 1 {
+				kind = self.acceptInOrOf()
+			}
+			if kind != "" {
+				// We only want (there should be only) one declaration
+				// (12.2 Variable Statement)
+				into = statement.List[0]
+				declaration = "var"
+				if kind == "of" && statement.List[0].Initializer != nil {
+					panic(self.lexer.newSyntaxError(statement.List[0].Idx0(), "for-of loop variable declaration may not have an initializer"))
+				}
+			} else {
+				self.checkInitializer("var", statement.List)
+				left = statement
+			}
+		} else if self.matchLexical() {
+			statement := self.ParseLexicalDeclaration()
+			if len(statement.List) == 1 {
+				kind = self.acceptInOrOf()
+			}
+			if kind != "" {
+				into = statement.List[0]
+				declaration = statement.Token
+				if statement.List[0].Initializer != nil {
+					panic(self.lexer.newSyntaxError(statement.List[0].Idx0(), "for-%s loop variable declaration may not have an initializer", kind))
+				}
+			} else {
+				self.checkInitializer(statement.Token, statement.List)
+				self.checkLexicalDeclarations([]ast.Statement{statement}, nil)
+				left = statement
+			}
+		} else {
+			expression := self.ParseExpression()
+			if kind = self.acceptInOrOf(); kind != "" {
+				into = expression
+				if pattern := self.toPattern(expression); pattern != nil {
+					into = pattern // e.g. for ([ abc, def ] in ...)
+				}
+			} else {
+				left = expression
+			}
+		}
+		self.Scope().AllowIn = previousAllowIn
+	}
+
+	if kind == "" {
+		self.Expect(";")
+		return self.parseFor(idx0, left)
+	} else {
+		switch into.(type) {
+		case *ast.Identifier, *ast.DotExpression, *ast.BracketExpression, *ast.VariableExpression,
+			*ast.ArrayPattern, *ast.ObjectPattern:
+		default:
+			panic(self.newSyntaxError(self.History(-1), "Invalid left-hand side in for-%s", kind))
+		}
+	}
+
+	if kind == "of" {
+		node := self.parseForOf(idx0, into)
+		node.Declaration = declaration
+		return node
+	}
+	node := self.parseForIn(idx0, into)
+	node.Declaration = declaration
+	return node
+}
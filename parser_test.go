@@ -1148,49 +1148,43 @@ Unexpected token string
 1:-:-
 	`)
 
-	// TODO Incorrect cursor position
 	test(`/*
 ---
 Unexpected token ILLEGAL
-0:-:-
+1:-:-
 	`)
 
-	// TODO Incorrect cursor position
 	test(`/*
 
 
 
 ---
 Unexpected token ILLEGAL
-0:-:-
+1:-:-
 	`)
 
-	// TODO Incorrect cursor position
 	test(`/**
 ---
 Unexpected token ILLEGAL
-0:-:-
+1:-:-
 	`)
 
-	// TODO Incorrect cursor position
 	test("/*\n\n*", `
 ---
 Unexpected token ILLEGAL
-0:-:-
+1:-:-
 	`)
 
-	// TODO Incorrect cursor position
 	test(`/*hello
 ---
 Unexpected token ILLEGAL
-0:-:-
+1:-:-
 	`)
 
-	// TODO Incorrect cursor position
 	test(`/*hello  *
 ---
 Unexpected token ILLEGAL
-0:-:-
+1:-:-
 	`)
 
 	test("\n]", `
@@ -1425,14 +1419,14 @@ Label '_' has already been declared
 	/*/
 	---
 	Unexpected token ILLEGAL
-	0:0:0
+	2:2:3
 	`)
 
 	test(`
 	/*/.source
 	---
 	Unexpected token ILLEGAL
-	0:0:0
+	2:2:3
 	`)
 
 	test("/\\1/.source", "---\nInvalid regular expression: invalid escape sequence: `\\1`\n-:-:-")
@@ -1441,22 +1435,52 @@ Label '_' has already been declared
     var class
     ---
     Unexpected reserved word
-    2:14:15
+    2:9:10
 	`)
 
 	test(`
     object Object
     ---
 	Unexpected token Object
-    2:18:19
+    2:12:13
     `)
 
 	test(`
     [object Object]
     ---
 	Unexpected token Object
-    2:19:20
+    2:13:14
     `)
+
+	test(`({ get abc(def) {} })
+---
+Getter must not have any formal parameters.
+1:-:-
+	`)
+
+	test(`({ set abc() {} })
+---
+Setter must have exactly one formal parameter.
+1:-:-
+	`)
+
+	test(`({ abc: 1, get abc() {} })
+---
+Object literal may not have data and accessor property with the same name
+1:-:-
+	`)
+
+	test(`({ get abc() {}, get abc() {} })
+---
+Object literal may not have multiple get/set accessors with the same name
+1:-:-
+	`)
+
+	test(`"use strict"; ({ abc: 1, abc: 2 })
+---
+Duplicate data property in object literal not allowed in strict mode
+1:-:-
+	`)
 }
 
 func TestParseComment(t *testing.T) {
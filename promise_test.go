@@ -0,0 +1,156 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestPromise(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	// Reactions run after the script, in order
+	test(`
+        var abc = [];
+        var def = new Promise(function(resolve, reject){
+            abc.push("executor");
+            resolve(1);
+        });
+        def.then(function(value){
+            abc.push("then " + value);
+            return value + 1;
+        }).then(function(value){
+            abc.push("then " + value);
+        });
+        Promise.resolve().then(function(){ abc.push("resolve") });
+        abc.push("script");
+        abc.length;
+    `, "2")
+	test(`abc`, "executor,script,then 1,resolve,then 2")
+
+	test(`
+        var abc = [];
+        new Promise(function(){
+            throw new Error("xyzzy");
+        }).then(function(){
+            abc.push("fulfilled");
+        }).catch(function(error){
+            abc.push(error.message);
+            throw "nothing happens";
+        }).finally(function(){
+            abc.push("finally");
+            return "ignored";
+        }).then(null, function(reason){
+            abc.push(reason);
+        });
+    `, "[object Promise]")
+	test(`abc`, "xyzzy,finally,nothing happens")
+
+	// Thenables, and a promise resolved with itself
+	test(`
+        var abc = [];
+        Promise.resolve({ then: function(resolve){ resolve("thenable") } }).then(function(value){
+            abc.push(value);
+        });
+        var ghi;
+        var def = new Promise(function(resolve){ ghi = resolve });
+        ghi(def);
+        def.catch(function(error){
+            abc.push(error instanceof TypeError);
+        });
+        Promise.reject(1).then(null, function(reason){
+            abc.push(reason);
+        });
+    `, "[object Promise]")
+	test(`abc`, "true,1,thenable")
+
+	test(`
+        var abc = Promise.resolve(1);
+        [ Promise.resolve(abc) === abc, Object.prototype.toString.call(abc), typeof Promise.prototype.then ];
+    `, "true,[object Promise],function")
+
+	test(`raise: Promise(function(){})`, "TypeError: Promise constructor cannot be invoked without 'new'")
+	test(`raise: new Promise(1)`, "TypeError: Promise resolver 1 is not a function")
+	test(`raise: Promise.prototype.then.call({})`, "TypeError: Promise.prototype.then called on a non-promise")
+}
+
+func TestPromise_combinator(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = {};
+        var def = new Promise(function(resolve){ abc.resolve = resolve });
+        Promise.all([ 1, Promise.resolve(2), def ]).then(function(value){
+            abc.all = value;
+        });
+        Promise.all([]).then(function(value){
+            abc.empty = value.length;
+        });
+        Promise.all([ 1, Promise.reject("xyzzy") ]).catch(function(reason){
+            abc.rejected = reason;
+        });
+        Promise.race([ def, Promise.resolve("race") ]).then(function(value){
+            abc.race = value;
+        });
+        Promise.allSettled([ 1, Promise.reject("xyzzy") ]).then(function(value){
+            abc.allSettled = JSON.stringify(value);
+        });
+        Promise.all(null).catch(function(error){
+            abc.error = error instanceof TypeError;
+        });
+    `, "[object Promise]")
+	test(`[ abc.all, abc.empty, abc.rejected, abc.race, abc.error ]`, ",0,xyzzy,race,true")
+	test(`abc.allSettled`, `[{"status":"fulfilled","value":1},{"reason":"xyzzy","status":"rejected"}]`)
+
+	test(`abc.resolve(3)`, "undefined")
+	test(`abc.all`, "1,2,3")
+}
+
+func TestPromise_Go(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+	promise, resolve, reject := Otto.NewPromise()
+	Otto.Set("abc", promise)
+
+	_, err := Otto.Run(`
+        var def;
+        abc.then(function(value){
+            def = value;
+        });
+    `)
+	Is(err, nil)
+
+	done := make(chan bool)
+	go func() {
+		resolve(map[string]interface{}{"xyzzy": "Nothing happens."})
+		reject("ignored")
+		done <- true
+	}()
+	<-done
+
+	value, _ := Otto.Get("def")
+	Is(value, "undefined")
+
+	err = Otto.RunJobs()
+	Is(err, nil)
+	value, _ = Otto.Run(`def.xyzzy`)
+	Is(value, "Nothing happens.")
+
+	{
+		// The jobs are run at the end of a Call, as well
+		promise, _, reject := Otto.NewPromise()
+		Otto.Set("abc", promise)
+		reject("xyzzy")
+		value, err := Otto.Call(`(function(){
+            abc.catch(function(reason){ def = reason });
+        })`, nil)
+		Is(err, nil)
+		Is(value, "undefined")
+		value, _ = Otto.Get("def")
+		Is(value, "xyzzy")
+	}
+}
@@ -218,3 +218,62 @@ func (self *_runtime) fromPropertyDescriptor(descriptor _property) *_object {
 	object.defineProperty("configurable", toValue_bool(descriptor.configurable()), 0111, false)
 	return object
 }
+
+// fromPartialPropertyDescriptor is fromPropertyDescriptor for a descriptor that may
+// be missing fields (as from toPropertyDescriptor), which are left out of the object
+func (self *_runtime) fromPartialPropertyDescriptor(descriptor _property) *_object {
+	object := self.newObject()
+	switch value := descriptor.value.(type) {
+	case Value:
+		if !value.isEmpty() {
+			object.defineProperty("value", value, 0111, false)
+		}
+	case _propertyGetSet:
+		for index, name := range []string{"get", "set"} {
+			switch value[index] {
+			case nil:
+			case &_nilGetSetObject:
+				object.defineProperty(name, UndefinedValue(), 0111, false)
+			default:
+				object.defineProperty(name, toValue_object(value[index]), 0111, false)
+			}
+		}
+	}
+	if descriptor.writeSet() {
+		object.defineProperty("writable", toValue_bool(descriptor.writable()), 0111, false)
+	}
+	if descriptor.enumerateSet() {
+		object.defineProperty("enumerable", toValue_bool(descriptor.enumerable()), 0111, false)
+	}
+	if descriptor.configureSet() {
+		object.defineProperty("configurable", toValue_bool(descriptor.configurable()), 0111, false)
+	}
+	return object
+}
+
+// completePropertyDescriptor fills in the fields that are missing from descriptor
+// (as from toPropertyDescriptor) with their defaults, so that it describes a property
+func completePropertyDescriptor(descriptor *_property) {
+	if getSet, isAccessor := descriptor.value.(_propertyGetSet); isAccessor {
+		for index := range getSet {
+			if getSet[index] == &_nilGetSetObject {
+				getSet[index] = nil
+			}
+		}
+		descriptor.value = getSet
+		descriptor.writeClear() // An accessor is neither writable nor not
+	} else {
+		if value, valid := descriptor.value.(Value); !valid || value.isEmpty() {
+			descriptor.value = UndefinedValue()
+		}
+		if !descriptor.writeSet() {
+			descriptor.writeOff()
+		}
+	}
+	if !descriptor.enumerateSet() {
+		descriptor.enumerateOff()
+	}
+	if !descriptor.configureSet() {
+		descriptor.configureOff()
+	}
+}
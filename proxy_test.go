@@ -0,0 +1,332 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = [];
+        var def = { ghi: 1 };
+        var jkl = new Proxy(def, {
+            get: function(target, name, receiver) {
+                abc.push("get " + name);
+                return name in target ? target[name] : "xyzzy";
+            },
+            set: function(target, name, value, receiver) {
+                abc.push("set " + name);
+                if (typeof value !== "number") {
+                    return false;
+                }
+                target[name] = value;
+                return true;
+            },
+            has: function(target, name) {
+                abc.push("has " + name);
+                return name !== "ghi";
+            },
+            deleteProperty: function(target, name) {
+                abc.push("delete " + name);
+                return delete target[name];
+            },
+        });
+        jkl.mno = 2;
+        jkl.pqr = "3";
+        [ jkl.ghi, jkl.mno, jkl.pqr, "ghi" in jkl, "stu" in jkl, delete jkl.mno, def.mno, def.pqr ];
+    `, "1,2,xyzzy,false,true,true,,")
+	test(`abc`, "set mno,set pqr,get ghi,get mno,get pqr,has ghi,has stu,delete mno")
+
+	// An operation without a trap is passed on to the target
+	test(`
+        var abc = {};
+        var def = new Proxy(abc, {});
+        def.ghi = 1;
+        Object.defineProperty(def, "jkl", { value: 2, enumerable: false });
+        [ abc.ghi, abc.jkl, Object.keys(def), Object.getOwnPropertyNames(def), Object.getPrototypeOf(def) === Object.prototype, def instanceof Object ].join(";");
+    `, "1;2;ghi;ghi,jkl;true;true")
+
+	test(`
+        var abc = new Proxy({}, {
+            ownKeys: function(target) {
+                return [ "ghi", "def", Symbol.iterator ];
+            },
+            getOwnPropertyDescriptor: function(target, name) {
+                return { value: name, enumerable: name !== "def", configurable: true };
+            },
+        });
+        var jkl = [];
+        for (var mno in abc) {
+            jkl.push(mno);
+        }
+        [ jkl, Object.keys(abc), Object.getOwnPropertyNames(abc), Object.getOwnPropertySymbols(abc).length, Reflect.ownKeys(abc).length ].join(";");
+    `, "ghi;ghi;ghi,def;1;3")
+
+	test(`
+        var abc = [];
+        function def(ghi, jkl) {
+            return this.mno + ghi + jkl;
+        }
+        var pqr = new Proxy(def, {
+            apply: function(target, that, argumentList) {
+                abc.push(that === undefined, argumentList.length);
+                return target.apply({ mno: 1 }, argumentList) * 10;
+            },
+            construct: function(target, argumentList, newTarget) {
+                abc.push(newTarget === pqr);
+                return { stu: argumentList[0] };
+            },
+        });
+        [ typeof pqr, pqr.call(undefined, 2, 3), new pqr(4).stu, abc ].join(";");
+    `, "function;60;4;true,2,true")
+
+	// A class (or constructor) without a construct trap
+	test(`
+        class Abc {
+            constructor(def) {
+                this.def = def;
+            }
+        }
+        var ghi = new Proxy(Abc, {
+            get: function(target, name) {
+                return name === "jkl" ? "xyzzy" : target[name];
+            },
+        });
+        var mno = new ghi(1);
+        [ mno.def, mno instanceof Abc, ghi.jkl, new (new Proxy(Array, {}))(3).length ].join(",");
+    `, "1,true,xyzzy,3")
+
+	test(`
+        var abc = Proxy.revocable({ def: 1 }, {});
+        var ghi = abc.proxy.def;
+        abc.revoke();
+        var jkl;
+        try {
+            abc.proxy.def;
+        } catch (error) {
+            jkl = error.message;
+        }
+        [ ghi, jkl ].join(",");
+    `, "1,Cannot perform 'get' on a proxy that has been revoked")
+
+	test(`
+        var abc = new Proxy([ 1, 2 ], {});
+        [ Array.isArray(abc), Array.isArray(new Proxy({}, {})), abc.length, abc[1] ].join(",");
+    `, "true,false,2,2")
+
+	// A proxy of an array is an array to JSON.stringify, and to concat
+	test(`
+        var abc = new Proxy([ 1, 2 ], {});
+        [
+            JSON.stringify(abc),
+            JSON.stringify({ def: abc }),
+            JSON.stringify({ def: 1, ghi: 2 }, new Proxy([ "ghi" ], {})),
+            [].concat(abc).length,
+            [ 0 ].concat(abc, [ 3 ]).join(),
+        ].join(";");
+    `, `[1,2];{"def":[1,2]};{"ghi":2};2;0,1,2,3`)
+
+	// A proxy in the prototype chain of an object
+	test(`
+        var abc = new Proxy({ def: 1 }, {});
+        var ghi = Object.create(abc);
+        [ ghi.def, "def" in ghi ].join(",");
+    `, "1,true")
+
+	// ...which is passed the object as the receiver
+	test(`
+        var abc = [];
+        var def = Object.create(new Proxy({}, {
+            get: function(target, name, receiver) {
+                return name === "mno" ? receiver.ghi : Reflect.get(target, name, receiver);
+            },
+            set: function(target, name, value, receiver) {
+                abc.push(name + "=" + value, receiver === def);
+                return true;
+            },
+            has: function(target, name) {
+                abc.push("has " + name);
+                return true;
+            },
+        }));
+        Object.defineProperty(def, "ghi", { value: "xyzzy", writable: true });
+        def.jkl = 1;
+        def.ghi = "Nothing happens.";
+        [ def.mno, def.hasOwnProperty("jkl"), "pqr" in def, abc ].join(";");
+    `, "Nothing happens.;false;true;jkl=1,true,has pqr")
+
+	// An assignment without a set trap defines the property on the proxy
+	test(`
+        var abc = [];
+        var def = {};
+        var ghi = new Proxy(def, {
+            defineProperty: function(target, name, descriptor) {
+                abc.push(name, JSON.stringify(descriptor));
+                return Reflect.defineProperty(target, name, descriptor);
+            },
+        });
+        ghi.jkl = 1;
+        ghi.jkl = 2;
+        [ def.jkl, abc ].join(";");
+    `, `2;jkl,{"configurable":true,"enumerable":true,"value":1,"writable":true},jkl,{"value":2}`)
+
+	test(`
+        var abc = Object.create(new Proxy({}, {}));
+        abc.def = 1;
+        [ abc.hasOwnProperty("def"), Object.getPrototypeOf(abc).hasOwnProperty("def") ].join(",");
+    `, "true,false")
+
+	test(`
+        [
+            Object.prototype.toString.call(new Proxy([], {})),
+            Object.prototype.toString.call(new Proxy({}, {})),
+            Object.prototype.toString.call(new Proxy(function() {}, {})),
+        ].join(",");
+    `, "[object Array],[object Object],[object Function]")
+
+	test(`raise: Proxy({}, {})`, "TypeError: Constructor Proxy requires 'new'")
+
+	test(`raise: new Proxy({}, 1)`, "TypeError: Cannot create proxy with a non-object as target or handler")
+
+	test(`raise: new Proxy({}, { get: 1 }).abc`, "TypeError: 'get' on proxy: trap 1 is not a function")
+
+	test(`raise:
+        "use strict";
+        var abc = new Proxy({}, { set: function() { return false } });
+        abc.def = 1;
+    `, "TypeError: Cannot set property def of a proxy")
+
+	// A proxy cannot report what the target does not allow
+	test(`raise:
+        var abc = {};
+        Object.defineProperty(abc, "def", { value: 1 });
+        new Proxy(abc, { get: function() { return 2 } }).def;
+    `, "TypeError: 'get' on proxy: property def is read-only and non-configurable on the proxy target, but the proxy did not return its value")
+
+	test(`raise:
+        var abc = Object.freeze({ def: 1 });
+        Object.keys(new Proxy(abc, { ownKeys: function() { return [] } }));
+    `, "TypeError: 'ownKeys' on proxy: trap result did not include def")
+
+	test(`raise:
+        Object.isExtensible(new Proxy({}, { isExtensible: function() { return false } }));
+    `, "TypeError: 'isExtensible' on proxy: trap result does not reflect extensibility of proxy target (which is 'true')")
+
+	test(`raise:
+        var abc = {};
+        Object.preventExtensions(abc);
+        new Proxy(abc, { has: function() { return true } });
+        Object.getOwnPropertyDescriptor(new Proxy(abc, { getOwnPropertyDescriptor: function() { return { value: 1, configurable: true } } }), "def");
+    `, "TypeError: 'getOwnPropertyDescriptor' on proxy: trap returned a descriptor for property def, which the non-extensible proxy target does not have")
+}
+
+func TestProxyReflect(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = { def: 1 };
+        [
+            Reflect.has(abc, "def"),
+            Reflect.get(abc, "def"),
+            Reflect.set(abc, "ghi", 2),
+            abc.ghi,
+            Reflect.deleteProperty(abc, "ghi"),
+            Reflect.defineProperty(abc, "jkl", { value: 3 }),
+            Reflect.defineProperty(abc, "jkl", { value: 4 }),
+            Reflect.set(abc, "jkl", 5),
+            Reflect.getOwnPropertyDescriptor(abc, "jkl").writable,
+            Reflect.ownKeys(abc),
+        ].join(";");
+    `, "true;1;true;2;true;true;false;false;false;def,jkl")
+
+	test(`
+        var abc = {
+            get def() {
+                return this.ghi;
+            },
+            set def(value) {
+                this.ghi = value;
+            },
+        };
+        var jkl = { ghi: 1 };
+        Reflect.set(abc, "def", 2, jkl);
+        [ Reflect.get(abc, "def", jkl), jkl.ghi, abc.ghi ].join(",");
+    `, "2,2,")
+
+	test(`
+        function Abc(def, ghi) {
+            this.jkl = def + ghi;
+        }
+        function Mno() {}
+        var pqr = Reflect.construct(Abc, [ 1, 2 ], Mno);
+        [ Reflect.apply(Math.max, null, [ 1, 3, 2 ]), pqr.jkl, Reflect.getPrototypeOf(pqr) === Mno.prototype ].join(",");
+    `, "3,3,true")
+
+	test(`
+        var abc = {};
+        var def = Object.create(null);
+        [
+            Reflect.setPrototypeOf(abc, def),
+            Reflect.getPrototypeOf(abc) === def,
+            Reflect.setPrototypeOf(def, abc),
+            Reflect.isExtensible(abc),
+            Reflect.preventExtensions(abc),
+            Reflect.isExtensible(abc),
+            Reflect.setPrototypeOf(abc, null),
+            Object.prototype.toString.call(Reflect),
+        ].join(",");
+    `, "true,true,false,true,true,false,false,[object Reflect]")
+
+	// Reflect passes the arguments of a trap on to the target
+	test(`
+        var abc = [];
+        var def = { ghi: 1 };
+        var jkl = new Proxy(def, {
+            get: function(target, name, receiver) {
+                abc.push(name);
+                return Reflect.get(target, name, receiver);
+            },
+            set: function(target, name, value, receiver) {
+                abc.push(name + "=" + value);
+                return Reflect.set(target, name, value, receiver);
+            },
+        });
+        jkl.mno = jkl.ghi + 1;
+        [ def.mno, abc ].join(";");
+    `, "2;ghi,mno=2")
+
+	test(`raise: Reflect.get(1, "abc")`, "TypeError: Reflect.get called on non-object")
+
+	test(`raise: Reflect.construct(Math.max, [])`, "TypeError: [function] is not a constructor")
+}
+
+func TestProxyClone(t *testing.T) {
+	Terst(t)
+
+	vm := New()
+	_, err := vm.Run(`
+        var abc = { def: 1 };
+        var ghi = new Proxy(abc, {
+            get: function(target, name) {
+                return target[name] * 10;
+            },
+        });
+    `)
+	Is(err, nil)
+
+	clone := vm.Copy()
+	value, err := clone.Run(`abc.def = 2; ghi.def`)
+	Is(err, nil)
+	Is(value, "20")
+
+	// The original is unaffected
+	value, err = vm.Run(`ghi.def`)
+	Is(err, nil)
+	Is(value, "10")
+}
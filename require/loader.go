@@ -0,0 +1,55 @@
+package require
+
+import (
+	"io/fs"
+)
+
+// ModuleLoader loads the source of modules for require.
+//
+// A path is slash-separated and relative to the root of the loader, without a
+// leading slash (e.g. "lib/abc.js"), like the paths of an fs.FS. If there is
+// no file at path (or it is a directory), then Load should return an error
+// for which errors.Is(err, fs.ErrNotExist) is true, so that require can go on
+// to look elsewhere (e.g. path + ".js").
+type ModuleLoader interface {
+	Load(path string) ([]byte, error)
+}
+
+// FSLoader returns a ModuleLoader that loads modules from fsys, e.g.
+//
+//	registry := require.NewRegistry(require.FSLoader(os.DirFS("scripts")))
+func FSLoader(fsys fs.FS) ModuleLoader {
+	return _fsLoader{fsys}
+}
+
+type _fsLoader struct {
+	fsys fs.FS
+}
+
+func (self _fsLoader) Load(path string) ([]byte, error) {
+	info, err := fs.Stat(self.fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "load", Path: path, Err: fs.ErrNotExist}
+	}
+	return fs.ReadFile(self.fsys, path)
+}
+
+// MapLoader is an in-memory ModuleLoader, which maps the path of each module
+// to its source, e.g.
+//
+//	registry := require.NewRegistry(require.MapLoader{
+//	    "main.js": `module.exports = require("./lib/abc")`,
+//	    "lib/abc.js": `exports.abc = 1`,
+//	})
+type MapLoader map[string]string
+
+func (self MapLoader) Load(path string) ([]byte, error) {
+	source, exists := self[path]
+	if !exists {
+		return nil, &fs.PathError{Op: "load", Path: path, Err: fs.ErrNotExist}
+	}
+	return []byte(source), nil
+}
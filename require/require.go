@@ -0,0 +1,308 @@
+/*
+Package require implements CommonJS modules (require, module, and exports) for otto.
+
+	registry := require.NewRegistry(require.FSLoader(os.DirFS("scripts")))
+	registry.Register("greeting", func(vm *otto.Otto, module *otto.Object) {
+	    exports, _ := module.Get("exports")
+	    exports.Object().Set("hello", "Hello, World.")
+	})
+
+	Otto := otto.New()
+	modules := registry.Enable(Otto)
+	value, err := modules.Require("./main")
+
+	// Or, from JavaScript
+	value, err = Otto.Run(`require("./main")`)
+
+Modules are resolved like Node: a name beginning with "./", "../", or "/" is resolved
+(relative to the requiring module) as a file (trying name, name.js, and name.json), and
+then as a directory (using the main of its package.json, or index.js or index.json). Any
+other name is either a registered (Go) module, or is looked for in the node_modules
+directories above the requiring module.
+
+Each runtime has its own cache of modules, so every module is run (at most) once per
+runtime, and a cycle of requires gets the exports of the module that has not finished
+yet (as they are so far).
+*/
+package require
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+// NativeModule is a module implemented in Go, which sets up its exports using module
+// (e.g. module.exports, or the properties of the exports object).
+type NativeModule func(vm *otto.Otto, module *otto.Object)
+
+// Registry is a set of modules: those loaded by its ModuleLoader, along with the
+// registered (Go) modules.
+type Registry struct {
+	loader ModuleLoader
+	native map[string]NativeModule
+}
+
+// NewRegistry returns a registry that loads modules using loader (which may be
+// nil, in which case there are only registered modules).
+func NewRegistry(loader ModuleLoader) *Registry {
+	return &Registry{
+		loader: loader,
+		native: map[string]NativeModule{},
+	}
+}
+
+// Register registers the (Go) module with the given name, which can then be
+// required by name, e.g. require("name").
+func (self *Registry) Register(name string, module NativeModule) {
+	self.native[name] = module
+}
+
+// Modules are the modules of a runtime, see Registry.Enable.
+type Modules struct {
+	registry *Registry
+	vm       *otto.Otto
+	require  otto.Value // The require of the top-level
+	cache    map[string]*otto.Object
+}
+
+// Enable installs require in the global object of vm, returning the modules of vm.
+func (self *Registry) Enable(vm *otto.Otto) *Modules {
+	modules := &Modules{
+		registry: self,
+		vm:       vm,
+		cache:    map[string]*otto.Object{},
+	}
+	modules.require = modules.newRequire(".")
+	vm.Set("require", modules.require)
+	return modules
+}
+
+// Require requires the module with the given name (relative to the root of the
+// loader), returning its exports, like require(name) at the top-level.
+func (self *Modules) Require(name string) (otto.Value, error) {
+	return self.vm.Call(self.require, nil, name)
+}
+
+// newRequire returns a require for the modules in directory
+func (self *Modules) newRequire(directory string) otto.Value {
+	value, _ := self.vm.ToValue(func(call otto.FunctionCall) otto.Value {
+		name, _ := call.Argument(0).ToString()
+		if !call.Argument(0).IsString() || name == "" {
+			self.throw("TypeError", "The module name must be a non-empty string", "")
+		}
+		return self.requireModule(directory, name)
+	})
+	return value
+}
+
+func (self *Modules) requireModule(directory string, name string) otto.Value {
+	key, module := "", NativeModule(nil)
+	source := []byte(nil)
+	if native, exists := self.registry.native[name]; exists && !isPath(name) {
+		key, module = ":"+name, native // Not a valid path, so no conflict
+	} else {
+		key, source = self.resolve(directory, name)
+		if key == "" {
+			self.throw("Error", fmt.Sprintf("Cannot find module '%s'", name), "MODULE_NOT_FOUND")
+		}
+	}
+
+	if cached, exists := self.cache[key]; exists {
+		exports, _ := cached.Get("exports")
+		return exports
+	}
+
+	object, _ := self.vm.Object(`({ exports: {}, loaded: false })`)
+	object.Set("id", key)
+	if module == nil {
+		object.Set("filename", key)
+	}
+	self.cache[key] = object
+
+	err := error(nil)
+	if module != nil {
+		err = self.runNative(module, object)
+	} else {
+		err = self.run(key, source, object)
+	}
+	if err != nil {
+		delete(self.cache, key) // Try again, the next time
+		self.rethrow(err)
+	}
+
+	object.Set("loaded", true)
+	exports, _ := object.Get("exports")
+	return exports
+}
+
+func (self *Modules) runNative(module NativeModule, object *otto.Object) (err error) {
+	defer func() {
+		if caught := recover(); caught != nil {
+			if value, ok := caught.(otto.Value); ok {
+				err = &nativeError{value}
+				return
+			}
+			panic(caught)
+		}
+	}()
+	module(self.vm, object)
+	return nil
+}
+
+// nativeError is a value thrown by a NativeModule
+type nativeError struct {
+	value otto.Value
+}
+
+func (self *nativeError) Error() string {
+	return self.value.String()
+}
+
+func (self *Modules) run(filename string, source []byte, object *otto.Object) error {
+	exports, _ := object.Get("exports")
+	if path.Ext(filename) == ".json" {
+		value, err := self.vm.Call("JSON.parse", nil, string(source))
+		if err != nil {
+			return err
+		}
+		return object.Set("exports", value)
+	}
+
+	// The wrapper has a line of its own (line 0), so that positions in the module are as in its file
+	script, err := self.vm.CompileAt(filename, 0, "(function(exports, require, module, __filename, __dirname) {\n"+string(source)+"\n})")
+	if err != nil {
+		return err
+	}
+	function, err := self.vm.Run(script)
+	if err != nil {
+		return err
+	}
+	directory := path.Dir(filename)
+	_, err = function.Call(exports, exports, self.newRequire(directory), object.Value(), filename, directory)
+	return err
+}
+
+// rethrow throws err (from running a module) as a JavaScript exception
+func (self *Modules) rethrow(err error) {
+	switch err := err.(type) {
+	case *nativeError:
+		panic(err.value)
+	case *otto.Error:
+		panic(err) // Thrown again as it was, keeping its name, message, and position
+	}
+	self.throw("Error", err.Error(), "")
+}
+
+func (self *Modules) throw(name string, message string, code string) {
+	value, err := self.vm.Call("new "+name, nil, message)
+	if err != nil {
+		value, _ = self.vm.Call("new Error", nil, message)
+	}
+	if code != "" {
+		value.Object().Set("code", code)
+	}
+	panic(value)
+}
+
+func isPath(name string) bool {
+	return name == "." || name == ".." || strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") || strings.HasPrefix(name, "/")
+}
+
+// resolve returns the path of the module with the given name, as required from
+// directory, along with its source, or "" if there is no such module
+func (self *Modules) resolve(directory string, name string) (string, []byte) {
+	if self.registry.loader == nil {
+		return "", nil
+	}
+
+	if isPath(name) {
+		if strings.HasPrefix(name, "/") {
+			directory = "."
+		}
+		return self.loadFileOrDirectory(path.Join(directory, strings.TrimLeft(name, "/")))
+	}
+
+	// node_modules, from directory up to the root
+	for {
+		if path.Base(directory) != "node_modules" {
+			filename, source := self.loadFileOrDirectory(path.Join(directory, "node_modules", name))
+			if filename != "" {
+				return filename, source
+			}
+		}
+		if directory == "." {
+			return "", nil
+		}
+		directory = path.Dir(directory)
+	}
+}
+
+func (self *Modules) loadFileOrDirectory(filename string) (string, []byte) {
+	if !fs.ValidPath(filename) {
+		return "", nil // e.g. Outside of the root
+	}
+
+	if filename, source := self.loadFile(filename); filename != "" {
+		return filename, source
+	}
+
+	if source := self.load(path.Join(filename, "package.json")); source != nil {
+		var pkg struct {
+			Main string `json:"main"`
+		}
+		if json.Unmarshal(source, &pkg) == nil && pkg.Main != "" {
+			main := path.Join(filename, pkg.Main)
+			if fs.ValidPath(main) {
+				if main, source := self.loadFile(main); main != "" {
+					return main, source
+				}
+				if main, source := self.loadIndex(main); main != "" {
+					return main, source
+				}
+			}
+		}
+	}
+
+	return self.loadIndex(filename)
+}
+
+func (self *Modules) loadFile(filename string) (string, []byte) {
+	for _, extension := range []string{"", ".js", ".json"} {
+		if source := self.load(filename + extension); source != nil {
+			return filename + extension, source
+		}
+	}
+	return "", nil
+}
+
+func (self *Modules) loadIndex(directory string) (string, []byte) {
+	for _, index := range []string{"index.js", "index.json"} {
+		filename := path.Join(directory, index)
+		if source := self.load(filename); source != nil {
+			return filename, source
+		}
+	}
+	return "", nil
+}
+
+// load returns the source at filename, or nil if there is no such file (and
+// throws if the loader fails otherwise)
+func (self *Modules) load(filename string) []byte {
+	source, err := self.registry.loader.Load(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		self.throw("Error", err.Error(), "")
+	}
+	if source == nil {
+		source = []byte{}
+	}
+	return source
+}
@@ -0,0 +1,193 @@
+package require
+
+import (
+	. "../terst"
+	"testing"
+	"testing/fstest"
+
+	"github.com/robertkrimen/otto"
+)
+
+func TestRequire(t *testing.T) {
+	Terst(t)
+
+	registry := NewRegistry(MapLoader{
+		"main.js": `
+            var abc = require("./lib/abc");
+            var def = require("./lib/def.js");
+            module.exports = [ abc.name, def.name, abc === require("./lib/abc.js"), abc.def === def ];
+        `,
+		"lib/abc.js": `
+            exports.name = "abc";
+            exports.def = require("./def");
+            exports.file = [ __filename, __dirname, module.id ];
+        `,
+		"lib/def.js": `
+            module.exports = { name: "def", count: (this.count || 0) + 1 };
+        `,
+		"data.json":                     `{ "xyzzy": "Nothing happens." }`,
+		"lib/ghi/package.json":          `{ "main": "./src/main" }`,
+		"lib/ghi/src/main.js":           `exports.name = "ghi"`,
+		"lib/jkl/index.js":              `exports.name = require("../ghi").name + "/jkl"`,
+		"node_modules/mno/index.js":     `exports.name = "mno"`,
+		"lib/node_modules/mno/index.js": `exports.name = "lib/mno"`,
+		"lib/pqr.js":                    `exports.name = [ require("mno").name, require("/node_modules/mno").name ]`,
+	})
+	Otto := otto.New()
+	modules := registry.Enable(Otto)
+
+	value, err := modules.Require("./main")
+	Is(err, nil)
+	Is(value, "abc,def,true,true")
+
+	value, err = Otto.Run(`
+        [
+            require("./lib/abc").file,
+            require("./data").xyzzy,
+            require("./lib/jkl").name,
+            require("mno").name,
+            require("./lib/pqr").name,
+            require("./lib/def").count
+        ].join(";");
+    `)
+	Is(err, nil)
+	Is(value, "lib/abc.js,lib,lib/abc.js;Nothing happens.;ghi/jkl;mno;lib/mno,mno;1")
+}
+
+func TestRequire_cycle(t *testing.T) {
+	Terst(t)
+
+	registry := NewRegistry(MapLoader{
+		"a.js": `
+            exports.done = false;
+            var b = require("./b");
+            exports.b = b.done + "," + b.a;
+            exports.done = true;
+        `,
+		"b.js": `
+            exports.done = false;
+            var a = require("./a");
+            exports.a = a.done;
+            exports.done = true;
+        `,
+	})
+	Otto := otto.New()
+	registry.Enable(Otto)
+
+	value, err := Otto.Run(`
+        var a = require("./a");
+        [ a.done, a.b, require("./b").done ];
+    `)
+	Is(err, nil)
+	Is(value, "true,true,false,true")
+}
+
+func TestRequire_native(t *testing.T) {
+	Terst(t)
+
+	registry := NewRegistry(nil)
+	count := 0
+	registry.Register("xyzzy", func(vm *otto.Otto, module *otto.Object) {
+		count++
+		exports, _ := module.Get("exports")
+		exports.Object().Set("hello", func(call otto.FunctionCall) otto.Value {
+			value, _ := vm.ToValue("Hello, " + call.Argument(0).String() + ".")
+			return value
+		})
+	})
+	registry.Register("throw", func(vm *otto.Otto, module *otto.Object) {
+		value, _ := vm.Call("new Error", nil, "Nothing happens.")
+		panic(value)
+	})
+
+	Otto := otto.New()
+	registry.Enable(Otto)
+
+	value, err := Otto.Run(`
+        require("xyzzy").hello("World") + " " + (require("xyzzy") === require("xyzzy"));
+    `)
+	Is(err, nil)
+	Is(value, "Hello, World. true")
+	Is(count, 1)
+
+	_, err = Otto.Run(`require("throw")`)
+	Is(err, "Error: Nothing happens.")
+
+	// Each runtime has its own modules
+	registry.Enable(otto.New()).Require("xyzzy")
+	Is(count, 2)
+}
+
+func TestRequire_error(t *testing.T) {
+	Terst(t)
+
+	registry := NewRegistry(FSLoader(fstest.MapFS{
+		"throw.js":   {Data: []byte(`exports.abc = 1; throw new TypeError("xyzzy");`)},
+		"syntax.js":  {Data: []byte("var abc = 1;\nabc abc;")},
+		"null.js":    {Data: []byte("var abc = null;\n\nabc.def;")},
+		"main.js":    {Data: []byte(`require("./throw");`)},
+		"lib/abc.js": {Data: []byte(`module.exports = require("../../outside")`)},
+		"lib/def":    {Data: []byte(`exports.def = "def"`)},
+	}))
+	Otto := otto.New()
+	modules := registry.Enable(Otto)
+
+	value, err := Otto.Run(`
+        var abc = [];
+        try {
+            require("./xyzzy");
+        } catch (error) {
+            abc.push(error.message, error.code);
+        }
+        try {
+            require("./throw");
+        } catch (error) {
+            abc.push(error instanceof TypeError, error.message);
+        }
+        try {
+            require("./lib/abc");
+        } catch (error) {
+            abc.push(error.code);
+        }
+        try {
+            require("./lib");
+        } catch (error) {
+            abc.push(error.code);
+        }
+        abc.push(require("./lib/def").def);
+        abc;
+    `)
+	Is(err, nil)
+	Is(value, "Cannot find module './xyzzy',MODULE_NOT_FOUND,true,xyzzy,MODULE_NOT_FOUND,MODULE_NOT_FOUND,def")
+
+	// An error is thrown (by require) as it was, with its position in the module
+	_, err = modules.Require("./syntax")
+	Is(err, "SyntaxError: Unexpected token abc (line 2)")
+	Is(err.(*otto.Error).Position(), "syntax.js:2:5")
+
+	_, err = modules.Require("./throw")
+	Is(err.(*otto.Error).Name, "TypeError")
+	Is(err.(*otto.Error).Message, "xyzzy")
+	Is(err.(*otto.Error).Position(), "throw.js:1:18")
+
+	// Including through the require of another module
+	_, err = modules.Require("./main")
+	Is(err.(*otto.Error).Position(), "throw.js:1:18")
+
+	_, err = modules.Require("./null")
+	Is(err.(*otto.Error).Name, "TypeError")
+	Is(err.(*otto.Error).Position(), "null.js:3:1")
+
+	value, err = Otto.Run(`
+        try {
+            require("./null");
+        } catch (error) {
+            [ error instanceof TypeError, error.stack.split("\n")[1] ].join(";");
+        }
+    `)
+	Is(err, nil)
+	Is(value, "true;    at null.js:3:1")
+
+	_, err = modules.Require("")
+	Is(err, "TypeError: The module name must be a non-empty string")
+}
@@ -3,6 +3,8 @@ package otto
 import (
 	"reflect"
 	"strconv"
+	"sync"
+	"weak"
 )
 
 type _global struct {
@@ -23,6 +25,14 @@ type _global struct {
 	SyntaxError    *_object
 	URIError       *_object
 	JSON           *_object
+	Promise        *_object // Promise( ... ), new Promise( ... ) - 1
+	Symbol         *_object // Symbol( ... ) - 0
+	Map            *_object // new Map( ... ) - 0
+	Set            *_object // new Set( ... ) - 0
+	WeakMap        *_object // new WeakMap( ... ) - 0
+	WeakSet        *_object // new WeakSet( ... ) - 0
+	Proxy          *_object // new Proxy( ... ) - 2
+	Reflect        *_object
 
 	ObjectPrototype         *_object // Object.prototype
 	FunctionPrototype       *_object // Function.prototype
@@ -39,6 +49,18 @@ type _global struct {
 	ReferenceErrorPrototype *_object
 	SyntaxErrorPrototype    *_object
 	URIErrorPrototype       *_object
+	PromisePrototype        *_object // Promise.prototype
+	SymbolPrototype         *_object // Symbol.prototype
+	IteratorPrototype       *_object // The prototype of the prototype of each (builtin) iterator
+	ArrayIteratorPrototype  *_object // The prototype of the iterator of an array, e.g. [][Symbol.iterator]()
+	StringIteratorPrototype *_object // The prototype of the iterator of a string
+	MapPrototype            *_object // Map.prototype
+	SetPrototype            *_object // Set.prototype
+	WeakMapPrototype        *_object // WeakMap.prototype
+	WeakSetPrototype        *_object // WeakSet.prototype
+	MapIteratorPrototype    *_object // The prototype of the iterator of a Map
+	SetIteratorPrototype    *_object // The prototype of the iterator of a Set
+	GeneratorPrototype      *_object // The prototype of the prototype of each generator function
 }
 
 type _runtime struct {
@@ -47,15 +69,52 @@ type _runtime struct {
 	GlobalObject      *_object
 	GlobalEnvironment *_objectEnvironment
 
+	// GlobalLexicalEnvironment has the let and const bindings of the top-level
+	// (which are not properties of the global object), its outer is GlobalEnvironment
+	GlobalLexicalEnvironment *_declarativeEnvironment
+
 	Global _global
 
 	eval *_object // The builtin eval, for determine indirect versus direct invocation
 
+	symbolRegistry map[string]_symbol // The symbols of Symbol.for, by key
+
+	templateObjects      map[weak.Pointer[_templateObjectNode]]*_object // The strings array of each tagged template, see evaluateTemplateObject
+	templateObjectsSweep int                                            // The size of templateObjects at which it is next swept
+
+	throwTypeErrorFunction *_object // See throwTypeError
+
 	Otto *Otto
+
+	interrupt    int32 // Set (atomically) when the context of a run is done, see watchContext
+	interruptErr error
+
+	steps   uint64 // The number of steps evaluated, see SetStepLimit
+	stepMax uint64 // The step count at which to abort, or 0 for no limit
+
+	callDepth    int // The number of (JavaScript and Go) function calls in progress
+	maxCallDepth int // See SetMaxCallDepth
+
+	memoryUsage uint64 // See SetMemoryLimit
+	memoryLimit uint64
+
+	jobQueue      []func() // Jobs to run once the current JavaScript has finished, see runJobs
+	runningJobs   bool
+	asyncJobQueue []func() // Jobs queued by other goroutines, see enqueueAsyncJob
+	asyncJobLock  sync.Mutex
+
+	unhandledRejections []*_object // Promises rejected without a handler, see checkUnhandledRejections
+
+	generators      map[*_generatorChannel]_generatorBody // The bodies that have been started, and have yet to complete, see Close
+	generatorsSweep int                                   // The size of generators at which it is next swept, see sweepGenerators
 }
 
+// defaultMaxCallDepth is deep enough for any reasonable (recursive) program,
+// while keeping well within the maximum size of the Go stack
+const defaultMaxCallDepth = 10000
+
 func (self *_runtime) EnterGlobalExecutionContext() {
-	self.EnterExecutionContext(newExecutionContext(self.GlobalEnvironment, self.GlobalEnvironment, self.GlobalObject))
+	self.EnterExecutionContext(newExecutionContext(self.GlobalLexicalEnvironment, self.GlobalEnvironment, toValue_object(self.GlobalObject)))
 }
 
 func (self *_runtime) EnterExecutionContext(scope *_executionContext) {
@@ -82,14 +141,28 @@ func (self *_runtime) EnterFunctionExecutionContext(function *_object, this Valu
 		scopeEnvironment = self.GlobalEnvironment
 	}
 	environment := self.newFunctionEnvironment(scopeEnvironment)
-	var thisObject *_object
-	switch this._valueType {
-	case valueUndefined, valueNull:
-		thisObject = self.GlobalObject
-	default:
-		thisObject = self.toObject(this)
+	strict := false
+	if node := function.functionNode(); node != nil {
+		strict = node.strict
+	} else if function.proxyValue() != nil {
+		strict = true // A proxy passes this on as-is (to the apply trap, or the target)
+	}
+	if arrowThis, arrow := function.arrowThis(); arrow {
+		// An arrow function has the this of where it was defined
+		this = arrowThis
+	} else if !strict {
+		// In strict mode code, this is passed as-is (10.4.3)
+		switch this._valueType {
+		case valueUndefined, valueNull:
+			this = toValue_object(self.GlobalObject)
+		default:
+			this = toValue_object(self.toObject(this))
+		}
 	}
-	self.EnterExecutionContext(newExecutionContext(environment, environment, thisObject))
+	executionContext := newExecutionContext(environment, environment, this)
+	executionContext.function = function
+	executionContext.strict = strict
+	self.EnterExecutionContext(executionContext)
 	return environment
 }
 
@@ -101,6 +174,7 @@ func (self *_runtime) EnterEvalExecutionContext(call FunctionCall) {
 	new := newExecutionContext(parent.LexicalEnvironment, parent.VariableEnvironment, parent.this)
 	// FIXME Make passing through of self.GlobalObject more general? Whenever newExecutionContext is passed a nil object?
 	new.eval = true
+	new.strict = parent.strict // A direct eval in strict mode code is strict (10.1.1)
 	self.EnterExecutionContext(new)
 }
 
@@ -114,6 +188,10 @@ func (self *_runtime) GetValue(value Value) Value {
 func (self *_runtime) PutValue(reference _reference, value Value) {
 	if !reference.PutValue(value) {
 		// Why? -- If reference.Base == nil
+		if reference.IsStrict() {
+			// In strict mode code, assigning to an undeclared identifier is an error (8.7.2)
+			panic(newReferenceError("notDefined", reference.GetName()))
+		}
 		strict := false
 		self.GlobalObject.defineProperty(reference.GetName(), value, 0111, strict)
 	}
@@ -129,6 +207,9 @@ func (self *_runtime) _callNode(function *_object, environment *_functionEnviron
 	// ...
 
 	for index, name := range node.ParameterList {
+		if name == "" {
+			continue // Destructured, see below
+		}
 		value := UndefinedValue()
 		if index < len(argumentList) {
 			value = argumentList[index]
@@ -137,13 +218,25 @@ func (self *_runtime) _callNode(function *_object, environment *_functionEnviron
 		self.localSet(name, value)
 	}
 
-	if !node.ArgumentsIsParameter {
+	// In strict mode code, or if the parameters are not just names, arguments
+	// does not alias the parameters (10.6)
+	mapped := !node.strict && node.ParameterPatternList == nil && node.Rest == nil
+
+	// An arrow function has the arguments of where it was defined (as an outer binding)
+	if !node.ArgumentsIsParameter && !node.arrow {
+		if !mapped {
+			indexOfParameterName = nil
+		}
 		arguments := self.newArgumentsObject(indexOfParameterName, environment, len(argumentList))
-		arguments.defineProperty("callee", toValue_object(function), 0101, false)
+		if node.strict {
+			self.defineThrowTypeError(arguments, "callee", "caller")
+		} else {
+			arguments.defineProperty("callee", toValue_object(function), 0101, false)
+		}
 		environment.arguments = arguments
 		self.localSet("arguments", toValue_object(arguments))
 		for index, _ := range argumentList {
-			if index < len(node.ParameterList) {
+			if index < len(node.ParameterList) && mapped {
 				continue
 			}
 			indexAsString := strconv.FormatInt(int64(index), 10)
@@ -151,9 +244,39 @@ func (self *_runtime) _callNode(function *_object, environment *_functionEnviron
 		}
 	}
 
+	for index, pattern := range node.ParameterPatternList {
+		if pattern == nil {
+			continue
+		}
+		value := UndefinedValue()
+		if index < len(argumentList) {
+			value = argumentList[index]
+		}
+		self.destructure(pattern, value, self.localSet)
+	}
+
+	if node.Rest != nil {
+		// function(abc, ...def)
+		rest := []Value{}
+		if len(argumentList) > len(node.ParameterList) {
+			rest = argumentList[len(node.ParameterList):]
+		}
+		self.destructure(node.Rest, toValue_object(self.newArrayOf(rest)), self.localSet)
+	}
+
+	if len(node.LexicalList) > 0 {
+		// The execution context is left with the call, so there is no need to restore
+		self.enterLexicalEnvironment(node.LexicalList, nil)
+	}
+
 	self.declare("function", node.FunctionList)
 	self.declare("variable", node.VariableList)
 
+	if node.generator {
+		// The body is evaluated by the generator, from one yield to the next (see resumeGenerator)
+		return toValue_object(self.newGenerator(function, node))
+	}
+
 	result := self.evaluateBody(node.Body)
 	if result.isResult() {
 		return result
@@ -163,9 +286,30 @@ func (self *_runtime) _callNode(function *_object, environment *_functionEnviron
 }
 
 func (self *_runtime) Call(function *_object, this Value, argumentList []Value, evalHint bool) Value {
+	result, _ := self.call(function, this, argumentList, evalHint, nil)
+	return result
+}
+
+// call is Call, where newTarget is the class that new was applied to (for the
+// constructor of a class), returning the this at the end of the call as well,
+// since the this of a class that extends another is bound by super()
+func (self *_runtime) call(function *_object, this Value, argumentList []Value, evalHint bool, newTarget *_object) (Value, Value) {
+	// Throw a RangeError, instead of (eventually) overflowing the Go stack
+	if self.maxCallDepth > 0 && self.callDepth >= self.maxCallDepth {
+		panic(newRangeError("Maximum call stack size exceeded"))
+	}
+
+	if node := function.functionNode(); node != nil && node.class && newTarget == nil {
+		panic(newTypeError("Class constructor %s cannot be invoked without 'new'", node.name))
+	}
+
 	// Pass eval boolean through to EnterFunctionExecutionContext for further testing
 	_functionEnvironment := self.EnterFunctionExecutionContext(function, this)
+	executionContext := self._executionContext(0)
+	executionContext.newTarget = newTarget
+	self.callDepth++
 	defer func() {
+		self.callDepth--
 		self.LeaveExecutionContext()
 	}()
 
@@ -174,9 +318,69 @@ func (self *_runtime) Call(function *_object, this Value, argumentList []Value,
 	}
 	callValue := function.functionValue().call.Dispatch(function, _functionEnvironment, self, this, argumentList, evalHint)
 	if value, valid := callValue.value.(_result); valid {
-		return value.value
+		return value.value, executionContext.this
 	}
-	return callValue
+	return callValue, executionContext.this
+}
+
+// construct constructs an object with constructor, where newTarget is the class
+// that new was applied to, which differs from constructor for super()
+func (self *_runtime) construct(constructor *_object, newTarget *_object, argumentList []Value) Value {
+	if constructor == nil {
+		panic(newTypeError("null is not a constructor"))
+	}
+	if constructor.functionValue().construct == nil {
+		panic(newTypeError("%v is not a constructor", toValue_object(constructor)))
+	}
+
+	node := constructor.functionNode()
+	if node == nil || !node.class {
+		// A constructor other than a class (e.g. Error or Array) knows nothing of
+		// newTarget, so the prototype of the object is replaced afterward
+		result := constructor.functionValue().construct(constructor, UndefinedValue(), argumentList)
+		if newTarget != constructor && result.IsObject() {
+			if prototype := newTarget.get("prototype"); prototype.IsObject() {
+				result._object().prototype = prototype._object()
+			}
+		}
+		return result
+	}
+
+	if node.derived && node.implicit {
+		// constructor(...arguments) { super(...arguments) }
+		return self.construct(constructor.prototype, newTarget, argumentList)
+	}
+
+	this := emptyValue() // Bound by super()
+	if !node.derived {
+		object := self.newObject()
+		if prototype := newTarget.get("prototype"); prototype.IsObject() {
+			object.prototype = prototype._object()
+		}
+		this = toValue_object(object)
+	}
+	result, this := self.call(constructor, this, argumentList, false, newTarget)
+	if result.IsObject() {
+		return result
+	}
+	if node.derived {
+		if result.IsDefined() {
+			panic(newTypeError("Derived constructors may only return object or undefined"))
+		}
+		if this._valueType == valueEmpty {
+			panic(newReferenceError("Must call super constructor in derived class before accessing 'this' or returning from derived constructor"))
+		}
+	}
+	return this
+}
+
+// invoke calls the method name of value (with value as this)
+func (self *_runtime) invoke(value Value, name string, argumentList ...Value) Value {
+	function := self.toObject(value).get(name)
+	if !function.isCallable() {
+		panic(newTypeError("%s is not a function", name))
+	}
+	return self.Call(function._object(), value, argumentList, false)
 }
 
 func (self *_runtime) tryCatchEvaluate(inner func() Value) (tryValue Value, exception bool) {
@@ -193,7 +397,11 @@ func (self *_runtime) tryCatchEvaluate(inner func() Value) (tryValue Value, exce
 			switch caught := caught.(type) {
 			case _error:
 				exception = true
-				tryValue = toValue_object(self.newError(caught.Name, caught.MessageValue()))
+				object := self.newError(caught.Name, caught.MessageValue())
+				if caught.stack != nil {
+					object.put("stack", toValue_string(formatStack(caught.String(), caught.stack)), false)
+				}
+				tryValue = toValue_object(object)
 			case *_syntaxError:
 				exception = true
 				tryValue = toValue_object(self.newError("SyntaxError", toValue_string(caught.Message)))
@@ -233,6 +441,40 @@ func (self *_runtime) declare(kind string, declarationList []_declaration) {
 	}
 }
 
+// enterLexicalEnvironment enters a new declarative environment (of a block) with the
+// (uninitialized) let and const bindings of lexicalList, and the functions of
+// functionList, returning the previous lexical environment (to restore on leaving)
+func (self *_runtime) enterLexicalEnvironment(lexicalList []_lexicalDeclaration, functionList []_declaration) _environment {
+	executionContext := self._executionContext(0)
+	environment := self.newDeclarativeEnvironment(executionContext.LexicalEnvironment)
+	for _, declaration := range lexicalList {
+		environment.CreateLexicalBinding(declaration.Name, !declaration.Const)
+	}
+	previous := executionContext.LexicalEnvironment
+	executionContext.LexicalEnvironment = environment
+	for _, declaration := range functionList {
+		environment.SetValue(declaration.Name, self.evaluate(declaration.Definition), false)
+	}
+	return previous
+}
+
+// leaveLexicalEnvironment restores the lexical environment from before enterLexicalEnvironment
+func (self *_runtime) leaveLexicalEnvironment(previous _environment) {
+	self._executionContext(0).LexicalEnvironment = previous
+}
+
+// copyLexicalEnvironment replaces the lexical environment (of a for loop) with a new
+// one that has a copy of its bindings, for the next iteration
+func (self *_runtime) copyLexicalEnvironment() {
+	executionContext := self._executionContext(0)
+	environment := executionContext.LexicalEnvironment.(*_declarativeEnvironment)
+	copy := self.newDeclarativeEnvironment(environment.outer)
+	for name, property := range environment.property {
+		copy.property[name] = property
+	}
+	executionContext.LexicalEnvironment = copy
+}
+
 // _executionContext Proxy
 
 func (self *_runtime) localGet(name string) Value {
@@ -263,6 +505,8 @@ func (self *_runtime) toObject(value Value) *_object {
 		return self.newString(value)
 	case valueNumber:
 		return self.newNumber(value)
+	case valueSymbol:
+		return self.newSymbol(value)
 	case valueObject:
 		return value._object()
 	}
@@ -282,7 +526,7 @@ func testObjectCoercible(value Value) (isObject bool, mustCoerce bool) {
 	switch value._valueType {
 	case valueReference, valueEmpty, valueNull, valueUndefined:
 		return false, false
-	case valueNumber, valueString, valueBoolean:
+	case valueNumber, valueString, valueBoolean, valueSymbol:
 		isObject = false
 		mustCoerce = true
 	case valueObject:
@@ -364,17 +608,37 @@ func (runtime *_runtime) newGoArray(value reflect.Value) *_object {
 }
 
 func (self *_runtime) run(source string) Value {
-	return self.evaluate(mustParse(source))
+	value := self.GetValue(self.evaluate(mustParse(source)))
+	self.runJobs()
+	return value
+}
+
+// parseSource returns the program for src, which is either a *Script
+// or some source to be parsed (see Otto.Compile)
+func (self *_runtime) parseSource(src interface{}) (*_programNode, error) {
+	if script, ok := src.(*Script); ok {
+		return script.program, nil
+	}
+	script, err := compile("", 1, src)
+	if err != nil {
+		return nil, err
+	}
+	return script.program, nil
 }
 
-func (self *_runtime) runSafe(source string) (Value, error) {
+func (self *_runtime) runSafe(src interface{}) (Value, error) {
 	result := UndefinedValue()
-	err := catchPanic(func() {
-		result = self.run(source)
-	})
-	switch result._valueType {
-	case valueReference:
-		result = self.GetValue(result)
+	program, err := self.parseSource(src)
+	if err != nil {
+		return result, err
 	}
+	err = catchPanic(func() {
+		result = self.evaluate(program)
+		switch result._valueType {
+		case valueReference:
+			result = self.GetValue(result)
+		}
+		self.runJobs()
+	})
 	return result, err
 }
@@ -0,0 +1,69 @@
+package otto
+
+import (
+	"github.com/robertkrimen/otto/parser"
+)
+
+// Script is a handle for some (reusable) JavaScript.
+// Passing a Script value to a run method will evaluate the JavaScript.
+//
+// A Script is immutable once compiled, so it can be shared by any number of
+// runtimes (and goroutines), and run any number of times, without reparsing.
+type Script struct {
+	program  *_programNode
+	filename string
+	src      string
+}
+
+// Compile will parse the given source and return a Script value or nil and
+// an error if there was a problem during compilation.
+//
+// The filename is used in the positions of any errors, and may be empty.
+// The source can be a string, []byte, *bytes.Buffer, or io.Reader. If src is
+// nil, then the source is read from the file specified by filename.
+//
+//      script, err := Otto.Compile("", `var abc; if (!abc) abc = 0; abc += 2; abc;`)
+//      Otto.Run(script) // 2
+//      Otto.Run(script) // 4
+//
+func (self *Otto) Compile(filename string, src interface{}) (*Script, error) {
+	return compile(filename, 1, src)
+}
+
+// CompileAt is Compile for source that begins at the given line of filename
+// (rather than line 1), e.g. for source wrapped in a function, where the
+// wrapper has a line of its own, which is line 0:
+//
+//      script, err := Otto.CompileAt("xyzzy.js", 0, "(function() {\n"+source+"\n})")
+func (self *Otto) CompileAt(filename string, line int, src interface{}) (*Script, error) {
+	return compile(filename, line, src)
+}
+
+func compile(filename string, line int, src interface{}) (*Script, error) {
+	source, err := parser.ReadSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+
+	script := &Script{
+		filename: filename,
+		src:      source,
+	}
+	err = catchPanic(func() {
+		script.program = mustParseFileAt(filename, line, source)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
+// String returns the source of the script.
+func (self *Script) String() string {
+	return self.src
+}
+
+// Filename returns the filename the script was compiled with, if any.
+func (self *Script) Filename() string {
+	return self.filename
+}
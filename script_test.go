@@ -0,0 +1,136 @@
+package otto
+
+import (
+	. "./terst"
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	script, err := Otto.Compile("xyzzy.js", `var abc; if (!abc) abc = 0; abc += 2; abc;`)
+	Is(err, nil)
+	Is(script.Filename(), "xyzzy.js")
+	Is(script.String(), `var abc; if (!abc) abc = 0; abc += 2; abc;`)
+
+	value, err := Otto.Run(script)
+	Is(err, nil)
+	Is(value, "2")
+
+	value, err = Otto.Run(script)
+	Is(err, nil)
+	Is(value, "4")
+
+	// The same script, in another runtime
+	value, err = New().Run(script)
+	Is(err, nil)
+	Is(value, "2")
+
+	{
+		script, err := Otto.Compile("", []byte(`abc * 10`))
+		Is(err, nil)
+		value, err := Otto.Run(script)
+		Is(err, nil)
+		Is(value, "40")
+
+		script, err = Otto.Compile("", bytes.NewBufferString(`abc * 100`))
+		Is(err, nil)
+		value, err = Otto.Run(script)
+		Is(err, nil)
+		Is(value, "400")
+	}
+
+	{
+		script, err := Otto.Compile("xyzzy.js", `
+            var abc = {;
+        `)
+		Is(script == nil, true)
+		Is(err, "SyntaxError: Unexpected token ; (line 2)")
+		Is(err.(*Error).Position().Filename, "xyzzy.js")
+
+		script, err = Otto.Compile("xyzzy.js", `
+            abc = 1;
+            def.ghi;
+        `)
+		Is(err, nil)
+		_, err = Otto.Run(script)
+		Is(err, "ReferenceError: def is not defined (line 3)")
+		Is(err.(*Error).Position().String(), "xyzzy.js:3:13")
+	}
+
+	{
+		// The source of a function wrapper, on a line of its own (line 0)
+		script, err := Otto.CompileAt("xyzzy.js", 0, "(function() {\nabc = 1; def.ghi;\n})")
+		Is(err, nil)
+		function, err := Otto.Run(script)
+		Is(err, nil)
+		_, err = function.Call(UndefinedValue())
+		Is(err, "ReferenceError: def is not defined (line 1)")
+		Is(err.(*Error).Position().String(), "xyzzy.js:1:10")
+
+		_, err = Otto.CompileAt("xyzzy.js", 0, "(function() {\nabc abc;\n})")
+		Is(err, "SyntaxError: Unexpected token abc (line 1)")
+		Is(err.(*Error).Position().String(), "xyzzy.js:1:5")
+	}
+
+	{
+		value, err := Otto.Run([]byte(`abc + 1`))
+		Is(err, nil)
+		Is(value, "2")
+	}
+}
+
+func TestScript_Call(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	script, err := Otto.Compile("", `(function(def){ return this + def; })`)
+	Is(err, nil)
+
+	value, err := Otto.Call(script, "abc", "def")
+	Is(err, nil)
+	Is(value, "abcdef")
+
+	script, err = Otto.Compile("", `(function(def){ return def * 2; })`)
+	Is(err, nil)
+
+	value, err = Otto.Call(script, nil, 21)
+	Is(err, nil)
+	Is(value, "42")
+}
+
+func TestScript_concurrent(t *testing.T) {
+	Terst(t)
+
+	script, err := New().Compile("", `
+        var abc = [];
+        for (var def = 0; def < 100; def++) {
+            abc.push(def * 2);
+        }
+        abc.length + abc[99];
+    `)
+	Is(err, nil)
+
+	var group sync.WaitGroup
+	result := make([]Value, 8)
+	for index := range result {
+		group.Add(1)
+		go func(index int) {
+			defer group.Done()
+			Otto := New()
+			for count := 0; count < 10; count++ {
+				result[index], _ = Otto.Run(script)
+			}
+		}(index)
+	}
+	group.Wait()
+
+	for _, value := range result {
+		Is(value, "298")
+	}
+}
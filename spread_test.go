@@ -0,0 +1,105 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestSpread(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function abc() {
+            return Array.prototype.join.call(arguments, ";");
+        }
+        var def = [ 2, 3 ];
+        [ abc(...def), abc(1, ...def, 4, ...[ 5 ]), abc(...[]), abc(..."xy") ].join("|");
+    `, "2;3|1;2;3;4;5||x;y")
+
+	test(`
+        var abc = [ 1, 2 ], def = [ 4 ];
+        var ghi = [ 0, ...abc, 3, ...def ];
+        [ ghi.length, ghi, [ ...abc ] !== abc ].join(";");
+    `, "5;0,1,2,3,4;true")
+
+	test(`Math.max(...[ 1, 5, 3 ])`, "5")
+
+	test(`
+        function Abc(def, ghi) {
+            this.value = def + ghi;
+        }
+        new Abc(...[ 1, 2 ]).value;
+    `, "3")
+
+	test(`
+        var abc = { def: function() { return this === abc } };
+        abc.def(...[]);
+    `, "true")
+
+	test(`raise: Math.max(...undefined)`, "TypeError: undefined is not iterable")
+}
+
+func TestRestParameter(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function abc(def, ...ghi) {
+            return [ def, ghi.length, Array.isArray(ghi), ghi.join(":") ].join(";");
+        }
+        [ abc(1, 2, 3), abc(1), abc.length ].join("|");
+    `, "1;2;true;2:3|1;0;true;|1")
+
+	test(`
+        var abc = (...def) => def.length;
+        [ abc(), abc(1, 2, 3) ];
+    `, "0,3")
+
+	test(`
+        function abc(...[ def, ghi ]) {
+            return def + ghi;
+        }
+        abc(1, 2);
+    `, "3")
+
+	test(`raise: function abc(...def, ghi) {}`, "SyntaxError: Rest parameter must be last formal parameter")
+}
+
+func TestDefaultParameter(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function abc(def, ghi = def + 1, { jkl } = { jkl: "jkl" }) {
+            return [ def, String(ghi), jkl ].join(";");
+        }
+        [ abc(1), abc(1, 3, { jkl: 4 }), abc(1, undefined, undefined), abc(1, null) ].join("|");
+    `, "1;2;jkl|1;3;4|1;2;jkl|1;null;jkl")
+
+	// The length is the number of parameters before the first with a default
+	test(`
+        [ (function(abc, def = 1, ghi) {}).length, ((abc = 1) => abc).length, (function({ abc }, def) {}).length ];
+    `, "1,0,2")
+
+	// A default is evaluated (each time) when it is needed
+	test(`
+        var abc = 0;
+        function def(ghi = ++abc) {
+            return ghi;
+        }
+        [ def(), def(10), def(), abc ];
+    `, "1,10,2,2")
+
+	test(`
+        function abc(def = arguments.length) {
+            return def;
+        }
+        abc(undefined, 2, 3);
+    `, "3")
+
+	test(`raise: function abc(def, def = 1) {}`, "SyntaxError: Duplicate parameter name not allowed in this context")
+}
@@ -0,0 +1,68 @@
+package otto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stackLimit is the maximum number of frames recorded in a stack trace
+const stackLimit = 10
+
+// _frame is a single frame of a stack trace: the function that was
+// executing (if any), and where
+type _frame struct {
+	callee   string
+	position _position
+}
+
+func (self _frame) String() string {
+	location := "<unknown>"
+	if start := self.position.start(); start != nil {
+		location = start.String()
+	}
+	if self.callee == "" {
+		return location
+	}
+	return fmt.Sprintf("%s (%s)", self.callee, location)
+}
+
+// captureStack returns the (JavaScript) call stack, innermost first, where
+// position is the current position in the innermost frame (if known)
+//
+// Native functions do not have a position in the source, so they are omitted
+func (self *_runtime) captureStack(position _position) []_frame {
+	frames := []_frame{}
+	for index := len(self.Stack) - 1; index >= 0 && len(frames) < stackLimit; index-- {
+		context := self.Stack[index]
+		frame := _frame{
+			position: context.position,
+		}
+		if context.function != nil {
+			node := context.function.functionNode()
+			if node == nil {
+				continue
+			}
+			frame.callee = node.name
+		} else if context.eval {
+			frame.callee = "eval"
+		}
+		if len(frames) == 0 && position.isValid() {
+			frame.position = position
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// formatStack formats a stack trace in the style of Error.prototype.stack:
+//
+//	TypeError: Nothing happens.
+//	    at xyzzy (file.js:3:9)
+//	    at file.js:6:1
+func formatStack(description string, frames []_frame) string {
+	lines := []string{description}
+	for _, frame := range frames {
+		lines = append(lines, "    at "+frame.String())
+	}
+	return strings.Join(lines, "\n")
+}
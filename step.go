@@ -0,0 +1,88 @@
+package otto
+
+import (
+	"errors"
+)
+
+// ErrStepLimit is the error returned when a run exceeds a step limit, either
+// the limit of the runtime (see SetStepLimit) or the limit of the run (see
+// RunWithStepLimit and CallWithStepLimit).
+//
+// Like an interrupt, exceeding a step limit cannot be caught by JavaScript
+// (try/catch).
+var ErrStepLimit = errors.New("otto: step limit exceeded")
+
+// SetStepLimit limits the total number of steps the runtime will evaluate,
+// over all runs, to limit. A run that would exceed the limit is aborted, and
+// ErrStepLimit is returned.
+//
+// A step is the evaluation of a single node (statement or expression), or an
+// iteration of a loop, so the count is deterministic for a given script, and
+// does not depend on the speed of the machine.
+//
+// A limit of 0 means no limit (the default).
+func (self *Otto) SetStepLimit(limit uint64) {
+	self.runtime.stepMax = limit
+}
+
+// Steps returns the total number of steps evaluated by the runtime so far.
+//
+// The steps consumed by a run are the difference in Steps from before to after.
+func (self *Otto) Steps() uint64 {
+	return self.runtime.steps
+}
+
+// RunWithStepLimit is like Run, except that the run is aborted (and
+// ErrStepLimit is returned) if it would evaluate more than limit steps.
+// The number of steps consumed by the run is returned, whether or not
+// there was an error.
+//
+// A limit of 0 means no limit, other than the limit of the runtime (see
+// SetStepLimit), which always applies.
+func (self Otto) RunWithStepLimit(src interface{}, limit uint64) (Value, uint64, error) {
+	defer self.runtime.limitSteps(limit)()
+	start := self.runtime.steps
+	value, err := self.Run(src)
+	return value, self.runtime.steps - start, err
+}
+
+// CallWithStepLimit is like Call, except that the call is aborted (and
+// ErrStepLimit is returned) if it would evaluate more than limit steps.
+// The number of steps consumed by the call is returned, whether or not
+// there was an error.
+//
+// A limit of 0 means no limit, other than the limit of the runtime (see
+// SetStepLimit), which always applies.
+func (self Otto) CallWithStepLimit(limit uint64, source interface{}, this interface{}, argumentList ...interface{}) (Value, uint64, error) {
+	defer self.runtime.limitSteps(limit)()
+	start := self.runtime.steps
+	value, err := self.Call(source, this, argumentList...)
+	return value, self.runtime.steps - start, err
+}
+
+// limitSteps limits the runtime to evaluating at most limit more steps,
+// until the returned function is called
+func (self *_runtime) limitSteps(limit uint64) func() {
+	if limit == 0 {
+		return func() {}
+	}
+	stepMax := self.stepMax
+	max := self.steps + limit
+	if stepMax == 0 || max < stepMax {
+		self.stepMax = max
+	}
+	return func() {
+		self.stepMax = stepMax
+	}
+}
+
+// step counts a step of evaluation, and unwinds the runtime if a step limit
+// has been exceeded, or the context of the current run is done
+func (self *_runtime) step() {
+	self.steps++
+	if self.stepMax != 0 && self.steps > self.stepMax {
+		self.steps--
+		panic(_interrupt{err: ErrStepLimit})
+	}
+	self.checkInterrupt()
+}
@@ -0,0 +1,92 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestStepLimit(t *testing.T) {
+	Terst(t)
+
+	Otto := New()
+
+	script, err := Otto.Compile("", `
+        var abc = 0;
+        for (var def = 0; def < 10; def++) {
+            abc += def;
+        }
+        abc;
+    `)
+	Is(err, nil)
+
+	{
+		value, steps, err := Otto.RunWithStepLimit(script, 0)
+		Is(err, nil)
+		Is(value, "45")
+		Is(steps > 0, true)
+		Is(Otto.Steps(), steps)
+
+		// The count is deterministic
+		_, again, _ := Otto.RunWithStepLimit(script, 0)
+		Is(again, steps)
+
+		_, exact, err := Otto.RunWithStepLimit(script, steps)
+		Is(err, nil)
+		Is(exact, steps)
+
+		_, short, err := Otto.RunWithStepLimit(script, steps-1)
+		Is(err == ErrStepLimit, true)
+		Is(short, steps-1)
+	}
+
+	{
+		_, steps, err := Otto.RunWithStepLimit(`
+            while (true) {
+                try {
+                    for (;;) {}
+                } catch (error) {
+                    // Exceeding the limit cannot be caught
+                }
+            }
+        `, 1000)
+		Is(err == ErrStepLimit, true)
+		Is(steps, 1000)
+	}
+
+	{
+		_, err := Otto.Run(`function ghi() { for (;;) {} }`)
+		Is(err, nil)
+		_, steps, err := Otto.CallWithStepLimit(100, "ghi", nil)
+		Is(err == ErrStepLimit, true)
+		Is(steps, 100)
+
+		// The limit is restored after the call
+		value, err := Otto.Run(`abc`)
+		Is(err, nil)
+		Is(value, "45")
+	}
+
+	// The limit of the runtime spans every run
+	Otto = New()
+	Otto.SetStepLimit(10)
+	{
+		_, err := Otto.Run(`1; 2; 3;`)
+		Is(err, nil)
+		used := Otto.Steps()
+		Is(used > 0 && used < 10, true)
+
+		_, err = Otto.Run(`for (;;) {}`)
+		Is(err == ErrStepLimit, true)
+		Is(Otto.Steps(), 10)
+
+		// A larger limit for a single run does not lift the limit of the runtime
+		_, steps, err := Otto.RunWithStepLimit(`1`, 1000)
+		Is(err == ErrStepLimit, true)
+		Is(steps, 0)
+
+		Otto.SetStepLimit(0)
+		value, err := Otto.Run(`1 + 1`)
+		Is(err, nil)
+		Is(value, "2")
+	}
+}
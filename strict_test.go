@@ -0,0 +1,287 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	// Assigning to an undeclared identifier
+	test(`raise:
+        "use strict";
+        abc = 1;
+    `, "ReferenceError: abc is not defined")
+
+	test(`
+        def = 1;
+        def;
+    `, "1")
+
+	test(`
+        (function(){
+            "use strict";
+            try {
+                ghi = 1;
+            } catch (error) {
+                return error instanceof ReferenceError;
+            }
+        })();
+    `, "true")
+
+	test(`typeof ghi`, "undefined")
+
+	// this
+	test(`
+        (function(){
+            "use strict";
+            return this === undefined;
+        })();
+    `, "true")
+
+	test(`
+        (function(){
+            return this === undefined;
+        })();
+    `, "false")
+
+	test(`
+        (function(){
+            "use strict";
+            return [ typeof this, this === null ];
+        }).call(null);
+    `, "object,true")
+
+	test(`
+        (function(){
+            "use strict";
+            return typeof this;
+        }).call(1);
+    `, "number")
+
+	// A function declared at global scope is called without a this, as is a method
+	// of a class (which is strict) that is called on its own
+	test(`
+        function abc() {
+            "use strict";
+            return this;
+        }
+        function def() {
+            return this === undefined;
+        }
+        class Ghi {
+            jkl() {
+                return this;
+            }
+        }
+        var mno = new Ghi().jkl;
+        [ abc(), typeof abc, def(), mno() ];
+    `, ",function,false,")
+
+	test(`
+        "use strict";
+        function abc() {
+            return this;
+        }
+        abc();
+    `, "undefined")
+
+	test(`
+        var abc = {
+            def: function() {
+                "use strict";
+                return this === abc;
+            },
+        };
+        with (abc) {
+            def();
+        }
+    `, "true")
+
+	// A function nested in strict mode code is strict
+	test(`
+        (function(){
+            "use strict";
+            return (function(){
+                return this;
+            })();
+        })();
+    `, "undefined")
+
+	// A non-writable property
+	test(`
+        var jkl = Object.freeze({ mno: 1 });
+        (function(){
+            "use strict";
+            try {
+                jkl.mno = 2;
+            } catch (error) {
+                return [ error instanceof TypeError, jkl.mno ];
+            }
+        })();
+    `, "true,1")
+
+	test(`
+        jkl.mno = 2;
+        jkl.mno;
+    `, "1")
+
+	test(`
+        (function(){
+            "use strict";
+            try {
+                delete Object.prototype;
+            } catch (error) {
+                return error instanceof TypeError;
+            }
+        })();
+    `, "true")
+
+	// arguments does not alias the parameters
+	test(`
+        (function(pqr){
+            "use strict";
+            arguments[0] = 2;
+            pqr = 3;
+            return [ pqr, arguments[0], arguments[1], arguments.length ];
+        })(1, 4);
+    `, "3,2,4,2")
+
+	test(`
+        (function(pqr){
+            arguments[0] = 2;
+            return pqr;
+        })(1);
+    `, "2")
+
+	// Strict eval code has its own variable environment
+	test(`
+        eval("'use strict'; var stu = 1;");
+        typeof stu;
+    `, "undefined")
+
+	test(`
+        (function(){
+            "use strict";
+            eval("var vwx = 1;");
+            return typeof vwx;
+        })();
+    `, "undefined")
+
+	// A directive must be in the prologue, and unescaped
+	test(`
+        var xyz = 0;
+        (function(){
+            xyz;
+            "use strict";
+            return this === undefined;
+        })();
+    `, "false")
+
+	test(`
+        (function(){
+            "use\u0020strict";
+            return this === undefined;
+        })();
+    `, "false")
+
+	test(`
+        (function(){
+            "abc";
+            'use strict';
+            return this === undefined;
+        })();
+    `, "true")
+
+	test(`
+        new Function("'use strict'; return this;")();
+    `, "undefined")
+
+	test(`
+        [
+            (function(){ "use strict"; return this; }).call(undefined),
+            (function(){ return this === undefined; }).call(undefined),
+            (function(){ "use strict"; return this; }).apply(undefined, [])
+        ];
+    `, ",false,")
+
+	// The callee and caller of arguments, and the caller and arguments of
+	// the function itself, throw a TypeError
+	test(`
+        var abc = [];
+        function def() {
+            "use strict";
+            try {
+                arguments.callee;
+            } catch (error) {
+                abc.push(error instanceof TypeError);
+            }
+            try {
+                arguments.caller = 1;
+            } catch (error) {
+                abc.push(error instanceof TypeError);
+            }
+        }
+        def();
+        var ghi = Object.getOwnPropertyDescriptor(def, "caller");
+        [ abc, ghi.get === ghi.set, ghi.enumerable, ghi.configurable, Object.isExtensible(ghi.get) ].join(";");
+    `, "true,true;true;false;false;false")
+
+	test(`
+        function abc() {
+            return arguments.callee === abc;
+        }
+        abc();
+    `, "true")
+
+	test(`raise:
+        function abc() {
+            "use strict";
+        }
+        abc.caller;
+    `, "TypeError: 'caller', 'callee', and 'arguments' properties may not be accessed on strict mode functions or the arguments objects for calls to them")
+
+	test(`raise:
+        (function() {
+            "use strict";
+        }).arguments;
+    `, "TypeError: 'caller', 'callee', and 'arguments' properties may not be accessed on strict mode functions or the arguments objects for calls to them")
+
+	test(`raise:
+        (function() {}).bind(null).caller;
+    `, "TypeError: 'caller', 'callee', and 'arguments' properties may not be accessed on strict mode functions or the arguments objects for calls to them")
+}
+
+func TestStrict_SyntaxError(t *testing.T) {
+	Terst(t)
+
+	test := func(source, expect string) {
+		_, err := New().Run(source)
+		Is(err, expect)
+	}
+
+	test(`"use strict"; with ({}) {}`, "SyntaxError: Strict mode code may not include a with statement (line 1)")
+	test(`with ({}) {}`, "<nil>")
+
+	test(`function abc(def, def) { "use strict"; }`, "SyntaxError: Strict mode function may not have duplicate parameter names (line 1)")
+	test(`"use strict"; function abc(def, def) {}`, "SyntaxError: Strict mode function may not have duplicate parameter names (line 1)")
+	test(`function abc(def, def) {}`, "<nil>")
+
+	test(`"use strict"; var abc; delete abc;`, "SyntaxError: Delete of an unqualified identifier in strict mode. (line 1)")
+	test(`"use strict"; var eval;`, "SyntaxError: Unexpected eval or arguments in strict mode (line 1)")
+	test(`"use strict"; arguments = 1;`, "SyntaxError: Unexpected eval or arguments in strict mode (line 1)")
+	test(`function eval() { "use strict"; }`, "SyntaxError: Unexpected eval or arguments in strict mode (line 1)")
+	test(`"use strict"; try {} catch (eval) {}`, "SyntaxError: Unexpected eval or arguments in strict mode (line 1)")
+
+	test(`"use strict"; 010`, "SyntaxError: Octal literals are not allowed in strict mode (line 1)")
+	test(`function abc() { "use strict"; return { 07: 1 }; }`, "SyntaxError: Octal literals are not allowed in strict mode (line 1)")
+	test(`010`, "<nil>")
+	test(`"use strict"; 0; 0.10; 0x10`, "<nil>")
+
+	_, err := New().Run(`new Function("abc", "abc", "'use strict';")`)
+	Is(err.(*Error).Name, "SyntaxError")
+	Is(err.(*Error).Message, "Strict mode function may not have duplicate parameter names")
+}
@@ -0,0 +1,75 @@
+package otto
+
+import (
+	. "./terst"
+	"testing"
+)
+
+func TestSymbol(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        var abc = Symbol("xyzzy");
+        [ typeof abc, abc.toString(), String(abc), abc.description, Symbol().description, Symbol().toString() ].join(";");
+    `, "symbol;Symbol(xyzzy);Symbol(xyzzy);xyzzy;;Symbol()")
+
+	test(`
+        var abc = Symbol("xyzzy");
+        [ abc === abc, abc === Symbol("xyzzy"), abc == Object(abc), typeof Object(abc), Object(abc) instanceof Symbol ].join(",");
+    `, "true,false,true,object,true")
+
+	test(`
+        [ Symbol.for("abc") === Symbol.for("abc"), Symbol.keyFor(Symbol.for("abc")), Symbol.keyFor(Symbol("abc")), typeof Symbol.iterator ].join(",");
+    `, "true,abc,,symbol")
+
+	test(`
+        var abc = Symbol("abc");
+        var def = { ghi: 1 };
+        def[abc] = 2;
+        var jkl = [];
+        for (var name in def) {
+            jkl.push(name);
+        }
+        [ def[abc], abc in def, def.hasOwnProperty(abc), Object.keys(def), Object.getOwnPropertyNames(def), jkl, JSON.stringify(def) ].join(";");
+    `, `2;true;true;ghi;ghi;ghi;{"ghi":1}`)
+
+	test(`
+        var abc = Symbol("abc");
+        var def = {};
+        def[abc] = 1;
+        var ghi = Object.getOwnPropertySymbols(def);
+        [ ghi.length, ghi[0] === abc ].join(",");
+    `, "1,true")
+
+	test(`
+        var abc = {};
+        abc[Symbol.toPrimitive] = function(hint) {
+            return hint === "number" ? 42 : hint;
+        };
+        [ +abc, "" + abc, String(abc) ].join(",");
+    `, "42,default,string")
+
+	test(`
+        var abc = {};
+        abc[Symbol.toStringTag] = "Xyzzy";
+        [ Object.prototype.toString.call(abc), Object.prototype.toString.call(Math), Object.prototype.toString.call(Symbol()) ].join(",");
+    `, "[object Xyzzy],[object Math],[object Symbol]")
+
+	test(`
+        var Even = {};
+        Even[Symbol.hasInstance] = function(value) {
+            return value % 2 === 0;
+        };
+        [ 2 instanceof Even, 3 instanceof Even ].join(",");
+    `, "true,false")
+
+	test(`raise: Symbol() + ""`, "TypeError: Cannot convert a Symbol value to a string")
+
+	test(`raise: +Symbol()`, "TypeError: Cannot convert a Symbol value to a number")
+
+	test(`raise: new Symbol()`, "TypeError: Symbol is not a constructor")
+
+	test(`raise: Symbol.keyFor("abc")`, "TypeError: abc is not a symbol")
+}
@@ -0,0 +1,121 @@
+package otto
+
+import (
+	. "./terst"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test("`xyzzy`", "xyzzy")
+	test("``", "")
+
+	test(`
+        var abc = 1, def = "def";
+        `+"`abc = ${abc}, ${def + \"!\"} ${ { ghi: 2 }.ghi }${[3, 4]}`", "abc = 1, def! 23,4")
+
+	// Nested, along with a } inside a substitution
+	test("`[${ `(${ (function(){ return 1 })() })` }]`", "[(1)]")
+
+	test("`abc\\n\\u0041\\x42\\`\\${def}$ {}`", "abc\nAB`${def}$ {}")
+
+	// Multi-line, with \r\n normalized to \n, and a line continuation
+	test("`abc\r\ndef\\\nghi`.split('\\n').join('|')", "abc|defghi")
+
+	test(`
+        var abc = {
+            toString: function() { return "toString" },
+            valueOf: function() { return "valueOf" }
+        };
+        `+"`${abc}`", "toString")
+
+	test("raise: `${xyzzy}`", "ReferenceError: xyzzy is not defined")
+
+	test("raise: `abc", "SyntaxError: Unexpected token ILLEGAL (`abc)")
+}
+
+func TestTemplate_tagged(t *testing.T) {
+	Terst(t)
+
+	test := runTest()
+
+	test(`
+        function tag(strings) {
+            return [ strings.length, strings.join("|"), strings.raw.join("|"),
+                Array.prototype.slice.call(arguments, 1).join("|") ].join(";");
+        }
+        `+"tag`abc${1}\\n${2 + 2}def\\u0041`", "3;abc|\n|defA;abc|\\n|def\\u0041;1|4")
+
+	test("tag``", "1;;;")
+
+	test(`
+        var abc;
+        (function(strings){ abc = strings })`+"`abc${1}`"+`;
+        [ Object.isFrozen(abc), Object.isFrozen(abc.raw), abc.propertyIsEnumerable("raw"), Array.isArray(abc) ];
+    `, "true,true,false,true")
+
+	// The this of a member tag
+	test(`
+        var abc = {
+            def: "def",
+            ghi: function(strings, value) { return this.def + strings[0] + value }
+        };
+        `+"abc.ghi`:${1}`", "def:1")
+
+	// A tagged template after a call
+	test(`
+        function abc() {
+            return function(strings) { return strings[0] };
+        }
+        `+"abc()`xyzzy`", "xyzzy")
+
+	test(`String.raw`+"`abc\\n${1}`", "abc\\n1")
+
+	// The strings array is the same for each evaluation of a template (but not of another template)
+	test(`
+        function abc(strings) {
+            return strings;
+        }
+        var def = [];
+        for (var ghi = 0; ghi < 2; ghi++) {
+            def.push(abc`+"`xyzzy`"+`);
+        }
+        [ def[0] === def[1], def[0] === abc`+"`xyzzy`"+` ].join(",");
+    `, "true,false")
+}
+
+func TestTemplate_taggedCache(t *testing.T) {
+	Terst(t)
+
+	vm := New()
+	_, err := vm.Run(`
+        function abc(strings) {
+            return strings;
+        }
+        var def = function() {
+            return abc` + "`xyzzy`" + `;
+        };
+        var ghi = def();
+    `)
+	Is(err, nil)
+
+	// The strings array of a source that was run once is forgotten (once its node is collected)
+	for index := 0; index < 1000; index++ {
+		_, err := vm.Run("abc`" + strconv.Itoa(index) + "`")
+		Is(err, nil)
+		if index%100 == 0 {
+			runtime.GC()
+		}
+	}
+	Is(len(vm.runtime.templateObjects) < 500, true)
+
+	// ...but not that of a template that can still be evaluated
+	value, err := vm.Run(`def() === ghi`)
+	Is(err, nil)
+	Is(value, "true")
+}
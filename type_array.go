@@ -16,6 +16,19 @@ func isArray(object *_object) bool {
 	return object != nil && (object.class == "Array" || object.class == "GoArray")
 }
 
+// isArrayOrProxy is isArray, where a proxy of an array is an array (and operation is
+// what is reported for a proxy that has been revoked)
+func isArrayOrProxy(object *_object, operation string) bool {
+	for object != nil && object.objectClass == _classProxy {
+		proxy := object.proxyValue()
+		if proxy.target == nil {
+			panic(newTypeError("Cannot perform '%s' on a proxy that has been revoked", operation))
+		}
+		object = proxy.target
+	}
+	return isArray(object)
+}
+
 func objectLength(object *_object) uint32 {
 	if object == nil {
 		return 0
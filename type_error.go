@@ -1,9 +1,40 @@
 package otto
 
-func (runtime *_runtime) newErrorObject(message Value) *_object {
+func (runtime *_runtime) newErrorObject(prototype *_object, message Value) *_object {
 	self := runtime.newClassObject("Error")
+	self.prototype = prototype
 	if message.IsDefined() {
 		self.defineProperty("message", toValue_string(toString(message)), 0111, false)
 	}
+	frames := []_frame{}
+	if len(runtime.Stack) > 0 {
+		frames = runtime.captureStack(_position{})
+	}
+	self.defineProperty("stack", toValue_string(formatStack(errorString(self), frames)), 0101, false)
 	return self
 }
+
+// errorString returns the description of an Error object (as Error.prototype.toString would)
+func errorString(object *_object) string {
+	name := "Error"
+	nameValue := object.get("name")
+	if nameValue.IsDefined() {
+		name = toString(nameValue)
+	}
+
+	message := ""
+	messageValue := object.get("message")
+	if messageValue.IsDefined() {
+		message = toString(messageValue)
+	}
+
+	if len(name) == 0 {
+		return message
+	}
+
+	if len(message) == 0 {
+		return name
+	}
+
+	return name + ": " + message
+}
@@ -28,7 +28,13 @@ func (runtime *_runtime) newNodeFunctionObject(node *_functionNode, scopeEnviron
 		call:      newNodeCallFunction(node, scopeEnvironment),
 		construct: defaultConstructFunction,
 	}
-	self.defineProperty("length", toValue_int(len(node.ParameterList)), 0000, false)
+	if node.generator || node.async {
+		// A generator (or async) function is not a constructor
+		self.value = _functionObject{
+			call: newNodeCallFunction(node, scopeEnvironment),
+		}
+	}
+	self.defineProperty("length", toValue_int(node.Length), 0000, false)
 	return self
 }
 
@@ -36,7 +42,7 @@ func (runtime *_runtime) newBoundFunctionObject(target *_object, this Value, arg
 	self := runtime.newClassObject("Function")
 	self.value = _functionObject{
 		call:      newBoundCallFunction(target, this, argumentList),
-		construct: newBoundConstructFunction(target),
+		construct: newBoundConstructFunction(target, argumentList),
 	}
 	length := int(toInt32(target.get("length")))
 	length -= len(argumentList)
@@ -44,8 +50,7 @@ func (runtime *_runtime) newBoundFunctionObject(target *_object, this Value, arg
 		length = 0
 	}
 	self.defineProperty("length", toValue_int(length), 0000, false)
-	self.defineProperty("caller", UndefinedValue(), 0000, false)    // TODO Should throw a TypeError
-	self.defineProperty("arguments", UndefinedValue(), 0000, false) // TODO Should throw a TypeError
+	runtime.defineThrowTypeError(self, "caller", "arguments")
 	return self
 }
 
@@ -58,11 +63,68 @@ func (runtime *_runtime) newBoundFunction(target *_object, this Value, argumentL
 	return self
 }
 
+// throwTypeError returns the %ThrowTypeError% function (13.2.3), created on demand
+func (runtime *_runtime) throwTypeError() *_object {
+	if runtime.throwTypeErrorFunction == nil {
+		runtime.throwTypeErrorFunction = runtime.newBuiltinFunction(0, func(call FunctionCall) Value {
+			panic(newTypeError("'caller', 'callee', and 'arguments' properties may not be accessed on strict mode functions or the arguments objects for calls to them"))
+		})
+		runtime.throwTypeErrorFunction.extensible = false
+	}
+	return runtime.throwTypeErrorFunction
+}
+
+// defineThrowTypeError defines each name of object as an accessor that throws
+// a TypeError (for the caller and arguments of a strict mode function, and
+// the callee and caller of its arguments)
+func (runtime *_runtime) defineThrowTypeError(object *_object, nameList ...string) {
+	thrower := runtime.throwTypeError()
+	for _, name := range nameList {
+		object.defineOwnProperty(name, _property{_propertyGetSet{thrower, thrower}, 0200}, false)
+	}
+}
+
 func (self *_object) functionValue() _functionObject {
 	value, _ := self.value.(_functionObject)
 	return value
 }
 
+// functionNode returns the node of a function defined in JavaScript,
+// or nil for a native (or bound) function
+func (self *_object) functionNode() *_functionNode {
+	switch call := self.functionValue().call.(type) {
+	case *_nodeCallFunction:
+		return call.node
+	case _nodeCallFunction:
+		return call.node
+	}
+	return nil
+}
+
+// arrowThis returns the this of an arrow function, and whether the function
+// is an arrow function
+func (self *_object) arrowThis() (Value, bool) {
+	switch call := self.functionValue().call.(type) {
+	case *_nodeCallFunction:
+		return call.this, call.node.arrow
+	case _nodeCallFunction:
+		return call.this, call.node.arrow
+	}
+	return Value{}, false
+}
+
+// homeObject returns the object that a method (of a class) belongs to, which
+// super refers to the prototype of, or nil if the function is not a method
+func (self *_object) homeObject() *_object {
+	switch call := self.functionValue().call.(type) {
+	case *_nodeCallFunction:
+		return call.home
+	case _nodeCallFunction:
+		return call.home
+	}
+	return nil
+}
+
 func (self *_object) Call(this Value, argumentList ...interface{}) Value {
 	if self.functionValue().call == nil {
 		panic(newTypeError("%v is not a function", toValue_object(self)))
@@ -98,6 +160,11 @@ func defaultConstructFunction(self *_object, this Value, argumentList []Value) V
 	return newObjectValue
 }
 
+// classConstructFunction is the construct of a class, see _runtime.construct
+func classConstructFunction(self *_object, _ Value, argumentList []Value) Value {
+	return self.runtime.construct(self, self, argumentList)
+}
+
 func (self *_object) callGet(this Value) Value {
 	return self.runtime.Call(self, this, []Value(nil), false)
 }
@@ -121,12 +188,12 @@ func (self *_object) HasInstance(of Value) bool {
 	}
 	prototypeObject := prototype._object()
 
-	value := of._object().prototype
+	value := of._object().getPrototypeOf()
 	for value != nil {
 		if value == prototypeObject {
 			return true
 		}
-		value = value.prototype
+		value = value.getPrototypeOf()
 	}
 	return false
 }
@@ -152,6 +219,14 @@ func newNativeCallFunction(native _nativeFunction) _nativeCallFunction {
 }
 
 func (self _nativeCallFunction) Dispatch(_ *_object, _ *_functionEnvironment, runtime *_runtime, this Value, argumentList []Value, evalHint bool) Value {
+	defer func() {
+		if caught := recover(); caught != nil {
+			if err, ok := caught.(*Error); ok {
+				caught = err.exception()
+			}
+			panic(caught)
+		}
+	}()
 	return self(FunctionCall{
 		runtime:  runtime,
 		evalHint: evalHint,
@@ -178,6 +253,8 @@ func (self0 _nativeCallFunction) clone(clone *_clone) _callFunction {
 type _nodeCallFunction struct {
 	node             *_functionNode
 	scopeEnvironment _environment // Can be either Lexical or Variable
+	this             Value        // The this of an arrow function
+	home             *_object     // The object of a method (or of the method an arrow function is in), for super
 }
 
 func newNodeCallFunction(node *_functionNode, scopeEnvironment _environment) *_nodeCallFunction {
@@ -189,6 +266,12 @@ func newNodeCallFunction(node *_functionNode, scopeEnvironment _environment) *_n
 }
 
 func (self _nodeCallFunction) Dispatch(function *_object, environment *_functionEnvironment, runtime *_runtime, this Value, argumentList []Value, _ bool) Value {
+	if self.node.async {
+		// The parameters and body are evaluated by the async function, from one await to the next
+		return toValue_object(runtime.callAsync(func() Value {
+			return runtime._callNode(function, environment, self.node, this, argumentList)
+		}))
+	}
 	return runtime._callNode(function, environment, self.node, this, argumentList)
 }
 
@@ -201,9 +284,15 @@ func (self _nodeCallFunction) Source() string {
 }
 
 func (self0 _nodeCallFunction) clone(clone *_clone) _callFunction {
+	home := self0.home
+	if home != nil {
+		home = clone.object(home)
+	}
 	return _nodeCallFunction{
 		node:             self0.node,
 		scopeEnvironment: clone.environment(self0.scopeEnvironment),
+		this:             clone.value(self0.this),
+		home:             home,
 	}
 }
 
@@ -244,12 +333,19 @@ func (self0 _boundCallFunction) clone(clone *_clone) _callFunction {
 	}
 }
 
-func newBoundConstructFunction(target *_object) _constructFunction {
+func newBoundConstructFunction(target *_object, boundArgumentList []Value) _constructFunction {
 	// This is not exactly as described in 15.3.4.5.2, we let [[Call]] supply the
 	// bound arguments, etc.
 	return func(self *_object, this Value, argumentList []Value) Value {
 		switch value := target.value.(type) {
 		case _functionObject:
+			if value.construct == nil {
+				panic(newTypeError("%v is not a constructor", toValue_object(target)))
+			}
+			if node := target.functionNode(); node != nil && node.class {
+				// A class cannot be called, so is constructed with the bound arguments here
+				return self.runtime.construct(target, target, append(append([]Value{}, boundArgumentList...), argumentList...))
+			}
 			return value.construct(self, this, argumentList)
 		}
 		panic(newTypeError())
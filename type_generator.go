@@ -0,0 +1,374 @@
+package otto
+
+import (
+	"weak"
+)
+
+type _generatorState int
+
+const (
+	generatorSuspendedStart _generatorState = iota // The body has yet to be started (by next)
+	generatorSuspendedYield                        // The body is suspended at a yield
+	generatorExecuting                             // The body is running
+	generatorCompleted                             // The body has returned (or thrown)
+)
+
+// _generatorObject is the state of a generator, as returned by a call of a generator function
+// (or of a call of an async function, see callAsync)
+//
+// The body of a generator runs on a goroutine of its own, so that it can be suspended
+// at a yield (in the middle of an evaluation), and later resumed. Control is handed
+// back and forth over the channels of _generatorChannel, so only one of the body and
+// its caller is ever running, and the runtime is never used concurrently.
+//
+// The goroutine ends once the body completes, once the generator has been collected
+// (see sweepGenerators), or once the runtime is closed (see Close), and until then it is
+// accounted against the memory limit (see generatorMemory).
+type _generatorObject struct {
+	state   _generatorState
+	node    *_functionNode     // nil, for an async function
+	context *_executionContext // The execution context of the body, which is entered whenever it runs
+	channel *_generatorChannel // nil, until the body is started
+}
+
+// _generatorChannel is how control is handed between the body of a generator and its caller
+type _generatorChannel struct {
+	resume chan _generatorResumption // To the body, when it is resumed (by next, throw, or return)
+	yield  chan _generatorCompletion // To the caller, when the body yields (or completes)
+}
+
+// _generatorBody is the body of a generator that has been started, and has yet to
+// complete, as kept by the runtime (which does not keep the generator itself reachable)
+type _generatorBody struct {
+	generator weak.Pointer[_generatorObject]
+	context   *_executionContext
+}
+
+type _generatorResumptionKind int
+
+const (
+	generatorNext _generatorResumptionKind = iota
+	generatorThrow
+	generatorReturn
+	generatorAbandon // The generator is gone (or the runtime is closed), so the body is unwound (see unwindGenerator)
+)
+
+type _generatorResumption struct {
+	kind  _generatorResumptionKind
+	value Value
+}
+
+// _generatorCompletion is a yield of the body of a generator (or its completion, if done)
+type _generatorCompletion struct {
+	value  Value
+	done   bool
+	result *_object    // The result to pass on as-is (from the iterator of a yield*), if any
+	caught interface{} // The panic (e.g. an exception) that the body completed with, if any
+}
+
+// _generatorReturn is panicked (at a yield) by the return method of a generator, to
+// unwind the body as if by a return statement (see evaluateReturnable)
+type _generatorReturn struct {
+	value Value
+}
+
+// _generatorAbandoned is panicked (at a yield) to unwind the body of a generator that is
+// gone (or when the runtime is closed), without running any finally block
+type _generatorAbandoned struct{}
+
+func (runtime *_runtime) newGenerator(function *_object, node *_functionNode) *_object {
+	self := runtime.newObject()
+	if prototype := function.get("prototype"); prototype.IsObject() {
+		self.prototype = prototype._object()
+	} else {
+		self.prototype = runtime.Global.GeneratorPrototype
+	}
+	self.value = &_generatorObject{
+		node:    node,
+		context: runtime._executionContext(0),
+	}
+	return self
+}
+
+// defineGeneratorPrototype defines the prototype of a generator function, which is
+// the prototype of each generator it returns (and, unlike that of a constructor, has
+// no constructor)
+func (runtime *_runtime) defineGeneratorPrototype(function *_object) {
+	prototype := runtime.newObject()
+	prototype.prototype = runtime.Global.GeneratorPrototype
+	function.defineProperty("prototype", toValue_object(prototype), 0100, false)
+}
+
+// resumeGenerator runs the body of the generator until the next yield (or until
+// it completes), returning the iterator result
+func (runtime *_runtime) resumeGenerator(self *_generatorObject, kind _generatorResumptionKind, value Value) Value {
+	switch self.state {
+	case generatorExecuting:
+		panic(newTypeError("Generator is already running"))
+	case generatorSuspendedStart:
+		if kind != generatorNext {
+			// The body is never run
+			self.complete(runtime)
+		}
+	}
+	if self.state == generatorCompleted {
+		switch kind {
+		case generatorThrow:
+			panic(newException(value))
+		case generatorReturn:
+			return toValue_object(runtime.newIteratorResult(value, true))
+		}
+		return toValue_object(runtime.newIteratorResult(UndefinedValue(), true))
+	}
+
+	if self.channel == nil {
+		node := self.node
+		self.start(runtime, func() Value {
+			return runtime.evaluateBody(node.Body)
+		})
+	}
+	completion := self.resume(runtime, kind, value)
+	if completion.caught != nil {
+		panic(completion.caught)
+	}
+	if completion.result != nil {
+		return toValue_object(completion.result)
+	}
+	return toValue_object(runtime.newIteratorResult(completion.value, completion.done))
+}
+
+// resume runs the body of the generator (which has been started) until the next yield,
+// or until it completes
+func (self *_generatorObject) resume(runtime *_runtime, kind _generatorResumptionKind, value Value) _generatorCompletion {
+	self.state = generatorExecuting
+	// The context has already been entered if this is the call of an async function
+	if runtime._executionContext(0) != self.context {
+		runtime.EnterExecutionContext(self.context)
+		defer runtime.LeaveExecutionContext()
+	}
+	self.channel.resume <- _generatorResumption{kind, value}
+	completion := <-self.channel.yield
+
+	if completion.done {
+		self.complete(runtime)
+	} else {
+		self.state = generatorSuspendedYield
+	}
+	return completion
+}
+
+// start starts body (the body of the generator) on a goroutine of its own, where it
+// waits until the generator is first resumed
+func (self *_generatorObject) start(runtime *_runtime, body func() Value) {
+	if len(runtime.generators) >= runtime.generatorsSweep {
+		runtime.sweepGenerators()
+	}
+	runtime.allocate(generatorMemory)
+	channel := &_generatorChannel{
+		resume: make(chan _generatorResumption),
+		yield:  make(chan _generatorCompletion),
+	}
+	self.channel = channel
+	self.context.generator = channel
+	if runtime.generators == nil {
+		runtime.generators = map[*_generatorChannel]_generatorBody{}
+	}
+	runtime.generators[channel] = _generatorBody{weak.Make(self), self.context}
+
+	go func() {
+		<-channel.resume
+		channel.yield <- runtime.evaluateGeneratorBody(body)
+	}()
+}
+
+func (self *_generatorObject) complete(runtime *_runtime) {
+	if self.channel != nil {
+		runtime.forgetGenerator(self.channel)
+	}
+	self.state = generatorCompleted
+	self.context = nil
+	self.channel = nil
+}
+
+// forgetGenerator forgets the body of a generator (which has completed, or been unwound),
+// giving back its memory
+func (runtime *_runtime) forgetGenerator(channel *_generatorChannel) {
+	if _, exists := runtime.generators[channel]; exists {
+		delete(runtime.generators, channel)
+		runtime.memoryUsage -= generatorMemory
+	}
+}
+
+// sweepGenerators unwinds the body of each generator that has been collected, which
+// can never be resumed, so that its goroutine ends (and its memory is given back, see
+// allocate)
+//
+// A generator that is reachable from its own body (e.g. by a variable of the function
+// that it was declared in) is never collected, since the goroutine keeps the body
+// reachable, so it is only unwound by Close.
+func (runtime *_runtime) sweepGenerators() {
+	for channel, body := range runtime.generators {
+		if body.generator.Value() == nil {
+			runtime.unwindGenerator(channel, body.context)
+			runtime.forgetGenerator(channel)
+		}
+	}
+	runtime.generatorsSweep = 2*len(runtime.generators) + 64
+}
+
+// unwindGenerator unwinds the body of a generator that is suspended, without running
+// any JavaScript
+func (runtime *_runtime) unwindGenerator(channel *_generatorChannel, context *_executionContext) {
+	runtime.EnterExecutionContext(context)
+	channel.resume <- _generatorResumption{kind: generatorAbandon}
+	<-channel.yield
+	runtime.LeaveExecutionContext()
+}
+
+// evaluateGeneratorBody evaluates the body of a generator, on the goroutine of the generator,
+// where any panic is passed on to the caller (rather than taking down the program)
+func (runtime *_runtime) evaluateGeneratorBody(body func() Value) (completion _generatorCompletion) {
+	completion.done = true
+	defer func() {
+		if caught := recover(); caught != nil {
+			switch caught := caught.(type) {
+			case _generatorReturn:
+				completion.value = caught.value
+			case _generatorAbandoned:
+			default:
+				completion.caught = caught
+			}
+		}
+	}()
+
+	completion.value = UndefinedValue()
+	if result := body(); result.isResult() {
+		completion.value = result.value.(_result).value
+	}
+	return
+}
+
+// Close unwinds the body of every generator (or call of an async function) that is
+// suspended, ending the goroutine that the body runs on. A generator that is resumed
+// after Close is done, and the promise of an async function that was suspended is
+// never settled, but the runtime can otherwise still be used.
+//
+// The body of a generator that is gone is unwound by the runtime as it goes, but
+// not that of one that is reachable from its own body (e.g. a generator kept in a
+// variable of the function that declares the generator function), so Close should be
+// called once a runtime is no longer needed.
+func (self *Otto) Close() {
+	self.runtime.closeGenerators()
+}
+
+// closeGenerators unwinds the body of every generator that has been started, and has
+// yet to complete, except for one that is running (which is what called Close)
+func (runtime *_runtime) closeGenerators() {
+	for channel, body := range runtime.generators {
+		self := body.generator.Value()
+		if self != nil && self.state == generatorExecuting {
+			continue
+		}
+		runtime.unwindGenerator(channel, body.context)
+		if self != nil {
+			self.complete(runtime)
+		} else {
+			runtime.forgetGenerator(channel)
+		}
+	}
+}
+
+// suspend passes completion to the caller of the generator, and waits (on the goroutine
+// of the generator) until the body is resumed
+func (self *_generatorChannel) suspend(completion _generatorCompletion) _generatorResumption {
+	self.yield <- completion
+	resumption := <-self.resume
+	if resumption.kind == generatorAbandon {
+		panic(_generatorAbandoned{})
+	}
+	return resumption
+}
+
+// receive returns the value that the body is resumed with by next, or throws
+// (or returns) from the yield for throw (or return)
+func (self _generatorResumption) receive() Value {
+	switch self.kind {
+	case generatorThrow:
+		panic(newException(self.value))
+	case generatorReturn:
+		panic(_generatorReturn{self.value})
+	}
+	return self.value
+}
+
+// yieldDelegate is yield*, which yields each value of the iterator of value (passing on
+// each resumption to the iterator), returning the value that the iterator is done with
+func (runtime *_runtime) yieldDelegate(channel *_generatorChannel, value Value) Value {
+	iterator := runtime.getIterator(value)
+	received := _generatorResumption{generatorNext, UndefinedValue()}
+	for {
+		var method Value
+		switch received.kind {
+		case generatorNext:
+			method = iterator.next
+		case generatorThrow:
+			method = iterator.iterator.get("throw")
+			if !method.IsDefined() || method.IsNull() {
+				// Without a throw method, the best that can be done is to close the iterator
+				iterator.close()
+				panic(newTypeError("The iterator does not provide a 'throw' method"))
+			}
+		case generatorReturn:
+			method = iterator.iterator.get("return")
+			if !method.IsDefined() || method.IsNull() {
+				panic(_generatorReturn{received.value})
+			}
+		}
+		if !method.isCallable() {
+			panic(newTypeError("%v is not a function", method))
+		}
+		result := runtime.Call(method._object(), toValue_object(iterator.iterator), []Value{received.value}, false)
+		if !result.IsObject() {
+			panic(newTypeError("Iterator result %v is not an object", result))
+		}
+		if result._object().get("done").toBoolean() {
+			value := result._object().get("value")
+			if received.kind == generatorReturn {
+				panic(_generatorReturn{value})
+			}
+			return value
+		}
+		received = channel.suspend(_generatorCompletion{result: result._object()})
+	}
+}
+
+// evaluateReturnable is evaluate for the try (or catch) block of a try statement with
+// a finally block, where the return method of a generator (see _generatorReturn) is
+// a return statement, so that the finally block is run
+func (self *_runtime) evaluateReturnable(node _node) (value Value) {
+	defer func() {
+		if caught := recover(); caught != nil {
+			if generatorReturn, valid := caught.(_generatorReturn); valid {
+				value = toValue(newReturnResult(generatorReturn.value))
+				return
+			}
+			panic(caught)
+		}
+	}()
+	return self.evaluate(node)
+}
+
+func (self0 *_generatorObject) clone(clone *_clone) *_generatorObject {
+	self1 := &_generatorObject{
+		state: self0.state,
+		node:  self0.node,
+	}
+	switch self0.state {
+	case generatorSuspendedStart:
+		self1.context = self0.context.clone(clone)
+	case generatorSuspendedYield, generatorExecuting:
+		// The body cannot be copied in the middle of running, so the copy is completed
+		self1.state = generatorCompleted
+	}
+	return self1
+}
@@ -0,0 +1,150 @@
+package otto
+
+// _iterator is an iterator (from the Symbol.iterator method of an iterable), as it
+// is stepped through by for-of, a spread, or an array pattern
+type _iterator struct {
+	runtime  *_runtime
+	iterator *_object
+	next     Value
+}
+
+// getIterator returns the iterator of value (which must be iterable)
+func (runtime *_runtime) getIterator(value Value) *_iterator {
+	method := UndefinedValue()
+	switch value._valueType {
+	case valueUndefined, valueNull:
+	default:
+		method = runtime.toObject(value).get(symbolKeyIterator)
+	}
+	if !method.isCallable() {
+		panic(newTypeError("%v is not iterable", value))
+	}
+	iterator := runtime.Call(method._object(), value, nil, false)
+	if !iterator.IsObject() {
+		panic(newTypeError("Result of the Symbol.iterator method is not an object"))
+	}
+	return &_iterator{
+		runtime:  runtime,
+		iterator: iterator._object(),
+		next:     iterator._object().get("next"),
+	}
+}
+
+// step returns the next value of the iterator, or (undefined and) true if it is done
+func (self *_iterator) step() (Value, bool) {
+	if !self.next.isCallable() {
+		panic(newTypeError("%v is not a function", self.next))
+	}
+	result := self.runtime.Call(self.next._object(), toValue_object(self.iterator), nil, false)
+	if !result.IsObject() {
+		panic(newTypeError("Iterator result %v is not an object", result))
+	}
+	if result._object().get("done").toBoolean() {
+		return UndefinedValue(), true
+	}
+	return result._object().get("value"), false
+}
+
+// close calls the return method of the iterator (if any), as when a for-of loop is
+// exited before the iterator is done
+func (self *_iterator) close() {
+	method := self.iterator.get("return")
+	if !method.IsDefined() || method.IsNull() {
+		return
+	}
+	if !method.isCallable() {
+		panic(newTypeError("%v is not a function", method))
+	}
+	result := self.runtime.Call(method._object(), toValue_object(self.iterator), nil, false)
+	if !result.IsObject() {
+		panic(newTypeError("Iterator result %v is not an object", result))
+	}
+}
+
+// closeOnPanic closes the iterator if there is a panic (an exception) in progress,
+// and then continues with the panic, ignoring any exception from closing, unless
+// done is true (the iterator is done, or has thrown the exception itself), or the
+// panic is unwinding an abandoned generator
+func (self *_iterator) closeOnPanic(done *bool) {
+	caught := recover()
+	if caught == nil {
+		return
+	}
+	if _, abandoned := caught.(_generatorAbandoned); !*done && !abandoned {
+		func() {
+			defer func() {
+				recover()
+			}()
+			self.close()
+		}()
+	}
+	panic(caught)
+}
+
+// iterableToList returns the values of value, which must be iterable, e.g. for
+// the spread of [ ...abc ]
+func (runtime *_runtime) iterableToList(value Value) []Value {
+	iterator := runtime.getIterator(value)
+	valueList := []Value{}
+	for {
+		value, done := iterator.step()
+		if done {
+			return valueList
+		}
+		valueList = append(valueList, value)
+	}
+}
+
+func (runtime *_runtime) newIteratorResult(value Value, done bool) *_object {
+	self := runtime.newObject()
+	self.defineProperty("value", value, 0111, false)
+	self.defineProperty("done", toValue_bool(done), 0111, false)
+	return self
+}
+
+// _arrayIteratorObject is the state of an iterator of an array (or an array-like
+// object), from values, keys, or entries
+type _arrayIteratorObject struct {
+	object *_object // nil, once the iterator is done
+	kind   string   // "values", "keys", or "entries"
+	index  int64
+}
+
+func (runtime *_runtime) newArrayIterator(object *_object, kind string) *_object {
+	self := runtime.newObject()
+	self.prototype = runtime.Global.ArrayIteratorPrototype
+	self.value = &_arrayIteratorObject{
+		object: object,
+		kind:   kind,
+	}
+	return self
+}
+
+func (self0 *_arrayIteratorObject) clone(clone *_clone) *_arrayIteratorObject {
+	self1 := *self0
+	if self0.object != nil {
+		self1.object = clone.object(self0.object)
+	}
+	return &self1
+}
+
+// _stringIteratorObject is the state of an iterator of a string, which iterates
+// by code point (rather than by UTF-16 code unit)
+type _stringIteratorObject struct {
+	value []rune
+	index int
+}
+
+func (runtime *_runtime) newStringIterator(value string) *_object {
+	self := runtime.newObject()
+	self.prototype = runtime.Global.StringIteratorPrototype
+	self.value = &_stringIteratorObject{
+		value: []rune(value),
+	}
+	return self
+}
+
+func (self0 *_stringIteratorObject) clone(clone *_clone) *_stringIteratorObject {
+	self1 := *self0
+	return &self1
+}
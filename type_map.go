@@ -0,0 +1,294 @@
+package otto
+
+import (
+	"math"
+)
+
+// _mapKey is the key of an entry in a Map (or Set), such that keys which are the
+// same by SameValueZero have the same _mapKey
+type _mapKey struct {
+	kind  _valueType
+	value interface{} // nil for undefined, null, and NaN
+}
+
+func toMapKey(value Value) _mapKey {
+	key := _mapKey{kind: value._valueType}
+	switch value._valueType {
+	case valueBoolean:
+		key.value = value.value.(bool)
+	case valueNumber:
+		float := toFloat(value)
+		if math.IsNaN(float) {
+			break
+		}
+		if float == 0 {
+			float = 0 // -0 is the same as +0
+		}
+		key.value = float
+	case valueString:
+		key.value = toString(value)
+	case valueObject:
+		key.value = value._object()
+	case valueSymbol:
+		key.value = value.value.(_symbol).id
+	}
+	return key
+}
+
+// _mapEntry is an entry of a Map (or Set), in a list in insertion order
+//
+// A deleted entry keeps its previous, so an iterator that is at the entry can
+// find its way back to the list (see nextEntry)
+type _mapEntry struct {
+	key      Value
+	value    Value
+	previous *_mapEntry
+	next     *_mapEntry
+	deleted  bool
+}
+
+// _mapObject is the value of a Map or Set (a Set has entries with a value that is the key)
+type _mapObject struct {
+	hash  map[_mapKey]*_mapEntry
+	first *_mapEntry
+	last  *_mapEntry
+}
+
+func (runtime *_runtime) newMapObject(class string) *_object {
+	self := runtime.newObject()
+	self.class = class
+	self.value = &_mapObject{
+		hash: map[_mapKey]*_mapEntry{},
+	}
+	return self
+}
+
+func (self *_object) mapValue() *_mapObject {
+	value, _ := self.value.(*_mapObject)
+	return value
+}
+
+func (self *_mapObject) size() int {
+	return len(self.hash)
+}
+
+func (self *_mapObject) get(key Value) (Value, bool) {
+	if entry, exists := self.hash[toMapKey(key)]; exists {
+		return entry.value, true
+	}
+	return UndefinedValue(), false
+}
+
+func (self *_mapObject) has(key Value) bool {
+	_, exists := self.hash[toMapKey(key)]
+	return exists
+}
+
+// set sets the value of key, which is added (at the end) if it is not already
+// an entry, returning whether it was added
+func (self *_mapObject) set(key Value, value Value) bool {
+	mapKey := toMapKey(key)
+	if entry, exists := self.hash[mapKey]; exists {
+		entry.value = value
+		return false
+	}
+	if key._valueType == valueNumber && mapKey.value == float64(0) {
+		key = toValue_int(0) // -0 is added as +0
+	}
+	entry := &_mapEntry{
+		key:   key,
+		value: value,
+	}
+	self.append(mapKey, entry)
+	return true
+}
+
+func (self *_mapObject) append(mapKey _mapKey, entry *_mapEntry) {
+	entry.previous = self.last
+	if self.last != nil {
+		self.last.next = entry
+	} else {
+		self.first = entry
+	}
+	self.last = entry
+	self.hash[mapKey] = entry
+}
+
+func (self *_mapObject) delete(key Value) bool {
+	mapKey := toMapKey(key)
+	entry, exists := self.hash[mapKey]
+	if !exists {
+		return false
+	}
+	delete(self.hash, mapKey)
+	entry.deleted = true
+	if entry.previous != nil {
+		entry.previous.next = entry.next
+	} else {
+		self.first = entry.next
+	}
+	if entry.next != nil {
+		entry.next.previous = entry.previous
+	} else {
+		self.last = entry.previous
+	}
+	return true
+}
+
+func (self *_mapObject) clear() {
+	for entry := self.first; entry != nil; entry = entry.next {
+		entry.deleted = true
+		entry.previous = nil
+	}
+	self.hash = map[_mapKey]*_mapEntry{}
+	self.first = nil
+	self.last = nil
+}
+
+// forEach calls fn for each entry, including any that are added during the
+// iteration, and excluding any that are deleted before they are reached
+func (self *_mapObject) forEach(fn func(*_mapEntry)) {
+	var entry *_mapEntry
+	for {
+		entry = self.nextEntry(entry)
+		if entry == nil {
+			return
+		}
+		fn(entry)
+	}
+}
+
+// nextEntry returns the entry after entry (or the first entry, if entry is nil),
+// or nil if there are no more
+func (self *_mapObject) nextEntry(entry *_mapEntry) *_mapEntry {
+	// If the entry has been deleted, then go back to one that has not
+	for entry != nil && entry.deleted {
+		entry = entry.previous
+	}
+	if entry == nil {
+		return self.first
+	}
+	return entry.next
+}
+
+func (self0 *_mapObject) clone(clone *_clone) *_mapObject {
+	self1 := &_mapObject{
+		hash: make(map[_mapKey]*_mapEntry, len(self0.hash)),
+	}
+	for entry := self0.first; entry != nil; entry = entry.next {
+		entry := clone.mapEntry(entry)
+		self1.append(toMapKey(entry.key), entry)
+	}
+	return self1
+}
+
+// exportMap returns the entries of a Map as a map[string]interface{}, if every key
+// is a string, or else as a map[interface{}]interface{}, where a number key is a
+// float64 (so 1 is the same key, whatever its Go type), and a key that cannot be
+// the key of a Go map once it is exported (an object or a symbol) is an otto.Value
+func (self *_mapObject) exportMap() interface{} {
+	stringKey := true
+	for entry := self.first; entry != nil; entry = entry.next {
+		if !entry.key.IsString() {
+			stringKey = false
+			break
+		}
+	}
+	if stringKey {
+		result := make(map[string]interface{}, self.size())
+		for entry := self.first; entry != nil; entry = entry.next {
+			result[toString(entry.key)] = entry.value.export()
+		}
+		return result
+	}
+	result := make(map[interface{}]interface{}, self.size())
+	for entry := self.first; entry != nil; entry = entry.next {
+		var key interface{} = entry.key
+		switch entry.key._valueType {
+		case valueNumber:
+			key = toFloat(entry.key)
+		case valueUndefined, valueNull, valueBoolean, valueString:
+			key = entry.key.export()
+		}
+		result[key] = entry.value.export()
+	}
+	return result
+}
+
+// exportSet returns the values of a Set as a []interface{}, in insertion order
+func (self *_mapObject) exportSet() interface{} {
+	result := make([]interface{}, 0, self.size())
+	for entry := self.first; entry != nil; entry = entry.next {
+		result = append(result, entry.key.export())
+	}
+	return result
+}
+
+// _mapIteratorObject is the state of an iterator of a Map or Set, from keys,
+// values, or entries
+type _mapIteratorObject struct {
+	object *_object   // The Map or Set, or nil once the iterator is done
+	entry  *_mapEntry // The entry last visited, or nil if none has been
+	kind   string     // "keys", "values", or "entries"
+}
+
+func (runtime *_runtime) newMapIterator(object *_object, kind string) *_object {
+	self := runtime.newObject()
+	if object.class == "Set" {
+		self.prototype = runtime.Global.SetIteratorPrototype
+	} else {
+		self.prototype = runtime.Global.MapIteratorPrototype
+	}
+	self.value = &_mapIteratorObject{
+		object: object,
+		kind:   kind,
+	}
+	return self
+}
+
+// next returns the next entry, or nil if the iterator is done
+func (self *_mapIteratorObject) next() *_mapEntry {
+	if self.object == nil {
+		return nil
+	}
+	entry := self.object.mapValue().nextEntry(self.entry)
+	if entry == nil {
+		self.object = nil
+	}
+	self.entry = entry
+	return entry
+}
+
+func (self0 *_mapIteratorObject) clone(clone *_clone) *_mapIteratorObject {
+	self1 := *self0
+	if self0.object != nil {
+		self1.object = clone.object(self0.object)
+	}
+	// Only the entries still in the Map are cloned, so start from the last of those
+	entry := self0.entry
+	for entry != nil && entry.deleted {
+		entry = entry.previous
+	}
+	if entry != nil {
+		self1.entry = clone.mapEntry(entry)
+	}
+	return &self1
+}
+
+// _weakMapObject is the value of a WeakMap or WeakSet
+//
+// The entries are not kept by the WeakMap, but by each key (see _object.weakValue),
+// so that an entry is collected along with its key
+type _weakMapObject struct{}
+
+func (runtime *_runtime) newWeakMapObject(class string) *_object {
+	self := runtime.newObject()
+	self.class = class
+	self.value = _weakMapObject{}
+	return self
+}
+
+// canBeHeldWeakly returns whether value can be a key of a WeakMap (or WeakSet)
+func canBeHeldWeakly(value Value) bool {
+	return value.IsObject()
+}
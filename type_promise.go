@@ -0,0 +1,266 @@
+package otto
+
+type _promiseState int
+
+const (
+	promisePending _promiseState = iota
+	promiseFulfilled
+	promiseRejected
+)
+
+type _promiseObject struct {
+	state            _promiseState
+	result           Value // The value (fulfilled) or reason (rejected)
+	fulfillReactions []*_promiseReaction
+	rejectReactions  []*_promiseReaction
+	handled          bool // A reaction has been added, so a rejection is handled
+
+	// Where the reason was thrown, if it is known (for an unhandled rejection)
+	position _position
+	stack    []_frame
+}
+
+// _promiseReaction is a handler waiting on the settlement of a promise, along
+// with the capability of the (derived) promise that the handler settles
+type _promiseReaction struct {
+	capability *_promiseCapability
+	handler    Value // The handler, which may not be callable (pass through)
+	reject     bool
+}
+
+// _promiseCapability is a promise, along with the functions to resolve or reject it
+type _promiseCapability struct {
+	promise Value
+	resolve Value
+	reject  Value
+}
+
+func (runtime *_runtime) newPromiseObject() *_object {
+	self := runtime.newObject()
+	self.class = "Promise"
+	self.value = &_promiseObject{}
+	return self
+}
+
+func (self *_object) promiseValue() *_promiseObject {
+	value, _ := self.value.(*_promiseObject)
+	return value
+}
+
+func (self0 *_promiseObject) clone(clone *_clone) *_promiseObject {
+	self1 := &_promiseObject{
+		state:    self0.state,
+		result:   clone.value(self0.result),
+		handled:  self0.handled,
+		position: self0.position,
+		stack:    self0.stack,
+	}
+	reactions := func(reactionList []*_promiseReaction) []*_promiseReaction {
+		result := make([]*_promiseReaction, len(reactionList))
+		for index, reaction := range reactionList {
+			result[index] = &_promiseReaction{
+				capability: reaction.capability.clone(clone),
+				handler:    clone.value(reaction.handler),
+				reject:     reaction.reject,
+			}
+		}
+		return result
+	}
+	self1.fulfillReactions = reactions(self0.fulfillReactions)
+	self1.rejectReactions = reactions(self0.rejectReactions)
+	return self1
+}
+
+func (self0 *_promiseCapability) clone(clone *_clone) *_promiseCapability {
+	if self0 == nil {
+		return nil
+	}
+	return &_promiseCapability{
+		promise: clone.value(self0.promise),
+		resolve: clone.value(self0.resolve),
+		reject:  clone.value(self0.reject),
+	}
+}
+
+func isPromise(value Value) bool {
+	object := value._object()
+	return object != nil && object.promiseValue() != nil
+}
+
+// newResolvingFunctions returns the resolve and reject functions for promise,
+// which share the state of whether the promise has already been resolved
+func (runtime *_runtime) newResolvingFunctions(promise *_object) (*_object, *_object) {
+	alreadyResolved := false
+	resolve := runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+		if !alreadyResolved {
+			alreadyResolved = true
+			runtime.resolvePromise(promise, call.Argument(0))
+		}
+		return UndefinedValue()
+	})
+	reject := runtime.newBuiltinFunction(1, func(call FunctionCall) Value {
+		if !alreadyResolved {
+			alreadyResolved = true
+			runtime.rejectPromise(promise, call.Argument(0))
+		}
+		return UndefinedValue()
+	})
+	return resolve, reject
+}
+
+func (runtime *_runtime) resolvePromise(promise *_object, resolution Value) {
+	if !resolution.IsObject() {
+		runtime.fulfillPromise(promise, resolution)
+		return
+	}
+	if resolution._object() == promise {
+		runtime.rejectPromise(promise, toValue_object(runtime.newTypeError(toValue_string("Chaining cycle detected for promise"))))
+		return
+	}
+	then, exception := runtime.tryCatchEvaluate(func() Value {
+		return resolution._object().get("then")
+	})
+	if exception {
+		runtime.rejectPromise(promise, then)
+		return
+	}
+	if !then.isCallable() {
+		runtime.fulfillPromise(promise, resolution)
+		return
+	}
+	runtime.enqueueJob(func() {
+		// Resolve the promise with the thenable (after the current JavaScript)
+		resolve, reject := runtime.newResolvingFunctions(promise)
+		value, exception := runtime.tryCatchEvaluate(func() Value {
+			return runtime.Call(then._object(), resolution, []Value{toValue_object(resolve), toValue_object(reject)}, false)
+		})
+		if exception {
+			runtime.Call(reject, UndefinedValue(), []Value{value}, false)
+		}
+	})
+}
+
+func (runtime *_runtime) fulfillPromise(promise *_object, value Value) {
+	self := promise.promiseValue()
+	reactionList := self.fulfillReactions
+	self.state, self.result = promiseFulfilled, value
+	self.fulfillReactions, self.rejectReactions = nil, nil
+	for _, reaction := range reactionList {
+		runtime.enqueuePromiseReaction(reaction, value)
+	}
+}
+
+func (runtime *_runtime) rejectPromise(promise *_object, reason Value) {
+	self := promise.promiseValue()
+	reactionList := self.rejectReactions
+	self.state, self.result = promiseRejected, reason
+	self.fulfillReactions, self.rejectReactions = nil, nil
+	if !self.handled {
+		runtime.unhandledRejections = append(runtime.unhandledRejections, promise)
+	}
+	for _, reaction := range reactionList {
+		runtime.enqueuePromiseReaction(reaction, reason)
+	}
+}
+
+func (runtime *_runtime) enqueuePromiseReaction(reaction *_promiseReaction, argument Value) {
+	runtime.enqueueJob(func() {
+		value, exception := argument, reaction.reject
+		if reaction.handler.isCallable() {
+			value, exception = runtime.tryCatchEvaluate(func() Value {
+				return runtime.Call(reaction.handler._object(), UndefinedValue(), []Value{argument}, false)
+			})
+		}
+		if reaction.capability == nil {
+			return
+		}
+		if exception {
+			runtime.Call(reaction.capability.reject._object(), UndefinedValue(), []Value{value}, false)
+		} else {
+			runtime.Call(reaction.capability.resolve._object(), UndefinedValue(), []Value{value}, false)
+		}
+	})
+}
+
+// performPromiseThen adds the reactions for onFulfilled and onRejected to promise,
+// returning the promise of capability (if any)
+func (runtime *_runtime) performPromiseThen(promise *_object, onFulfilled, onRejected Value, capability *_promiseCapability) Value {
+	self := promise.promiseValue()
+	self.handled = true
+	fulfillReaction := &_promiseReaction{capability: capability, handler: onFulfilled}
+	rejectReaction := &_promiseReaction{capability: capability, handler: onRejected, reject: true}
+	switch self.state {
+	case promisePending:
+		self.fulfillReactions = append(self.fulfillReactions, fulfillReaction)
+		self.rejectReactions = append(self.rejectReactions, rejectReaction)
+	case promiseFulfilled:
+		runtime.enqueuePromiseReaction(fulfillReaction, self.result)
+	case promiseRejected:
+		runtime.enqueuePromiseReaction(rejectReaction, self.result)
+	}
+	if capability == nil {
+		return UndefinedValue()
+	}
+	return capability.promise
+}
+
+// newPromiseCapability creates a new promise by calling constructor, which is
+// usually Promise (but may be a subclass, or something promise-like)
+func (runtime *_runtime) newPromiseCapability(constructor Value) *_promiseCapability {
+	if constructor._object() == runtime.Global.Promise {
+		promise := runtime.newPromise()
+		resolve, reject := runtime.newResolvingFunctions(promise)
+		return &_promiseCapability{
+			promise: toValue_object(promise),
+			resolve: toValue_object(resolve),
+			reject:  toValue_object(reject),
+		}
+	}
+
+	if !constructor.isCallable() {
+		panic(newTypeError("%v is not a constructor", constructor))
+	}
+	capability := &_promiseCapability{resolve: UndefinedValue(), reject: UndefinedValue()}
+	executor := runtime.newBuiltinFunction(2, func(call FunctionCall) Value {
+		if capability.resolve.IsDefined() || capability.reject.IsDefined() {
+			panic(newTypeError("Promise executor has already been invoked"))
+		}
+		capability.resolve = call.Argument(0)
+		capability.reject = call.Argument(1)
+		return UndefinedValue()
+	})
+	capability.promise = constructor._object().Construct(UndefinedValue(), toValue_object(executor))
+	if !capability.resolve.isCallable() || !capability.reject.isCallable() {
+		panic(newTypeError("Promise resolve or reject function is not callable"))
+	}
+	return capability
+}
+
+// checkUnhandledRejections throws the reason of the first promise that has been rejected,
+// and still has no handler, once the jobs have been run (see runJobs)
+func (runtime *_runtime) checkUnhandledRejections() {
+	rejectionList := runtime.unhandledRejections
+	runtime.unhandledRejections = nil
+	for _, promise := range rejectionList {
+		if self := promise.promiseValue(); !self.handled {
+			panic(&_exception{
+				value:    self.result,
+				position: self.position,
+				stack:    self.stack,
+			})
+		}
+	}
+}
+
+// promiseResolve returns value if it is already a promise made by constructor,
+// otherwise a new promise (by constructor) that is resolved with value
+func (runtime *_runtime) promiseResolve(constructor *_object, value Value) Value {
+	if isPromise(value) {
+		if value._object().get("constructor")._object() == constructor {
+			return value
+		}
+	}
+	capability := runtime.newPromiseCapability(toValue_object(constructor))
+	runtime.Call(capability.resolve._object(), UndefinedValue(), []Value{value}, false)
+	return capability.promise
+}
@@ -0,0 +1,435 @@
+package otto
+
+// _proxyObject is the state of a Proxy, where each operation on the proxy (see _classProxy)
+// is passed on to the trap of the handler for the operation, or else (if the handler has no
+// such trap) to the target
+//
+// A Proxy of a function is a function, so its value is a _functionObject, where the
+// _proxyObject is kept by the _proxyCallFunction (see proxyValue)
+type _proxyObject struct {
+	target  *_object // nil, once revoked
+	handler *_object // nil, once revoked
+}
+
+func (runtime *_runtime) newProxy(target Value, handler Value) *_object {
+	if !target.IsObject() || !handler.IsObject() {
+		panic(newTypeError("Cannot create proxy with a non-object as target or handler"))
+	}
+	self := newObject(runtime, "Object")
+	self.objectClass = _classProxy
+	proxy := &_proxyObject{
+		target:  target._object(),
+		handler: handler._object(),
+	}
+	self.value = proxy
+	if function := target._object().functionValue(); function.call != nil {
+		self.class = "Function"
+		value := _functionObject{
+			call: &_proxyCallFunction{proxy},
+		}
+		if function.construct != nil {
+			value.construct = proxyConstructFunction
+		}
+		self.value = value
+	}
+	return self
+}
+
+func (self *_object) proxyValue() *_proxyObject {
+	switch value := self.value.(type) {
+	case *_proxyObject:
+		return value
+	case _functionObject:
+		if call, valid := value.call.(*_proxyCallFunction); valid {
+			return call.proxy
+		}
+	}
+	return nil
+}
+
+func (self *_proxyObject) revoke() {
+	self.target = nil
+	self.handler = nil
+}
+
+// trap returns the trap of the handler for name, or nil if the handler has none,
+// throwing a TypeError if the proxy has been revoked
+func (self *_proxyObject) trap(name string) *_object {
+	if self.handler == nil {
+		panic(newTypeError("Cannot perform '%s' on a proxy that has been revoked", name))
+	}
+	trap := self.handler.get(name)
+	if !trap.IsDefined() || trap.IsNull() {
+		return nil
+	}
+	if !trap.isCallable() {
+		panic(newTypeError("'%s' on proxy: trap %v is not a function", name, trap))
+	}
+	return trap._object()
+}
+
+func (self *_proxyObject) call(trap *_object, argumentList ...Value) Value {
+	return trap.runtime.Call(trap, toValue_object(self.handler), argumentList, false)
+}
+
+// Each trap is checked against the target, so that a proxy cannot report what the target
+// does not allow (e.g. that a property which is not configurable does not exist)
+
+func proxyGetOwnProperty(self *_object, name string) *_property {
+	proxy := self.proxyValue()
+	trap := proxy.trap("getOwnPropertyDescriptor")
+	target := proxy.target
+	if trap == nil {
+		return target.getOwnProperty(name)
+	}
+	key := propertyKeyValue(name)
+	result := proxy.call(trap, toValue_object(target), key)
+	targetProperty := target.getOwnProperty(name)
+	if !result.IsObject() {
+		if result.IsDefined() {
+			panic(newTypeError("'getOwnPropertyDescriptor' on proxy: trap returned neither an object nor undefined for property %v", key))
+		}
+		if targetProperty != nil && (!targetProperty.configurable() || !target.isExtensible()) {
+			panic(newTypeError("'getOwnPropertyDescriptor' on proxy: trap returned undefined for property %v, which the proxy target cannot be without", key))
+		}
+		return nil
+	}
+	property := toPropertyDescriptor(result)
+	completePropertyDescriptor(&property)
+	if targetProperty == nil && !target.isExtensible() {
+		panic(newTypeError("'getOwnPropertyDescriptor' on proxy: trap returned a descriptor for property %v, which the non-extensible proxy target does not have", key))
+	}
+	if !property.configurable() && (targetProperty == nil || targetProperty.configurable()) {
+		panic(newTypeError("'getOwnPropertyDescriptor' on proxy: trap reported property %v as non-configurable, which it is not on the proxy target", key))
+	}
+	return &property
+}
+
+// proxyGetProperty is getProperty, where a proxy (in the prototype chain of an object)
+// is consulted by its getOwnPropertyDescriptor and getPrototypeOf traps, rather than by
+// its has or get traps, as the object that the property is for is not passed on
+func proxyGetProperty(self *_object, name string) *_property {
+	if property := self.getOwnProperty(name); property != nil {
+		return property
+	}
+	if prototype := self.getPrototypeOf(); prototype != nil {
+		return prototype.getProperty(name)
+	}
+	return nil
+}
+
+func proxyGet(self *_object, name string) Value {
+	return self.proxyValue().get(name, toValue_object(self))
+}
+
+// get is the get trap, where receiver is the object that the property is got for
+// (the this of a getter)
+func (self *_proxyObject) get(name string, receiver Value) Value {
+	trap := self.trap("get")
+	target := self.target
+	if trap == nil {
+		return target.getFor(name, receiver)
+	}
+	key := propertyKeyValue(name)
+	value := self.call(trap, toValue_object(target), key, receiver)
+	if property := target.getOwnProperty(name); property != nil && !property.configurable() {
+		switch propertyValue := property.value.(type) {
+		case Value:
+			if !property.writable() && !sameValue(value, propertyValue) {
+				panic(newTypeError("'get' on proxy: property %v is read-only and non-configurable on the proxy target, but the proxy did not return its value", key))
+			}
+		case _propertyGetSet:
+			if propertyValue[0] == nil && value.IsDefined() {
+				panic(newTypeError("'get' on proxy: property %v is a non-configurable accessor without a getter on the proxy target, but the proxy did not return undefined", key))
+			}
+		}
+	}
+	return value
+}
+
+// proxyCanPut is always true, as whether the property can be put is up to the set trap
+// (or the target), which is only known once it is put
+func proxyCanPut(self *_object, name string) bool {
+	return true
+}
+
+func proxyPut(self *_object, name string, value Value, throw bool) {
+	if !self.proxyValue().set(name, value, toValue_object(self)) && throw {
+		panic(newTypeError("Cannot set property %v of a proxy", propertyKeyValue(name)))
+	}
+}
+
+// set is the set trap, returning whether the property was set, where receiver is
+// the object that the property is set for (the this of a setter)
+func (self *_proxyObject) set(name string, value Value, receiver Value) bool {
+	trap := self.trap("set")
+	target := self.target
+	if trap == nil {
+		return target.setFor(name, value, receiver)
+	}
+	key := propertyKeyValue(name)
+	if !self.call(trap, toValue_object(target), key, value, receiver).toBoolean() {
+		return false
+	}
+	if property := target.getOwnProperty(name); property != nil && !property.configurable() {
+		switch propertyValue := property.value.(type) {
+		case Value:
+			if !property.writable() && !sameValue(value, propertyValue) {
+				panic(newTypeError("'set' on proxy: property %v is read-only and non-configurable on the proxy target, but the proxy set it to another value", key))
+			}
+		case _propertyGetSet:
+			if propertyValue[1] == nil {
+				panic(newTypeError("'set' on proxy: property %v is a non-configurable accessor without a setter on the proxy target, but the proxy set it", key))
+			}
+		}
+	}
+	return true
+}
+
+func proxyHasProperty(self *_object, name string) bool {
+	proxy := self.proxyValue()
+	trap := proxy.trap("has")
+	target := proxy.target
+	if trap == nil {
+		return target.hasProperty(name)
+	}
+	key := propertyKeyValue(name)
+	if proxy.call(trap, toValue_object(target), key).toBoolean() {
+		return true
+	}
+	if property := target.getOwnProperty(name); property != nil {
+		if !property.configurable() || !target.isExtensible() {
+			panic(newTypeError("'has' on proxy: trap returned false for property %v, which the proxy target cannot be without", key))
+		}
+	}
+	return false
+}
+
+func proxyDefineOwnProperty(self *_object, name string, descriptor _property, throw bool) bool {
+	proxy := self.proxyValue()
+	trap := proxy.trap("defineProperty")
+	target := proxy.target
+	if trap == nil {
+		return target.defineOwnProperty(name, descriptor, throw)
+	}
+	key := propertyKeyValue(name)
+	if !proxy.call(trap, toValue_object(target), key, toValue_object(self.runtime.fromPartialPropertyDescriptor(descriptor))).toBoolean() {
+		if throw {
+			panic(newTypeError("'defineProperty' on proxy: trap returned false for property %v", key))
+		}
+		return false
+	}
+	targetProperty := target.getOwnProperty(name)
+	if targetProperty == nil && !target.isExtensible() {
+		panic(newTypeError("'defineProperty' on proxy: trap returned true for adding property %v to the non-extensible proxy target", key))
+	}
+	if descriptor.configureSet() && !descriptor.configurable() && (targetProperty == nil || targetProperty.configurable()) {
+		panic(newTypeError("'defineProperty' on proxy: trap returned true for defining property %v as non-configurable, which it is not on the proxy target", key))
+	}
+	return true
+}
+
+func proxyDelete(self *_object, name string, throw bool) bool {
+	proxy := self.proxyValue()
+	trap := proxy.trap("deleteProperty")
+	target := proxy.target
+	if trap == nil {
+		return target.delete(name, throw)
+	}
+	key := propertyKeyValue(name)
+	if !proxy.call(trap, toValue_object(target), key).toBoolean() {
+		if throw {
+			panic(newTypeError("'deleteProperty' on proxy: trap returned false for property %v", key))
+		}
+		return false
+	}
+	if property := target.getOwnProperty(name); property != nil && !property.configurable() {
+		panic(newTypeError("'deleteProperty' on proxy: trap returned true for property %v, which is non-configurable on the proxy target", key))
+	}
+	return true
+}
+
+// proxyEnumerate enumerates the keys of the ownKeys trap, where (unless all) only
+// those that getOwnProperty has as enumerable are enumerated
+func proxyEnumerate(self *_object, all bool, each func(string) bool) {
+	for _, name := range self.proxyValue().ownKeys() {
+		if !all {
+			if property := self.getOwnProperty(name); property == nil || !property.enumerable() {
+				continue
+			}
+		}
+		if !each(name) {
+			return
+		}
+	}
+}
+
+func (self *_proxyObject) ownKeys() []string {
+	trap := self.trap("ownKeys")
+	target := self.target
+	targetKeys := []string{}
+	target.enumerate(true, func(name string) bool {
+		targetKeys = append(targetKeys, name)
+		return true
+	})
+	if trap == nil {
+		return targetKeys
+	}
+
+	result := self.call(trap, toValue_object(target))
+	if !result.IsObject() {
+		panic(newTypeError("'ownKeys' on proxy: trap returned %v, which is not an object", result))
+	}
+	object := result._object()
+	length := toUint32(object.get("length"))
+	keys := make([]string, 0, length)
+	exists := make(map[string]bool, length)
+	for index := uint32(0); index < length; index++ {
+		value := object.get(arrayIndexToString(int64(index)))
+		if !value.IsString() && !value.IsSymbol() {
+			panic(newTypeError("'ownKeys' on proxy: %v is not a valid property name", value))
+		}
+		name := toPropertyKey(value)
+		if exists[name] {
+			panic(newTypeError("'ownKeys' on proxy: trap returned duplicate entries"))
+		}
+		exists[name] = true
+		keys = append(keys, name)
+	}
+
+	extensible := target.isExtensible()
+	for _, name := range targetKeys {
+		if exists[name] {
+			delete(exists, name)
+			continue
+		}
+		if property := target.getOwnProperty(name); !extensible || (property != nil && !property.configurable()) {
+			panic(newTypeError("'ownKeys' on proxy: trap result did not include %v", propertyKeyValue(name)))
+		}
+	}
+	if !extensible && len(exists) > 0 {
+		panic(newTypeError("'ownKeys' on proxy: trap returned extra keys, but the proxy target is non-extensible"))
+	}
+	return keys
+}
+
+func proxyGetPrototypeOf(self *_object) *_object {
+	proxy := self.proxyValue()
+	trap := proxy.trap("getPrototypeOf")
+	target := proxy.target
+	if trap == nil {
+		return target.getPrototypeOf()
+	}
+	result := proxy.call(trap, toValue_object(target))
+	if !result.IsObject() && !result.IsNull() {
+		panic(newTypeError("'getPrototypeOf' on proxy: trap returned %v, which is neither an object nor null", result))
+	}
+	prototype := result._object()
+	if !target.isExtensible() && prototype != target.getPrototypeOf() {
+		panic(newTypeError("'getPrototypeOf' on proxy: proxy target is non-extensible, but the trap did not return its actual prototype"))
+	}
+	return prototype
+}
+
+func proxySetPrototypeOf(self *_object, prototype *_object) bool {
+	proxy := self.proxyValue()
+	trap := proxy.trap("setPrototypeOf")
+	target := proxy.target
+	if trap == nil {
+		return target.setPrototypeOf(prototype)
+	}
+	prototypeValue := NullValue()
+	if prototype != nil {
+		prototypeValue = toValue_object(prototype)
+	}
+	if !proxy.call(trap, toValue_object(target), prototypeValue).toBoolean() {
+		return false
+	}
+	if !target.isExtensible() && prototype != target.getPrototypeOf() {
+		panic(newTypeError("'setPrototypeOf' on proxy: trap returned true for setting a new prototype on the non-extensible proxy target"))
+	}
+	return true
+}
+
+func proxyIsExtensible(self *_object) bool {
+	proxy := self.proxyValue()
+	trap := proxy.trap("isExtensible")
+	target := proxy.target
+	if trap == nil {
+		return target.isExtensible()
+	}
+	result := proxy.call(trap, toValue_object(target)).toBoolean()
+	if result != target.isExtensible() {
+		panic(newTypeError("'isExtensible' on proxy: trap result does not reflect extensibility of proxy target (which is '%v')", target.isExtensible()))
+	}
+	return result
+}
+
+func proxyPreventExtensions(self *_object) bool {
+	proxy := self.proxyValue()
+	trap := proxy.trap("preventExtensions")
+	target := proxy.target
+	if trap == nil {
+		return target.preventExtensions()
+	}
+	result := proxy.call(trap, toValue_object(target)).toBoolean()
+	if result && target.isExtensible() {
+		panic(newTypeError("'preventExtensions' on proxy: trap returned true but the proxy target is extensible"))
+	}
+	return result
+}
+
+func (self0 *_proxyObject) clone(clone *_clone) *_proxyObject {
+	self1 := &_proxyObject{}
+	if self0.target != nil {
+		self1.target = clone.object(self0.target)
+		self1.handler = clone.object(self0.handler)
+	}
+	return self1
+}
+
+// _proxyCallFunction is the call of a Proxy of a function, which is passed on to
+// the apply trap (or else to the target)
+type _proxyCallFunction struct {
+	proxy *_proxyObject
+}
+
+func (self *_proxyCallFunction) Dispatch(_ *_object, _ *_functionEnvironment, runtime *_runtime, this Value, argumentList []Value, _ bool) Value {
+	trap := self.proxy.trap("apply")
+	target := self.proxy.target
+	if trap == nil {
+		return runtime.Call(target, this, argumentList, false)
+	}
+	return self.proxy.call(trap, toValue_object(target), this, toValue_object(runtime.newArrayOf(argumentList)))
+}
+
+func (self *_proxyCallFunction) ScopeEnvironment() _environment {
+	return nil
+}
+
+func (self *_proxyCallFunction) Source() string {
+	return ""
+}
+
+func (self0 *_proxyCallFunction) clone(clone *_clone) _callFunction {
+	return &_proxyCallFunction{
+		proxy: self0.proxy.clone(clone),
+	}
+}
+
+// proxyConstructFunction is the construct of a Proxy of a constructor, which is
+// passed on to the construct trap (or else to the target), where the proxy is the
+// constructor that new was applied to
+func proxyConstructFunction(self *_object, _ Value, argumentList []Value) Value {
+	proxy := self.proxyValue()
+	trap := proxy.trap("construct")
+	target := proxy.target
+	if trap == nil {
+		return self.runtime.construct(target, self, argumentList)
+	}
+	result := proxy.call(trap, toValue_object(target), toValue_object(self.runtime.newArrayOf(argumentList)), toValue_object(self))
+	if !result.IsObject() {
+		panic(newTypeError("'construct' on proxy: trap returned %v, which is not an object", result))
+	}
+	return result
+}
@@ -30,8 +30,9 @@ func (self _referenceDefault) IsStrict() bool {
 
 type _propertyReference struct {
 	_referenceDefault
-	Base *_object
-	node _node
+	Base        *_object
+	node        _node
+	environment *_objectEnvironment // The environment that the name was resolved in, if any (see ImplicitThisValue)
 }
 
 func newPropertyReference(base *_object, name string, strict bool, node _node) *_propertyReference {
@@ -80,6 +81,76 @@ func (self *_propertyReference) Delete() bool {
 	return self.Base.delete(self.name, self.IsStrict())
 }
 
+// SuperReference
+
+// _superReference is a property of the prototype of the home object of a method, super.abc,
+// which is got (or set) with the this of the method
+type _superReference struct {
+	_referenceDefault
+	Base *_object
+	this Value
+	node _node
+}
+
+func newSuperReference(base *_object, name string, this Value, node _node) *_superReference {
+	return &_superReference{
+		Base: base,
+		_referenceDefault: _referenceDefault{
+			name:   name,
+			strict: true,
+		},
+		this: this,
+		node: node,
+	}
+}
+
+func (self *_superReference) GetBase() interface{} {
+	return self.Base
+}
+
+func (self *_superReference) IsUnresolvable() bool {
+	return false
+}
+
+func (self *_superReference) IsPropertyReference() bool {
+	return true
+}
+
+func (self *_superReference) GetValue() Value {
+	if self.Base == nil {
+		return UndefinedValue()
+	}
+	property := self.Base.getProperty(self.name)
+	if property == nil {
+		return UndefinedValue()
+	}
+	return property.get(self.Base.runtime.toObject(self.this))
+}
+
+func (self *_superReference) PutValue(value Value) bool {
+	if self.Base != nil {
+		if property := self.Base.getProperty(self.name); property != nil {
+			if getSet, ok := property.value.(_propertyGetSet); ok {
+				if getSet[1] == nil {
+					panic(newTypeError("Cannot set property %s which has only a getter", self.name))
+				}
+				getSet[1].callSet(self.this, value)
+				return true
+			}
+		}
+	}
+	// Otherwise, the property is set on this (rather than on the prototype)
+	if !self.this.IsObject() {
+		panic(newTypeError("Cannot create property '%s' on %v", self.name, self.this))
+	}
+	self.this._object().put(self.name, value, true)
+	return true
+}
+
+func (self *_superReference) Delete() bool {
+	panic(newReferenceError("Unsupported reference to 'super'"))
+}
+
 // ArgumentReference
 
 func newArgumentReference(base *_object, name string, strict bool) *_propertyReference {
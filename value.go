@@ -20,6 +20,7 @@ const (
 	valueObject
 	valueResult
 	valueReference
+	valueSymbol
 )
 
 // Value is the representation of a JavaScript value.
@@ -113,6 +114,7 @@ func (value Value) Call(this Value, argumentList ...interface{}) (Value, error)
 	result := UndefinedValue()
 	err := catchPanic(func() {
 		result = value.call(this, argumentList...)
+		value._object().runtime.runJobs()
 	})
 	return result, err
 }
@@ -129,6 +131,7 @@ func (value Value) constructSafe(this Value, argumentList ...interface{}) (Value
 	result := UndefinedValue()
 	err := catchPanic(func() {
 		result = value.construct(this, argumentList...)
+		value._object().runtime.runJobs()
 	})
 	return result, err
 }
@@ -177,6 +180,11 @@ func (value Value) IsString() bool {
 	return value._valueType == valueString
 }
 
+// IsSymbol will return true if value is a symbol (primitive).
+func (value Value) IsSymbol() bool {
+	return value._valueType == valueSymbol
+}
+
 // IsObject will return true if value is an object.
 func (value Value) IsObject() bool {
 	return value._valueType == valueObject
@@ -374,6 +382,10 @@ func toValue(value interface{}) Value {
 //
 // This method will make return the empty string if there is an error.
 func (value Value) String() string {
+	if value._valueType == valueSymbol {
+		// Unlike toString, which throws a TypeError
+		return value.value.(_symbol).String()
+	}
 	result := ""
 	catchPanic(func() {
 		result = value.toString()
@@ -588,6 +600,8 @@ func sameValue(x Value, y Value) bool {
 		result = x.toBoolean() == y.toBoolean()
 	case valueObject:
 		result = x._object() == y._object()
+	case valueSymbol:
+		result = x.value.(_symbol).id == y.value.(_symbol).id
 	default:
 		panic(hereBeDragons())
 	}
@@ -617,6 +631,8 @@ func strictEqualityComparison(x Value, y Value) bool {
 		result = x.toBoolean() == y.toBoolean()
 	case valueObject:
 		result = x._object() == y._object()
+	case valueSymbol:
+		result = x.value.(_symbol).id == y.value.(_symbol).id
 	default:
 		panic(hereBeDragons())
 	}
@@ -641,6 +657,8 @@ func strictEqualityComparison(x Value, y Value) bool {
 //      string      -> string
 //      Array       -> []interface{}
 //      Object      -> map[string]interface{}
+//      Map         -> map[string]interface{} (if every key is a string), or map[interface{}]interface{}
+//      Set         -> []interface{}
 //
 func (self Value) Export() (interface{}, error) {
 	return self.export(), nil
@@ -673,6 +691,11 @@ func (self Value) export() interface{} {
 			return value.value.Interface()
 		case *_goSliceObject:
 			return value.value.Interface()
+		case *_mapObject:
+			if object.class == "Set" {
+				return value.exportSet()
+			}
+			return value.exportMap()
 		}
 		if object.class == "Array" {
 			result := make([]interface{}, 0)
@@ -691,6 +714,9 @@ func (self Value) export() interface{} {
 			result := make(map[string]interface{})
 			// TODO Should we export everything? Or just what is enumerable?
 			object.enumerate(false, func(name string) bool {
+				if isSymbolKey(name) {
+					return true
+				}
 				value := object.get(name)
 				if value.IsDefined() {
 					result[name] = value.export()
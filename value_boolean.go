@@ -32,6 +32,8 @@ func toBoolean(value Value) bool {
 		return true
 	case string:
 		return 0 != len(value)
+	case _symbol:
+		return true
 	}
 	if value.IsObject() {
 		return true
@@ -85,6 +85,8 @@ func toFloat(value Value) float64 {
 		return value
 	case string:
 		return stringToFloat(value)
+	case _symbol:
+		panic(newTypeError("Cannot convert a Symbol value to a number"))
 	case *_object:
 		return toFloat(value.DefaultValue(defaultValueHintNumber))
 	}
@@ -14,7 +14,7 @@ func toPrimitive(value Value) Value {
 
 func _toPrimitive(value Value, hint _defaultValueHint) Value {
 	switch value._valueType {
-	case valueNull, valueUndefined, valueNumber, valueString, valueBoolean:
+	case valueNull, valueUndefined, valueNumber, valueString, valueBoolean, valueSymbol:
 		return value
 	case valueObject:
 		return value._object().DefaultValue(hint)
@@ -94,6 +94,8 @@ func toString(value Value) string {
 		return string(utf16.Decode(value))
 	case string:
 		return value
+	case _symbol:
+		panic(newTypeError("Cannot convert a Symbol value to a string"))
 	case *_object:
 		return toString(value.DefaultValue(defaultValueHintString))
 	}
@@ -0,0 +1,103 @@
+package otto
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// _symbol is the value of a symbol (primitive), which is unique by its id
+type _symbol struct {
+	id          uint64
+	description string
+	described   bool // Whether there is a description, as Symbol() has none (unlike Symbol(""))
+}
+
+// The well-known symbols, which are shared by every runtime
+var (
+	symbolIterator    = _symbol{1, "Symbol.iterator", true}
+	symbolToPrimitive = _symbol{2, "Symbol.toPrimitive", true}
+	symbolToStringTag = _symbol{3, "Symbol.toStringTag", true}
+	symbolHasInstance = _symbol{4, "Symbol.hasInstance", true}
+)
+
+// The (property) key of each well-known symbol, see _symbol.key
+const (
+	symbolKeyIterator    = "\xff1\xffSymbol.iterator"
+	symbolKeyToPrimitive = "\xff2\xffSymbol.toPrimitive"
+	symbolKeyToStringTag = "\xff3\xffSymbol.toStringTag"
+	symbolKeyHasInstance = "\xff4\xffSymbol.hasInstance"
+)
+
+// symbolCount is the id of the last symbol created (after the well-known symbols)
+var symbolCount uint64 = 4
+
+func newSymbol(description Value) _symbol {
+	self := _symbol{
+		id: atomic.AddUint64(&symbolCount, 1),
+	}
+	if description.IsDefined() {
+		self.description = toString(description)
+		self.described = true
+	}
+	return self
+}
+
+// key returns the name of a property keyed by the symbol, which is the id of the
+// symbol (and its description), after a \xff (a byte that does not appear in a
+// property name that is a string, as it is not valid UTF-8)
+func (self _symbol) key() string {
+	key := "\xff" + strconv.FormatUint(self.id, 10)
+	if self.described {
+		key += "\xff" + self.description
+	}
+	return key
+}
+
+func (self _symbol) descriptionValue() Value {
+	if self.described {
+		return toValue_string(self.description)
+	}
+	return UndefinedValue()
+}
+
+func (self _symbol) String() string {
+	return "Symbol(" + self.description + ")"
+}
+
+// isSymbolKey returns whether name is the key of a property keyed by a symbol
+func isSymbolKey(name string) bool {
+	return len(name) > 0 && name[0] == '\xff'
+}
+
+// symbolOfKey returns the symbol of name, a key made by _symbol.key
+func symbolOfKey(name string) _symbol {
+	self := _symbol{}
+	id := name[1:]
+	if index := strings.IndexByte(id, '\xff'); index >= 0 {
+		self.description = id[index+1:]
+		self.described = true
+		id = id[:index]
+	}
+	self.id, _ = strconv.ParseUint(id, 10, 64)
+	return self
+}
+
+// propertyKeyValue returns the key of the property name, as a symbol (if name is
+// the key of a symbol) or a string
+func propertyKeyValue(name string) Value {
+	if isSymbolKey(name) {
+		return toValue_symbol(symbolOfKey(name))
+	}
+	return toValue_string(name)
+}
+
+// toPropertyKey converts value to the name of a property, which is the key of a
+// symbol, or a string
+func toPropertyKey(value Value) string {
+	value = toStringPrimitive(value)
+	if value._valueType == valueSymbol {
+		return value.value.(_symbol).key()
+	}
+	return toString(value)
+}